@@ -7,6 +7,7 @@ import (
 	"net/http"
 	nativeos "os"
 	"os/signal"
+	"path/filepath"
 	"sync"
 	"syscall"
 	"time"
@@ -29,6 +30,7 @@ import (
 	"github.com/qdm12/gluetun/internal/settings"
 	"github.com/qdm12/gluetun/internal/shadowsocks"
 	"github.com/qdm12/gluetun/internal/storage"
+	"github.com/qdm12/gluetun/internal/systemd"
 	"github.com/qdm12/gluetun/internal/unix"
 	"github.com/qdm12/gluetun/internal/updater"
 	versionpkg "github.com/qdm12/gluetun/internal/version"
@@ -55,6 +57,12 @@ func main() {
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
 
+	// the timezone is set before creating the logger for log timestamps to use it
+	if err := setTimezone(params.NewReader(nil, os.New())); err != nil {
+		fmt.Println(err)
+		nativeos.Exit(1)
+	}
+
 	logger, err := logging.NewLogger(logging.ConsoleEncoding, logging.InfoLevel)
 	if err != nil {
 		fmt.Println(err)
@@ -107,6 +115,20 @@ func main() {
 	nativeos.Exit(1)
 }
 
+// setTimezone sets the timezone of the program from the TZ environment variable.
+func setTimezone(paramsReader params.Reader) error {
+	timezone, err := paramsReader.GetTimezone()
+	if err != nil || len(timezone) == 0 {
+		return err
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return err
+	}
+	time.Local = location
+	return nil
+}
+
 //nolint:gocognit,gocyclo
 func _main(background context.Context, buildInfo models.BuildInformation,
 	args []string, logger logging.Logger, os os.OS, osUser user.OSUser, unix unix.Unix,
@@ -115,12 +137,24 @@ func _main(background context.Context, buildInfo models.BuildInformation,
 		switch args[1] {
 		case "healthcheck":
 			return cli.HealthCheck(background)
+		case "benchmark":
+			return cli.Benchmark(args[2:], os)
 		case "clientkey":
-			return cli.ClientKey(args[2:], os.OpenFile)
+			return cli.ClientKey(args[2:], os)
+		case "explain-selection":
+			return cli.ExplainSelection(os)
+		case "export-env":
+			return cli.ExportEnv(os)
+		case "inspect-connection":
+			return cli.InspectConnection(os)
 		case "openvpnconfig":
 			return cli.OpenvpnConfig(os)
+		case "resolvconf":
+			return cli.ResolvConf(os)
 		case "update":
 			return cli.Update(args[2:], os)
+		case "validate-servers":
+			return cli.ValidateServers(args[2:], os)
 		default:
 			return fmt.Errorf("command %q is unknown", args[1])
 		}
@@ -158,15 +192,34 @@ func _main(background context.Context, buildInfo models.BuildInformation,
 	}
 	logger.Info(allSettings.String())
 
-	if err := os.MkdirAll("/tmp/gluetun", 0644); err != nil {
+	if len(allSettings.OpenVPN.Scramble) > 0 {
+		if err := ovpnConf.CheckScramble(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, statusFilepath := range []models.Filepath{
+		allSettings.PublicIP.IPFilepath,
+		allSettings.OpenVPN.Provider.PortForwarding.Filepath,
+	} {
+		if len(statusFilepath) == 0 {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(string(statusFilepath)), 0644); err != nil {
+			return err
+		}
+	}
+	dataDir := allSettings.System.DataDir
+	if err := os.MkdirAll(dataDir, 0644); err != nil {
 		return err
 	}
-	if err := os.MkdirAll("/gluetun", 0644); err != nil {
+	if err := checkWritableDir(os, dataDir); err != nil {
 		return err
 	}
 
 	// TODO run this in a loop or in openvpn to reload from file without restarting
-	storage := storage.New(logger, os, constants.ServersData)
+	storage := storage.New(logger, os, filepath.Join(dataDir, constants.ServersDataFilename),
+		allSettings.System.StorageLockTimeout)
 	allServers, err := storage.SyncServers(constants.GetAllServers())
 	if err != nil {
 		return err
@@ -184,6 +237,30 @@ func _main(background context.Context, buildInfo models.BuildInformation,
 		logger.Info("using existing username %s corresponding to user id %d", nonRootUsername, puid)
 	}
 
+	if allSettings.System.RandomizeHostname {
+		hostname, err := alpineConf.SetRandomHostname()
+		if err != nil {
+			return err
+		}
+		logger.Debug("hostname set to %s", hostname)
+	}
+
+	var notifySocket string
+	if allSettings.System.SystemdNotify {
+		notifySocket = allSettings.System.SystemdNotifySocket
+		if len(notifySocket) == 0 {
+			logger.Warn("SYSTEMD_NOTIFY is on but NOTIFY_SOCKET is not set: systemd will not be notified")
+		}
+	}
+
+	if allSettings.System.DisableIPv6 {
+		if err := alpineConf.DisableIPv6(); err != nil {
+			logger.Warn(err)
+		} else {
+			logger.Info("IPv6 disabled")
+		}
+	}
+
 	if err := os.Chown("/etc/unbound", puid, pgid); err != nil {
 		return err
 	}
@@ -193,7 +270,24 @@ func _main(background context.Context, buildInfo models.BuildInformation,
 		routingConf.SetDebug()
 	}
 
-	defaultInterface, defaultGateway, err := routingConf.DefaultRoute()
+	if err := firewallConf.SetBackend(ctx, allSettings.Firewall.Backend); err != nil {
+		return err
+	}
+	firewallConf.SetRuleComment(allSettings.Firewall.RuleComment)
+	firewallConf.SetIPFamily(allSettings.System.IPFamily)
+
+	if err := routingConf.SetUplinkInterface(allSettings.Firewall.UplinkInterface); err != nil {
+		return err
+	}
+
+	var defaultInterface string
+	var defaultGateway net.IP
+	if timeout := allSettings.System.StartupWaitNetwork; timeout > 0 {
+		logger.Info("waiting up to %s for a default route", timeout)
+		defaultInterface, defaultGateway, err = routingConf.WaitDefaultRoute(ctx, timeout)
+	} else {
+		defaultInterface, defaultGateway, err = routingConf.DefaultRoute()
+	}
 	if err != nil {
 		return err
 	}
@@ -226,10 +320,25 @@ func _main(background context.Context, buildInfo models.BuildInformation,
 	if err := routingConf.SetOutboundRoutes(allSettings.Firewall.OutboundSubnets); err != nil {
 		return err
 	}
+	if err := firewallConf.SetInputSources(ctx, allSettings.Firewall.InputSources); err != nil {
+		return err
+	}
 
-	if err := ovpnConf.CheckTUN(); err != nil {
+	if proxy := allSettings.OpenVPN.PreConnectProxy; len(proxy.Type) > 0 {
+		// resolve the proxy host now since DNS is blocked once the firewall is enabled
+		proxyIP, err := resolveHost(ctx, proxy.Host)
+		if err != nil {
+			return fmt.Errorf("cannot resolve pre-connect proxy: %w", err)
+		}
+		allSettings.OpenVPN.PreConnectProxy.Host = proxyIP.String()
+		if err := firewallConf.SetPreConnectProxy(ctx, proxyIP, proxy.Port); err != nil {
+			return err
+		}
+	}
+
+	if err := ovpnConf.CheckTUN(allSettings.OpenVPN.TUNDevice); err != nil {
 		logger.Warn(err)
-		err = ovpnConf.CreateTUN()
+		err = ovpnConf.CreateTUN(allSettings.OpenVPN.TUNDevice)
 		if err != nil {
 			return err
 		}
@@ -241,6 +350,16 @@ func _main(background context.Context, buildInfo models.BuildInformation,
 	defer close(dnsReadyCh)
 
 	if allSettings.Firewall.Enabled {
+		if grace := allSettings.Firewall.StartupGrace; grace > 0 {
+			logger.Warn("allowing all traffic for %s before enabling the firewall", grace)
+			timer := time.NewTimer(grace)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			}
+		}
 		err := firewallConf.SetEnabled(ctx, true) // disabled by default
 		if err != nil {
 			return err
@@ -276,13 +395,17 @@ func _main(background context.Context, buildInfo models.BuildInformation,
 	go updaterLooper.Run(ctx, wg)
 
 	unboundLooper := dns.NewLooper(dnsConf, allSettings.DNS, httpClient,
-		logger, nonRootUsername, puid, pgid)
+		logger, nonRootUsername, puid, pgid, os.OpenFile)
 	wg.Add(1)
 	// wait for unboundLooper.Restart or its ticker launched with RunRestartTicker
 	go unboundLooper.Run(ctx, wg, dnsReadyCh)
 
+	reconnectVPN := func() {
+		_, _ = openvpnLooper.SetStatus(constants.Stopped)
+		_, _ = openvpnLooper.SetStatus(constants.Running)
+	}
 	publicIPLooper := publicip.NewLooper(
-		httpClient, logger, allSettings.PublicIP, puid, pgid, os)
+		httpClient, logger, allSettings.PublicIP, puid, pgid, os, reconnectVPN)
 	wg.Add(1)
 	go publicIPLooper.Run(ctx, wg)
 	wg.Add(1)
@@ -300,16 +423,18 @@ func _main(background context.Context, buildInfo models.BuildInformation,
 	go routeReadyEvents(ctx, wg, buildInfo, tunnelReadyCh, dnsReadyCh,
 		unboundLooper, updaterLooper, publicIPLooper, routingConf, logger, httpClient,
 		allSettings.VersionInformation, allSettings.OpenVPN.Provider.PortForwarding.Enabled, openvpnLooper.PortForward,
+		notifySocket,
 	)
 	controlServerAddress := fmt.Sprintf("0.0.0.0:%d", allSettings.ControlServer.Port)
 	controlServerLogging := allSettings.ControlServer.Log
 	httpServer := server.New(controlServerAddress, controlServerLogging,
-		logger, buildInfo, openvpnLooper, unboundLooper, updaterLooper, publicIPLooper)
+		logger, buildInfo, openvpnLooper, unboundLooper, updaterLooper, publicIPLooper,
+		firewallConf, routingConf)
 	wg.Add(1)
 	go httpServer.Run(ctx, wg)
 
 	healthcheckServer := healthcheck.NewServer(
-		constants.HealthcheckAddress, logger)
+		constants.HealthcheckAddress, allSettings.Health, logger)
 	wg.Add(1)
 	go healthcheckServer.Run(ctx, wg)
 
@@ -319,6 +444,10 @@ func _main(background context.Context, buildInfo models.BuildInformation,
 
 	<-ctx.Done()
 
+	if err := systemd.Notify(notifySocket, "STOPPING=1"); err != nil {
+		logger.Warn(err)
+	}
+
 	if allSettings.OpenVPN.Provider.PortForwarding.Enabled {
 		logger.Info("Clearing forwarded port status file %s", allSettings.OpenVPN.Provider.PortForwarding.Filepath)
 		if err := os.Remove(string(allSettings.OpenVPN.Provider.PortForwarding.Filepath)); err != nil {
@@ -331,6 +460,38 @@ func _main(background context.Context, buildInfo models.BuildInformation,
 	return nil
 }
 
+// checkWritableDir checks the directory is writable
+// by creating and removing a file in it.
+func checkWritableDir(fileManager os.OS, dir string) error {
+	path := filepath.Join(dir, ".gluetun-write-test")
+	file, err := fileManager.OpenFile(path, os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("directory %s is not writable: %w", dir, err)
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return fileManager.Remove(path)
+}
+
+// resolveHost returns the IP address of the host, which can already
+// be an IP address, preferring an IPv4 address if there is one.
+func resolveHost(ctx context.Context, host string) (ip net.IP, err error) {
+	if ip = net.ParseIP(host); ip != nil {
+		return ip, nil
+	}
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+	if err != nil {
+		return nil, err
+	}
+	for _, ip := range ips {
+		if ip.To4() != nil {
+			return ip, nil
+		}
+	}
+	return ips[0], nil
+}
+
 func printVersions(ctx context.Context, logger logging.Logger,
 	versionFunctions map[string]func(ctx context.Context) (string, error)) {
 	const timeout = 5 * time.Second
@@ -350,7 +511,8 @@ func routeReadyEvents(ctx context.Context, wg *sync.WaitGroup, buildInfo models.
 	tunnelReadyCh, dnsReadyCh <-chan struct{},
 	unboundLooper dns.Looper, updaterLooper updater.Looper, publicIPLooper publicip.Looper,
 	routing routing.Routing, logger logging.Logger, httpClient *http.Client,
-	versionInformation, portForwardingEnabled bool, startPortForward func(vpnGateway net.IP)) {
+	versionInformation, portForwardingEnabled bool, startPortForward func(vpnGateway net.IP),
+	notifySocket string) {
 	defer wg.Done()
 	tickerWg := &sync.WaitGroup{}
 	// for linters only
@@ -363,6 +525,10 @@ func routeReadyEvents(ctx context.Context, wg *sync.WaitGroup, buildInfo models.
 			tickerWg.Wait()
 			return
 		case <-tunnelReadyCh: // blocks until openvpn is connected
+			if err := systemd.Notify(notifySocket, "READY=1"); err != nil {
+				logger.Warn(err)
+			}
+			publicIPLooper.ExpectIPChange()
 			if unboundLooper.GetSettings().Enabled {
 				_, _ = unboundLooper.SetStatus(constants.Running)
 			}
@@ -7,6 +7,8 @@ import (
 
 type Configurator interface {
 	CreateUser(username string, uid int) (createdUsername string, err error)
+	SetRandomHostname() (hostname string, err error)
+	DisableIPv6() error
 }
 
 type configurator struct {
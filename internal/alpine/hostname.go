@@ -0,0 +1,46 @@
+package alpine
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"github.com/qdm12/golibs/os"
+)
+
+// SetRandomHostname sets a random hostname looking like a Docker
+// container short ID, and returns the hostname set.
+func (c *configurator) SetRandomHostname() (hostname string, err error) {
+	hostname, err = randomHostname(rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("cannot generate random hostname: %w", err)
+	}
+	for _, path := range []string{"/proc/sys/kernel/hostname", "/etc/hostname"} {
+		if err := c.writeHostname(path, hostname); err != nil {
+			return "", fmt.Errorf("cannot set hostname: %w", err)
+		}
+	}
+	return hostname, nil
+}
+
+func randomHostname(reader io.Reader) (hostname string, err error) {
+	const length = 6
+	b := make([]byte, length)
+	if _, err := io.ReadFull(reader, b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+func (c *configurator) writeHostname(path, hostname string) error {
+	file, err := c.openFile(path, os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(hostname + "\n"); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
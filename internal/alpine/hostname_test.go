@@ -0,0 +1,41 @@
+package alpine
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_randomHostname(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		random   []byte
+		hostname string
+		err      bool
+	}{
+		"enough bytes": {
+			random:   []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd},
+			hostname: "0123456789ab",
+		},
+		"not enough bytes": {
+			random: []byte{0x01},
+			err:    true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			hostname, err := randomHostname(bytes.NewReader(testCase.random))
+			if testCase.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, testCase.hostname, hostname)
+		})
+	}
+}
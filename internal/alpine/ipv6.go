@@ -0,0 +1,33 @@
+package alpine
+
+import (
+	"fmt"
+
+	"github.com/qdm12/golibs/os"
+)
+
+// DisableIPv6 disables IPv6 on all the current and future network
+// interfaces of the container using the kernel sysctl files.
+func (c *configurator) DisableIPv6() error {
+	for _, path := range []string{
+		"/proc/sys/net/ipv6/conf/all/disable_ipv6",
+		"/proc/sys/net/ipv6/conf/default/disable_ipv6",
+	} {
+		if err := c.writeSysctl(path, "1"); err != nil {
+			return fmt.Errorf("cannot disable IPv6: %w", err)
+		}
+	}
+	return nil
+}
+
+func (c *configurator) writeSysctl(path, value string) error {
+	file, err := c.openFile(path, os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(value); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,201 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	nativeos "os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/latency"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/qdm12/golibs/logging"
+	"github.com/qdm12/golibs/os"
+)
+
+var (
+	ErrProviderNotSpecified = errors.New("provider must be specified")
+	ErrProviderUnknown      = errors.New("provider is unknown")
+	ErrNoBenchmarkTarget    = errors.New("no server matches the region")
+	ErrConcurrencyTooLow    = errors.New("concurrency must be at least 1")
+)
+
+func (c *cli) Benchmark(args []string, os os.OS) error {
+	flagSet := flag.NewFlagSet("benchmark", flag.ExitOnError)
+	providerName := flagSet.String("provider", "", "VPN provider to benchmark the servers of")
+	region := flagSet.String("region", "", "Region, country, city or hostname to filter servers with")
+	port := flagSet.Int("port", 443, "TCP port to connect to")                                  //nolint:gomnd
+	concurrency := flagSet.Int("concurrency", 10, "Maximum number of simultaneous connections") //nolint:gomnd
+	timeout := flagSet.Duration("timeout", 2*time.Second, "Timeout for each connection")        //nolint:gomnd
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	if *providerName == "" {
+		return ErrProviderNotSpecified
+	} else if *concurrency < 1 {
+		return ErrConcurrencyTooLow
+	}
+	provider := models.VPNProvider(strings.ToLower(*providerName))
+	if provider == "pia" {
+		provider = constants.PrivateInternetAccess
+	}
+
+	logger, err := logging.NewLogger(logging.ConsoleEncoding, logging.InfoLevel)
+	if err != nil {
+		return err
+	}
+	storage, err := newStorage(logger, os)
+	if err != nil {
+		return err
+	}
+	allServers, err := storage.SyncServers(constants.GetAllServers())
+	if err != nil {
+		return err
+	}
+	targets, err := benchmarkTargets(provider, *region, allServers)
+	if err != nil {
+		return err
+	}
+
+	dialer := &net.Dialer{Timeout: *timeout}
+	results := benchmark(context.Background(), dialer, targets, *port, *concurrency)
+	return printBenchmarkResults(results)
+}
+
+type benchmarkTarget struct {
+	name string
+	ip   net.IP
+}
+
+type benchmarkResult struct {
+	target benchmarkTarget
+	result latency.Result
+}
+
+// benchmarkTargets returns the IP addresses of the servers of the provider
+// matching the region given, which can also be a country, city or hostname
+// depending on the provider. An empty region matches all servers.
+func benchmarkTargets(provider models.VPNProvider, region string, //nolint:gocognit,gocyclo
+	allServers models.AllServers) (targets []benchmarkTarget, err error) {
+	matches := func(values ...string) bool {
+		if region == "" {
+			return true
+		}
+		for _, value := range values {
+			if strings.EqualFold(value, region) {
+				return true
+			}
+		}
+		return false
+	}
+	addTargets := func(name string, ips ...net.IP) {
+		for _, ip := range ips {
+			targets = append(targets, benchmarkTarget{name: name, ip: ip})
+		}
+	}
+
+	switch provider {
+	case constants.Cyberghost:
+		for _, server := range allServers.Cyberghost.Servers {
+			if matches(server.Region) {
+				addTargets(server.Region, server.IPs...)
+			}
+		}
+	case constants.Mullvad:
+		for _, server := range allServers.Mullvad.Servers {
+			if matches(server.Country, server.City) {
+				addTargets(server.Country+" "+server.City, server.IPs...)
+			}
+		}
+	case constants.Nordvpn:
+		for _, server := range allServers.Nordvpn.Servers {
+			if matches(server.Region) {
+				addTargets(server.Region+" #"+strconv.Itoa(int(server.Number)), server.IP)
+			}
+		}
+	case constants.PrivateInternetAccess:
+		for _, server := range allServers.Pia.Servers {
+			if matches(server.Region) {
+				addTargets(server.Region, server.OpenvpnTCP.IPs...)
+			}
+		}
+	case constants.Privado:
+		for _, server := range allServers.Privado.Servers {
+			if matches(server.Hostname) {
+				addTargets(server.Hostname, server.IP)
+			}
+		}
+	case constants.Purevpn:
+		for _, server := range allServers.Purevpn.Servers {
+			if matches(server.Region, server.Country, server.City) {
+				addTargets(server.Country+" "+server.City, server.IPs...)
+			}
+		}
+	case constants.Surfshark:
+		for _, server := range allServers.Surfshark.Servers {
+			if matches(server.Region) {
+				addTargets(server.Region, server.IPs...)
+			}
+		}
+	case constants.Vyprvpn:
+		for _, server := range allServers.Vyprvpn.Servers {
+			if matches(server.Region) {
+				addTargets(server.Region, server.IPs...)
+			}
+		}
+	case constants.Windscribe:
+		for _, server := range allServers.Windscribe.Servers {
+			if matches(server.Region, server.City, server.Hostname) {
+				addTargets(server.Hostname, server.IP)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrProviderUnknown, provider)
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoBenchmarkTarget, region)
+	}
+	return targets, nil
+}
+
+// benchmark measures the TCP connection latency to each of the targets,
+// with at most concurrency connections at the same time. The results
+// are sorted by increasing latency, with the failed connections last.
+func benchmark(ctx context.Context, dialer *net.Dialer, targets []benchmarkTarget,
+	port, concurrency int) (results []benchmarkResult) {
+	addresses := make([]string, len(targets))
+	for i, target := range targets {
+		addresses[i] = net.JoinHostPort(target.ip.String(), strconv.Itoa(port))
+	}
+	latencies := latency.Measure(ctx, dialer, addresses, concurrency)
+	results = make([]benchmarkResult, len(targets))
+	for i := range targets {
+		results[i] = benchmarkResult{target: targets[i], result: latencies[i]}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return latency.Less(results[i].result, results[j].result)
+	})
+	return results
+}
+
+func printBenchmarkResults(results []benchmarkResult) error {
+	const padding = 2
+	writer := tabwriter.NewWriter(nativeos.Stdout, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(writer, "SERVER\tIP\tLATENCY")
+	for _, result := range results {
+		latencyString := result.result.Latency.Round(time.Millisecond).String()
+		if result.result.Err != nil {
+			latencyString = "unreachable"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", result.target.name, result.target.ip, latencyString)
+	}
+	return writer.Flush()
+}
@@ -2,15 +2,26 @@ package cli
 
 import (
 	"context"
+	"path/filepath"
 
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/params"
+	"github.com/qdm12/gluetun/internal/storage"
+	"github.com/qdm12/golibs/logging"
 	"github.com/qdm12/golibs/os"
 )
 
 type CLI interface {
-	ClientKey(args []string, openFile os.OpenFileFunc) error
+	Benchmark(args []string, os os.OS) error
+	ClientKey(args []string, os os.OS) error
+	ExplainSelection(os os.OS) error
+	ExportEnv(os os.OS) error
 	HealthCheck(ctx context.Context) error
+	InspectConnection(os os.OS) error
 	OpenvpnConfig(os os.OS) error
+	ResolvConf(os os.OS) error
 	Update(args []string, os os.OS) error
+	ValidateServers(args []string, os os.OS) error
 }
 
 type cli struct{}
@@ -18,3 +29,27 @@ type cli struct{}
 func New() CLI {
 	return &cli{}
 }
+
+// serversDataFilepath returns the file path of the servers data file
+// in the data directory.
+func serversDataFilepath(logger logging.Logger, os os.OS) (path string, err error) {
+	dataDir, err := params.NewReader(logger, os).GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, constants.ServersDataFilename), nil
+}
+
+// newStorage creates the storage of the servers data file
+// in the data directory.
+func newStorage(logger logging.Logger, os os.OS) (s storage.Storage, err error) {
+	path, err := serversDataFilepath(logger, os)
+	if err != nil {
+		return nil, err
+	}
+	lockTimeout, err := params.NewReader(logger, os).GetStorageLockTimeout()
+	if err != nil {
+		return nil, err
+	}
+	return storage.New(logger, os, path, lockTimeout), nil
+}
@@ -4,19 +4,34 @@ import (
 	"flag"
 	"fmt"
 	"io/ioutil"
+	nativeos "os"
+	"path/filepath"
 	"strings"
 
 	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/params"
+	"github.com/qdm12/golibs/logging"
 	"github.com/qdm12/golibs/os"
 )
 
-func (c *cli) ClientKey(args []string, openFile os.OpenFileFunc) error {
+// ClientKey prints the client key from the file given, which defaults
+// to the client.key file of the data directory set by DATA_DIR.
+func (c *cli) ClientKey(args []string, os os.OS) error {
+	logger, err := logging.NewLogger(logging.ConsoleEncoding, logging.InfoLevel)
+	if err != nil {
+		return err
+	}
+	dataDir, err := params.NewReader(logger, os).GetDataDir()
+	if err != nil {
+		return err
+	}
 	flagSet := flag.NewFlagSet("clientkey", flag.ExitOnError)
-	filepath := flagSet.String("path", string(constants.ClientKey), "file path to the client.key file")
+	path := flagSet.String("path", filepath.Join(dataDir, constants.ClientKeyFilename),
+		"file path to the client.key file")
 	if err := flagSet.Parse(args); err != nil {
 		return err
 	}
-	file, err := openFile(*filepath, os.O_RDONLY, 0)
+	file, err := os.OpenFile(*path, nativeos.O_RDONLY, 0)
 	if err != nil {
 		return err
 	}
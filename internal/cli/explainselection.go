@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/qdm12/gluetun/internal/provider"
+	"github.com/qdm12/golibs/os"
+)
+
+// ExplainSelection prints each stage of the server filters pipeline with
+// the number of candidate servers remaining, followed by the server chosen.
+func (c *cli) ExplainSelection(os os.OS) error {
+	openvpnSettings, allServers, err := readSettingsAndServers(os)
+	if err != nil {
+		return err
+	}
+	providerName := openvpnSettings.Provider.Name
+	selection := openvpnSettings.Provider.ServerSelection
+	providerConf := provider.New(providerName, allServers, time.Now)
+	fmt.Println("Provider: " + string(providerName))
+	if selection.TargetIP != nil {
+		fmt.Println("Target IP " + selection.TargetIP.String() + " is set: server filters are bypassed")
+	} else if explainer, ok := providerConf.(provider.Explainer); ok {
+		for i, stage := range explainer.ExplainSelection(selection) {
+			fmt.Printf("%d. %s\n", i+1, stage)
+		}
+	}
+	connection, err := providerConf.GetOpenVPNConnection(selection)
+	if err != nil {
+		return err
+	}
+	chosen := connection.IP.String()
+	if len(connection.Hostname) > 0 {
+		chosen = connection.Hostname + " (" + chosen + ")"
+	}
+	fmt.Printf("Chosen server: %s port %d %s\n", chosen, connection.Port, connection.Protocol)
+	return nil
+}
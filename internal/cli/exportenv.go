@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/qdm12/gluetun/internal/params"
+	"github.com/qdm12/gluetun/internal/settings"
+	"github.com/qdm12/golibs/logging"
+	"github.com/qdm12/golibs/os"
+)
+
+// ExportEnv prints the settings resolved from the environment, defaults
+// included, as KEY=value lines which can be used as a Docker environment file.
+// Secret values are redacted.
+func (c *cli) ExportEnv(os os.OS) error {
+	logger, err := logging.NewLogger(logging.ConsoleEncoding, logging.InfoLevel)
+	if err != nil {
+		return err
+	}
+	paramsReader, export := params.NewExportReader(logger, os)
+	if _, _, err := settings.GetAllSettings(paramsReader); err != nil {
+		return err
+	}
+	lines := export()
+	fmt.Println(strings.Join(lines, "\n"))
+	return nil
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/qdm12/gluetun/internal/provider"
+	"github.com/qdm12/golibs/os"
+)
+
+// InspectConnection prints the OpenVPN connection resolved for the current
+// settings, with the cipher and auth algorithms of the settings or the
+// provider defaults, without building the OpenVPN configuration.
+func (c *cli) InspectConnection(os os.OS) error {
+	openvpnSettings, providerConf, connection, err := resolveConnection(os)
+	if err != nil {
+		return err
+	}
+	unsetValue := "provider default"
+	cipher, auth := openvpnSettings.Cipher, openvpnSettings.Auth
+	if defaulter, ok := providerConf.(provider.CipherAuthDefaulter); ok {
+		unsetValue = "OpenVPN default"
+		defaultCipher, defaultAuth := defaulter.DefaultCipherAuth(openvpnSettings)
+		if len(cipher) == 0 {
+			cipher = defaultCipher
+		}
+		if len(auth) == 0 {
+			auth = defaultAuth
+		}
+	}
+	if len(cipher) == 0 {
+		cipher = unsetValue
+	}
+	if len(auth) == 0 {
+		auth = unsetValue
+	}
+	lines := []string{
+		"Provider: " + string(openvpnSettings.Provider.Name),
+	}
+	if len(connection.Hostname) > 0 {
+		lines = append(lines, "Server: "+connection.Hostname)
+	}
+	lines = append(lines,
+		"IP address: "+connection.IP.String(),
+		fmt.Sprintf("Port: %d", connection.Port),
+		"Protocol: "+string(connection.Protocol),
+		"Cipher: "+cipher,
+		"Auth: "+auth,
+		openvpnSettings.Provider.String(),
+	)
+	fmt.Println(strings.Join(lines, "\n"))
+	return nil
+}
@@ -6,35 +6,60 @@ import (
 	"time"
 
 	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
 	"github.com/qdm12/gluetun/internal/params"
 	"github.com/qdm12/gluetun/internal/provider"
 	"github.com/qdm12/gluetun/internal/settings"
-	"github.com/qdm12/gluetun/internal/storage"
 	"github.com/qdm12/golibs/logging"
 	"github.com/qdm12/golibs/os"
 )
 
 func (c *cli) OpenvpnConfig(os os.OS) error {
-	logger, err := logging.NewLogger(logging.ConsoleEncoding, logging.InfoLevel)
+	openvpnSettings, providerConf, connection, err := resolveConnection(os)
 	if err != nil {
 		return err
 	}
-	paramsReader := params.NewReader(logger, os)
-	allSettings, _, err := settings.GetAllSettings(paramsReader)
+	lines, err := provider.BuildConfFromTemplate(providerConf, connection, "nonroortuser", openvpnSettings)
 	if err != nil {
 		return err
 	}
-	allServers, err := storage.New(logger, os, constants.ServersData).
-		SyncServers(constants.GetAllServers())
+	fmt.Println(strings.Join(lines, "\n"))
+	return nil
+}
+
+// resolveConnection reads the settings and servers data to obtain
+// the OpenVPN connection the provider would use.
+func resolveConnection(os os.OS) (openvpnSettings settings.OpenVPN,
+	providerConf provider.Provider, connection models.OpenVPNConnection, err error) {
+	openvpnSettings, allServers, err := readSettingsAndServers(os)
 	if err != nil {
-		return err
+		return openvpnSettings, nil, connection, err
 	}
-	providerConf := provider.New(allSettings.OpenVPN.Provider.Name, allServers, time.Now)
-	connection, err := providerConf.GetOpenVPNConnection(allSettings.OpenVPN.Provider.ServerSelection)
+	providerConf = provider.New(openvpnSettings.Provider.Name, allServers, time.Now)
+	connection, err = providerConf.GetOpenVPNConnection(openvpnSettings.Provider.ServerSelection)
 	if err != nil {
-		return err
+		return openvpnSettings, nil, connection, err
 	}
-	lines := providerConf.BuildConf(connection, "nonroortuser", allSettings.OpenVPN)
-	fmt.Println(strings.Join(lines, "\n"))
-	return nil
+	return openvpnSettings, providerConf, connection, nil
+}
+
+// readSettingsAndServers reads the OpenVPN settings and the servers data.
+func readSettingsAndServers(os os.OS) (openvpnSettings settings.OpenVPN,
+	allServers models.AllServers, err error) {
+	logger, err := logging.NewLogger(logging.ConsoleEncoding, logging.InfoLevel)
+	if err != nil {
+		return openvpnSettings, allServers, err
+	}
+	paramsReader := params.NewReader(logger, os)
+	allSettings, _, err := settings.GetAllSettings(paramsReader)
+	if err != nil {
+		return openvpnSettings, allServers, err
+	}
+	openvpnSettings = allSettings.OpenVPN
+	storage, err := newStorage(logger, os)
+	if err != nil {
+		return openvpnSettings, allServers, err
+	}
+	allServers, err = storage.SyncServers(constants.GetAllServers())
+	return openvpnSettings, allServers, err
 }
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/qdm12/gluetun/internal/dns"
+	"github.com/qdm12/gluetun/internal/params"
+	"github.com/qdm12/gluetun/internal/settings"
+	"github.com/qdm12/golibs/logging"
+	"github.com/qdm12/golibs/os"
+)
+
+func (c *cli) ResolvConf(os os.OS) error {
+	logger, err := logging.NewLogger(logging.ConsoleEncoding, logging.InfoLevel)
+	if err != nil {
+		return err
+	}
+	paramsReader := params.NewReader(logger, os)
+	dnsSettings, err := settings.GetDNSSettings(paramsReader)
+	if err != nil {
+		return err
+	}
+	content, err := dns.ResolvConf(os.OpenFile, dnsSettings)
+	if err != nil {
+		return err
+	}
+	fmt.Print(content)
+	return nil
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/settings"
-	"github.com/qdm12/gluetun/internal/storage"
 	"github.com/qdm12/gluetun/internal/updater"
 	"github.com/qdm12/golibs/logging"
 	"github.com/qdm12/golibs/os"
@@ -19,7 +18,7 @@ func (c *cli) Update(args []string, os os.OS) error {
 	options := settings.Updater{CLI: true}
 	var flushToFile bool
 	flagSet := flag.NewFlagSet("update", flag.ExitOnError)
-	flagSet.BoolVar(&flushToFile, "file", false, "Write results to /gluetun/servers.json (for end users)")
+	flagSet.BoolVar(&flushToFile, "file", false, "Write results to servers.json in the data directory (for end users)")
 	flagSet.BoolVar(&options.Stdout, "stdout", false, "Write results to console to modify the program (for maintainers)")
 	flagSet.StringVar(&options.DNSAddress, "dns", "1.1.1.1", "DNS resolver address to use")
 	flagSet.BoolVar(&options.Cyberghost, "cyberghost", false, "Update Cyberghost servers")
@@ -44,7 +43,10 @@ func (c *cli) Update(args []string, os os.OS) error {
 	ctx := context.Background()
 	const clientTimeout = 10 * time.Second
 	httpClient := &http.Client{Timeout: clientTimeout}
-	storage := storage.New(logger, os, constants.ServersData)
+	storage, err := newStorage(logger, os)
+	if err != nil {
+		return err
+	}
 	currentServers, err := storage.SyncServers(constants.GetAllServers())
 	if err != nil {
 		return fmt.Errorf("cannot update servers: %w", err)
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	nativeos "os"
+
+	"github.com/qdm12/gluetun/internal/storage"
+	"github.com/qdm12/golibs/logging"
+	"github.com/qdm12/golibs/os"
+)
+
+var ErrServersInvalid = errors.New("servers file is invalid")
+
+// ValidateServers checks the servers file given as argument, or the servers
+// file of the data directory if no argument is given, and prints each error found.
+func (c *cli) ValidateServers(args []string, os os.OS) error {
+	var path string
+	switch len(args) {
+	case 0:
+		logger, err := logging.NewLogger(logging.ConsoleEncoding, logging.InfoLevel)
+		if err != nil {
+			return err
+		}
+		path, err = serversDataFilepath(logger, os)
+		if err != nil {
+			return err
+		}
+	case 1:
+		path = args[0]
+	default:
+		return fmt.Errorf("too many arguments: %d", len(args))
+	}
+
+	file, err := os.OpenFile(path, nativeos.O_RDONLY, 0)
+	if err != nil {
+		return err
+	}
+	errs := storage.ValidateServers(file)
+	if err := file.Close(); err != nil {
+		return err
+	}
+	for _, err := range errs {
+		fmt.Println(err)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %s: %d errors", ErrServersInvalid, path, len(errs))
+	}
+	fmt.Println(path + " is valid")
+	return nil
+}
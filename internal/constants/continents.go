@@ -0,0 +1,92 @@
+package constants
+
+import (
+	"sort"
+	"strings"
+)
+
+//nolint:gochecknoglobals
+var continentCountries = map[string][]string{
+	"Africa": {
+		"Algeria", "Egypt", "Kenya", "Morocco", "Nigeria", "South Africa",
+		"Tunisia",
+	},
+	"Asia": {
+		"Azerbaijan", "Bahrain", "Bangladesh", "Cambodia", "China", "Dubai",
+		"Georgia", "Hong Kong", "India", "Indonesia", "Iran", "Israel", "Japan",
+		"Kazakhstan", "Korea", "Macao", "Malaysia", "Maldives", "Mongolia",
+		"Pakistan", "Philippines", "Qatar", "Saudi Arabia", "Singapore",
+		"South Korea", "Taiwan", "Thailand", "Turkey", "United Arab Emirates",
+		"Vietnam",
+	},
+	"Europe": {
+		"Albania", "Andorra", "Armenia", "Austria", "Belarus", "Belgium",
+		"Bosnia and Herzegovina", "Bulgaria", "Croatia", "Cyprus",
+		"Czech Republic", "Denmark", "Estonia", "Finland", "France", "Germany",
+		"Greece", "Hungary", "Iceland", "Ireland", "Isle of Man", "Italy",
+		"Latvia", "Liechtenstein", "Lithuania", "Luxembourg", "Macedonia",
+		"Malta", "Moldova", "Monaco", "Montenegro", "Netherlands", "Norway",
+		"Poland", "Portugal", "Romania", "Russia", "Russian Federation",
+		"Serbia", "Slovakia", "Slovenia", "Spain", "Sweden", "Switzerland",
+		"UK", "Ukraine", "United Kingdom",
+	},
+	"North America": {
+		"Bahamas", "Canada", "Costa Rica", "El Salvador", "Greenland",
+		"Mexico", "Panama", "United States", "USA",
+	},
+	"Oceania": {
+		"Australia", "Marshall Islands", "New Zealand",
+	},
+	"South America": {
+		"Argentina", "Brazil", "Chile", "Colombia", "Columbia", "Ecuador",
+		"Peru", "Uruguay", "Venezuela",
+	},
+}
+
+// countryCodeAliases maps country codes some providers prefix
+// their region names with, such as "US Texas", to country names.
+var countryCodeAliases = map[string]string{ //nolint:gochecknoglobals
+	"AU": "Australia",
+	"CA": "Canada",
+	"DE": "Germany",
+	"UK": "United Kingdom",
+	"US": "United States",
+}
+
+// ContinentChoices returns the sorted list of continents that can be used
+// to select regions or countries.
+func ContinentChoices() (choices []string) {
+	choices = make([]string, 0, len(continentCountries))
+	for continent := range continentCountries {
+		choices = append(choices, continent)
+	}
+	sort.Strings(choices)
+	return choices
+}
+
+// ContinentCountries returns the country names for the continent given,
+// together with the country code aliases of these countries.
+func ContinentCountries(continent string) (countries []string) {
+	for choice, names := range continentCountries {
+		if !strings.EqualFold(choice, continent) {
+			continue
+		}
+		countries = append(countries, names...)
+		for code, country := range countryCodeAliases {
+			if containsString(names, country) && !containsString(names, code) {
+				countries = append(countries, code)
+			}
+		}
+		return countries
+	}
+	return nil
+}
+
+func containsString(slice []string, value string) bool {
+	for _, element := range slice {
+		if element == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,10 @@
+package constants
+
+const (
+	// AutoFirewallBackend detects which firewall backend is available.
+	AutoFirewallBackend = "auto"
+	// IptablesBackend is the legacy iptables firewall backend.
+	IptablesBackend = "iptables"
+	// NftablesBackend is the nftables firewall backend.
+	NftablesBackend = "nftables"
+)
@@ -0,0 +1,10 @@
+package constants
+
+const (
+	// IPv4Family only uses IPv4 through the tunnel.
+	IPv4Family = "ipv4"
+	// IPv6Family only uses IPv6 through the tunnel.
+	IPv6Family = "ipv6"
+	// DualStackFamily uses both IPv4 and IPv6 through the tunnel.
+	DualStackFamily = "dual"
+)
@@ -8,3 +8,30 @@ const (
 	TUN models.VPNDevice = "tun0"
 	TAP models.VPNDevice = "tap0"
 )
+
+const (
+	// NoCompression disables OpenVPN compression.
+	NoCompression = "none"
+	// LZO is the LZO compression algorithm for OpenVPN.
+	LZO = "lzo"
+	// LZ4 is the LZ4 compression algorithm for OpenVPN.
+	LZ4 = "lz4"
+)
+
+const (
+	// AuthRetryNone makes OpenVPN exit on an authentication failure.
+	AuthRetryNone = "none"
+	// AuthRetryNoInteract makes OpenVPN retry an authentication
+	// failure with the same credentials.
+	AuthRetryNoInteract = "nointeract"
+	// AuthRetryInteract makes OpenVPN prompt for the credentials
+	// again on an authentication failure.
+	AuthRetryInteract = "interact"
+)
+
+const (
+	// SOCKSProxy is the SOCKS proxy type to reach the OpenVPN server.
+	SOCKSProxy = "socks"
+	// HTTPProxy is the HTTP proxy type to reach the OpenVPN server.
+	HTTPProxy = "http"
+)
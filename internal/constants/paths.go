@@ -13,10 +13,12 @@ const (
 	CACertificates models.Filepath = "/etc/ssl/certs/ca-certificates.crt"
 	// OpenVPNAuthConf is the file path to the OpenVPN auth file.
 	OpenVPNAuthConf models.Filepath = "/etc/openvpn/auth.conf"
+	// OpenVPNProxyAuthConf is the file path to the OpenVPN pre-connect proxy auth file.
+	OpenVPNProxyAuthConf models.Filepath = "/etc/openvpn/proxy-auth.conf"
 	// OpenVPNConf is the file path to the OpenVPN client configuration file.
 	OpenVPNConf models.Filepath = "/etc/openvpn/target.ovpn"
-	// PIAPortForward is the file path to the port forwarding JSON information for PIA servers.
-	PIAPortForward models.Filepath = "/gluetun/piaportforward.json"
+	// OpenVPNManagementSocket is the file path to the OpenVPN management interface unix socket.
+	OpenVPNManagementSocket models.Filepath = "/etc/openvpn/management.sock"
 	// TunnelDevice is the file path to tun device.
 	TunnelDevice models.Filepath = "/dev/net/tun"
 	// NetRoute is the path to the file containing information on the network route.
@@ -25,10 +27,24 @@ const (
 	RootHints models.Filepath = "/etc/unbound/root.hints"
 	// RootKey is the filepath to the root.key file used by Unbound.
 	RootKey models.Filepath = "/etc/unbound/root.key"
-	// Client key filepath, used by Cyberghost.
-	ClientKey models.Filepath = "/gluetun/client.key"
-	// Client certificate filepath, used by Cyberghost.
-	ClientCertificate models.Filepath = "/gluetun/client.crt"
-	// Servers information filepath.
-	ServersData = "/gluetun/servers.json"
+	// DefaultDataDir is the default directory path to store data and generated files in.
+	DefaultDataDir = "/gluetun"
+	// DefaultStatusDir is the default directory path to write status files in
+	// if the data directory is not set.
+	DefaultStatusDir = "/tmp/gluetun"
+)
+
+const (
+	// PIAPortForwardFilename is the file name in the data directory of the
+	// port forwarding JSON information for PIA servers.
+	PIAPortForwardFilename = "piaportforward.json"
+	// ClientKeyFilename is the file name in the data directory of the
+	// client key, used by Cyberghost.
+	ClientKeyFilename = "client.key"
+	// ClientCertificateFilename is the file name in the data directory of the
+	// client certificate, used by Cyberghost.
+	ClientCertificateFilename = "client.crt"
+	// ServersDataFilename is the file name in the data directory of the
+	// servers information.
+	ServersDataFilename = "servers.json"
 )
@@ -23,6 +23,20 @@ const (
 	Purevpn models.VPNProvider = "purevpn"
 	// Privado is a VPN provider.
 	Privado models.VPNProvider = "privado"
+	// Custom is a VPN provider not supported by the program,
+	// connected to using the OpenVPN remotes given by the user.
+	Custom models.VPNProvider = "custom"
+)
+
+const (
+	// RandomOrder picks a random server for each connection attempt.
+	RandomOrder = "random"
+	// SequentialOrder goes through the servers in order
+	// on each connection attempt.
+	SequentialOrder = "sequential"
+	// PingOrder goes through the servers by increasing TCP connection
+	// latency on each connection attempt.
+	PingOrder = "ping"
 )
 
 const (
@@ -13,6 +13,7 @@ import (
 	"github.com/qdm12/gluetun/internal/models"
 	"github.com/qdm12/gluetun/internal/settings"
 	"github.com/qdm12/golibs/logging"
+	"github.com/qdm12/golibs/os"
 )
 
 type Looper interface {
@@ -22,6 +23,7 @@ type Looper interface {
 	SetStatus(status models.LoopStatus) (outcome string, err error)
 	GetSettings() (settings settings.DNS)
 	SetSettings(settings settings.DNS) (outcome string)
+	GetStats(ctx context.Context) (stats Stats, err error)
 }
 
 type looper struct {
@@ -41,12 +43,18 @@ type looper struct {
 	backoffTime  time.Duration
 	timeNow      func() time.Time
 	timeSince    func(time.Time) time.Duration
+	openFile     os.OpenFileFunc
+	// Nameservers of the keep nameserver interface
+	interfaceNameservers     []net.IP
+	interfaceNameserversRead bool
+	// Last block lists lines downloaded without error
+	lastHostnameLines, lastIPLines []string
 }
 
 const defaultBackoffTime = 10 * time.Second
 
 func NewLooper(conf unbound.Configurator, settings settings.DNS, client *http.Client,
-	logger logging.Logger, username string, puid, pgid int) Looper {
+	logger logging.Logger, username string, puid, pgid int, openFile os.OpenFileFunc) Looper {
 	return &looper{
 		state: state{
 			status:   constants.Stopped,
@@ -66,6 +74,7 @@ func NewLooper(conf unbound.Configurator, settings settings.DNS, client *http.Cl
 		backoffTime:  defaultBackoffTime,
 		timeNow:      time.Now,
 		timeSince:    time.Since,
+		openFile:     openFile,
 	}
 }
 
@@ -189,7 +198,11 @@ func (l *looper) setupUnbound(ctx context.Context, wg *sync.WaitGroup,
 	settings := l.GetSettings()
 
 	unboundCtx, cancel := context.WithCancel(context.Background())
-	stdoutLines, stderrLines, waitError, err := l.conf.Start(unboundCtx, settings.Unbound.VerbosityDetailsLevel)
+	start := l.conf.Start
+	if settings.TLSMinVersion != defaultTLSMinVersion {
+		start = startUnboundWithOpenSSLConf
+	}
+	stdoutLines, stderrLines, waitError, err := start(unboundCtx, settings.Unbound.VerbosityDetailsLevel)
 	if err != nil {
 		cancel()
 		if !previousCrashed {
@@ -201,8 +214,8 @@ func (l *looper) setupUnbound(ctx context.Context, wg *sync.WaitGroup,
 	wg.Add(1)
 	go l.collectLines(wg, stdoutLines, stderrLines)
 
-	l.conf.UseDNSInternally(net.IP{127, 0, 0, 1})                                                  // use Unbound
-	if err := l.conf.UseDNSSystemWide(net.IP{127, 0, 0, 1}, settings.KeepNameserver); err != nil { // use Unbound
+	l.conf.UseDNSInternally(net.IP{127, 0, 0, 1})                              // use Unbound
+	if err := l.useDNSSystemWide(net.IP{127, 0, 0, 1}, settings); err != nil { // use Unbound
 		l.logger.Error(err)
 	}
 
@@ -246,7 +259,7 @@ func (l *looper) useUnencryptedDNS(fallback bool) {
 			l.logger.Info("using plaintext DNS at address %s", targetIP)
 		}
 		l.conf.UseDNSInternally(targetIP)
-		if err := l.conf.UseDNSSystemWide(targetIP, settings.KeepNameserver); err != nil {
+		if err := l.useDNSSystemWide(targetIP, settings); err != nil {
 			l.logger.Error(err)
 		}
 		return
@@ -263,7 +276,7 @@ func (l *looper) useUnencryptedDNS(fallback bool) {
 					l.logger.Info("using plaintext DNS at address %s", targetIP)
 				}
 				l.conf.UseDNSInternally(targetIP)
-				if err := l.conf.UseDNSSystemWide(targetIP, settings.KeepNameserver); err != nil {
+				if err := l.useDNSSystemWide(targetIP, settings); err != nil {
 					l.logger.Error(err)
 				}
 				return
@@ -334,25 +347,62 @@ func (l *looper) RunRestartTicker(ctx context.Context, wg *sync.WaitGroup) {
 }
 
 func (l *looper) updateFiles(ctx context.Context) (err error) {
+	settings := l.GetSettings()
 	l.logger.Info("downloading DNS over TLS cryptographic files")
 	if err := l.conf.SetupFiles(ctx); err != nil {
-		return err
+		if len(settings.RootHints) == 0 {
+			return err
+		}
+		// air-gapped setups use their own root hints file
+		l.logger.Warn("cannot download DNS over TLS cryptographic files, using root hints file %s: %s",
+			settings.RootHints, err)
+		if err := l.createIncludeConf(); err != nil {
+			return err
+		}
+	}
+	if settings.ServeTLS {
+		settings.ServeTLSCertFile, settings.ServeTLSKeyFile, err = l.setupServeTLSCertificate(
+			settings.ServeTLSCertFile, settings.ServeTLSKeyFile)
+		if err != nil {
+			return err
+		}
 	}
-	settings := l.GetSettings()
 
 	l.logger.Info("downloading hostnames and IP block lists")
-	hostnameLines, ipLines, errs := l.conf.BuildBlocked(ctx, l.client,
+	client := l.client
+	if len(settings.BlockListsMirrors) > 0 {
+		client = newMirrorClient(l.client, settings.BlockListsMirrors, l.logger)
+	}
+	hostnameLines, ipLines, errs := l.conf.BuildBlocked(ctx, client,
 		settings.BlockMalicious, settings.BlockAds, settings.BlockSurveillance,
 		settings.Unbound.BlockedHostnames, settings.Unbound.BlockedIPs,
 		settings.Unbound.AllowedHostnames)
 	for _, err := range errs {
 		l.logger.Warn(err)
 	}
+	switch {
+	case len(errs) == 0:
+		l.lastHostnameLines, l.lastIPLines = hostnameLines, ipLines
+	case l.lastHostnameLines != nil || l.lastIPLines != nil:
+		l.logger.Warn("keeping the block lists previously downloaded")
+		hostnameLines, ipLines = l.lastHostnameLines, l.lastIPLines
+	}
 
 	if err := l.conf.MakeUnboundConf(
 		settings.Unbound, hostnameLines, ipLines,
 		l.username, l.puid, l.pgid); err != nil {
 		return err
 	}
+	if err := l.customizeUnboundConf(settings); err != nil {
+		return err
+	}
+	if len(settings.LogFile) > 0 {
+		if err := l.createLogFile(settings.LogFile); err != nil {
+			return err
+		}
+	}
+	if settings.TLSMinVersion != defaultTLSMinVersion {
+		return l.writeOpenSSLConf(settings.TLSMinVersion)
+	}
 	return nil
 }
@@ -0,0 +1,72 @@
+package dns
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/qdm12/golibs/logging"
+)
+
+// blockListsBaseURL is the base URL the Unbound configurator
+// downloads the block lists from.
+const blockListsBaseURL = "https://raw.githubusercontent.com/qdm12/files/master/"
+
+// mirrorTransport is an HTTP transport downloading the block lists from
+// each mirror in order if the download from the primary source fails.
+type mirrorTransport struct {
+	base    http.RoundTripper
+	mirrors []string
+	logger  logging.Logger
+}
+
+func newMirrorClient(client *http.Client, mirrors []string, logger logging.Logger) *http.Client {
+	base := client.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	mirrorClient := *client
+	mirrorClient.Transport = &mirrorTransport{
+		base:    base,
+		mirrors: mirrors,
+		logger:  logger,
+	}
+	return &mirrorClient
+}
+
+func (m *mirrorTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	primaryURL := request.URL.String()
+	response, err := m.base.RoundTrip(request)
+	if !strings.HasPrefix(primaryURL, blockListsBaseURL) || (err == nil && response.StatusCode == http.StatusOK) {
+		return response, err
+	}
+	m.logger.Warn("cannot download block list from %s: %s", primaryURL, roundTripError(response, err))
+	fileName := strings.TrimPrefix(primaryURL, blockListsBaseURL)
+	for _, mirror := range m.mirrors {
+		mirrorRequest, mirrorErr := http.NewRequestWithContext(request.Context(), request.Method, mirror+fileName, nil)
+		if mirrorErr != nil {
+			m.logger.Warn(mirrorErr)
+			continue
+		}
+		mirrorResponse, mirrorErr := m.base.RoundTrip(mirrorRequest)
+		if mirrorErr == nil && mirrorResponse.StatusCode == http.StatusOK {
+			m.logger.Info("downloaded block list %s from mirror %s", fileName, mirror)
+			if err == nil {
+				_ = response.Body.Close()
+			}
+			return mirrorResponse, nil
+		}
+		m.logger.Warn("cannot download block list from %s: %s",
+			mirrorRequest.URL, roundTripError(mirrorResponse, mirrorErr))
+		if mirrorErr == nil {
+			_ = mirrorResponse.Body.Close()
+		}
+	}
+	return response, err
+}
+
+func roundTripError(response *http.Response, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return "HTTP status " + response.Status
+}
@@ -0,0 +1,143 @@
+package dns
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/qdm12/golibs/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// closableBody fails reading once closed.
+type closableBody struct {
+	*strings.Reader
+	closed bool
+}
+
+func (b *closableBody) Read(p []byte) (n int, err error) {
+	if b.closed {
+		return 0, errors.New("read on closed body")
+	}
+	return b.Reader.Read(p)
+}
+
+func (b *closableBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+type roundTripFunc func(request *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+func Test_mirrorTransport_RoundTrip(t *testing.T) {
+	t.Parallel()
+	const fileName = "blocklists/malicious-hostnames.updated"
+	testCases := map[string]struct {
+		url       string
+		mirrors   []string
+		responses map[string]int // URL to status code, missing URLs fail
+		requested []string
+		body      string
+		status    int
+		err       string
+	}{
+		"primary succeeds": {
+			url:       blockListsBaseURL + fileName,
+			mirrors:   []string{"https://mirror1/"},
+			responses: map[string]int{blockListsBaseURL + fileName: http.StatusOK},
+			requested: []string{blockListsBaseURL + fileName},
+			body:      blockListsBaseURL + fileName,
+			status:    http.StatusOK,
+		},
+		"other URL is not mirrored": {
+			url:       "https://example.com/" + fileName,
+			mirrors:   []string{"https://mirror1/"},
+			requested: []string{"https://example.com/" + fileName},
+			err:       "connection refused",
+		},
+		"first mirror succeeds": {
+			url:     blockListsBaseURL + fileName,
+			mirrors: []string{"https://mirror1/", "https://mirror2/"},
+			responses: map[string]int{
+				"https://mirror1/" + fileName: http.StatusOK,
+				"https://mirror2/" + fileName: http.StatusOK,
+			},
+			requested: []string{blockListsBaseURL + fileName, "https://mirror1/" + fileName},
+			body:      "https://mirror1/" + fileName,
+			status:    http.StatusOK,
+		},
+		"second mirror succeeds": {
+			url:     blockListsBaseURL + fileName,
+			mirrors: []string{"https://mirror1/", "https://mirror2/"},
+			responses: map[string]int{
+				blockListsBaseURL + fileName:  http.StatusServiceUnavailable,
+				"https://mirror1/" + fileName: http.StatusNotFound,
+				"https://mirror2/" + fileName: http.StatusOK,
+			},
+			requested: []string{
+				blockListsBaseURL + fileName,
+				"https://mirror1/" + fileName,
+				"https://mirror2/" + fileName,
+			},
+			body:   "https://mirror2/" + fileName,
+			status: http.StatusOK,
+		},
+		"all mirrors fail": {
+			url:       blockListsBaseURL + fileName,
+			mirrors:   []string{"https://mirror1/"},
+			responses: map[string]int{blockListsBaseURL + fileName: http.StatusServiceUnavailable},
+			requested: []string{blockListsBaseURL + fileName, "https://mirror1/" + fileName},
+			body:      blockListsBaseURL + fileName,
+			status:    http.StatusServiceUnavailable,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			logger, err := logging.NewEmptyLogger()
+			require.NoError(t, err)
+
+			var requested []string
+			base := roundTripFunc(func(request *http.Request) (*http.Response, error) {
+				url := request.URL.String()
+				requested = append(requested, url)
+				status, ok := testCase.responses[url]
+				if !ok {
+					return nil, errors.New("connection refused")
+				}
+				return &http.Response{
+					StatusCode: status,
+					Status:     http.StatusText(status),
+					Body:       &closableBody{Reader: strings.NewReader(url)},
+				}, nil
+			})
+			client := newMirrorClient(&http.Client{Transport: base}, testCase.mirrors, logger)
+
+			request, err := http.NewRequest(http.MethodGet, testCase.url, nil)
+			require.NoError(t, err)
+			response, err := client.Transport.RoundTrip(request)
+
+			assert.Equal(t, testCase.requested, requested)
+			if len(testCase.err) > 0 {
+				require.Error(t, err)
+				assert.Equal(t, testCase.err, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			defer response.Body.Close()
+			assert.Equal(t, testCase.status, response.StatusCode)
+			body, err := ioutil.ReadAll(response.Body)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.body, string(body))
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package dns
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+
+	"github.com/qdm12/gluetun/internal/settings"
+	"github.com/qdm12/golibs/os"
+)
+
+const resolvConfFilepath = "/etc/resolv.conf"
+
+// useDNSSystemWide sets the nameserver IP address given in /etc/resolv.conf.
+// If a keep nameserver interface is set, the original nameservers reachable
+// through this interface are kept after the nameserver given.
+func (l *looper) useDNSSystemWide(ip net.IP, settings settings.DNS) error {
+	if len(settings.KeepNameserverInterface) == 0 {
+		return l.conf.UseDNSSystemWide(ip, settings.KeepNameserver)
+	}
+
+	if !l.interfaceNameserversRead {
+		nameservers, err := l.readInterfaceNameservers(settings.KeepNameserverInterface)
+		if err != nil {
+			return err
+		}
+		l.interfaceNameservers = nameservers
+		l.interfaceNameserversRead = true
+		for _, nameserver := range nameservers {
+			l.logger.Info("keeping nameserver %s of interface %s",
+				nameserver, settings.KeepNameserverInterface)
+		}
+	}
+
+	const keepNameserver = false
+	if err := l.conf.UseDNSSystemWide(ip, keepNameserver); err != nil {
+		return err
+	}
+	return appendNameservers(l.openFile, l.interfaceNameservers)
+}
+
+func (l *looper) readInterfaceNameservers(interfaceName string) (nameservers []net.IP, err error) {
+	subnets, err := interfaceSubnets(interfaceName)
+	if err != nil {
+		return nil, err
+	}
+	b, err := readFile(l.openFile, resolvConfFilepath)
+	if err != nil {
+		return nil, err
+	}
+	return filterNameservers(string(b), subnets), nil
+}
+
+func interfaceSubnets(interfaceName string) (subnets []net.IPNet, err error) {
+	intf, err := net.InterfaceByName(interfaceName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find interface %s: %w", interfaceName, err)
+	}
+	addresses, err := intf.Addrs()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get addresses of interface %s: %w", interfaceName, err)
+	}
+	subnets = make([]net.IPNet, 0, len(addresses))
+	for _, address := range addresses {
+		if ipNet, ok := address.(*net.IPNet); ok {
+			subnets = append(subnets, *ipNet)
+		}
+	}
+	return subnets, nil
+}
+
+// filterNameservers returns the nameservers of the resolv.conf content
+// given which are part of one of the subnets given, as well as loopback
+// nameservers such as the Docker embedded DNS 127.0.0.11, which forwards
+// queries through the interface. The local nameserver 127.0.0.1 used by
+// Unbound is not kept.
+func filterNameservers(resolvConf string, subnets []net.IPNet) (nameservers []net.IP) {
+	for _, line := range strings.Split(resolvConf, "\n") {
+		fields := strings.Fields(line)
+		const expectedFields = 2
+		if len(fields) != expectedFields || fields[0] != "nameserver" {
+			continue
+		}
+		ip := net.ParseIP(fields[1])
+		if ip == nil || ip.Equal(net.IPv4(127, 0, 0, 1)) { //nolint:gomnd
+			continue
+		} else if ip.IsLoopback() {
+			nameservers = append(nameservers, ip)
+			continue
+		}
+		for _, subnet := range subnets {
+			if subnet.Contains(ip) {
+				nameservers = append(nameservers, ip)
+				break
+			}
+		}
+	}
+	return nameservers
+}
+
+func appendNameservers(openFile os.OpenFileFunc, nameservers []net.IP) error {
+	if len(nameservers) == 0 {
+		return nil
+	}
+	b, err := readFile(openFile, resolvConfFilepath)
+	if err != nil {
+		return err
+	}
+	s := string(b)
+	for _, nameserver := range nameservers {
+		s += "nameserver " + nameserver.String() + "\n"
+	}
+	file, err := openFile(resolvConfFilepath, os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(s); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func readFile(openFile os.OpenFileFunc, filepath string) (b []byte, err error) {
+	file, err := openFile(filepath, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	b, err = ioutil.ReadAll(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return b, file.Close()
+}
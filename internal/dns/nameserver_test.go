@@ -0,0 +1,53 @@
+package dns
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_filterNameservers(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		resolvConf  string
+		subnets     []net.IPNet
+		nameservers []net.IP
+	}{
+		"empty": {},
+		"nameservers in and out of subnet": {
+			resolvConf: "search lan\nnameserver 172.17.0.1\nnameserver 1.1.1.1\noptions ndots:0\n",
+			subnets: []net.IPNet{
+				{IP: net.IP{172, 17, 0, 0}, Mask: net.IPv4Mask(255, 255, 0, 0)},
+			},
+			nameservers: []net.IP{net.ParseIP("172.17.0.1")},
+		},
+		"docker embedded DNS": {
+			resolvConf: "nameserver 127.0.0.11\noptions ndots:0\n",
+			subnets: []net.IPNet{
+				{IP: net.IP{172, 17, 0, 0}, Mask: net.IPv4Mask(255, 255, 0, 0)},
+			},
+			nameservers: []net.IP{net.ParseIP("127.0.0.11")},
+		},
+		"local nameserver": {
+			resolvConf: "nameserver 127.0.0.1\n",
+			subnets: []net.IPNet{
+				{IP: net.IPv4zero, Mask: net.IPv4Mask(0, 0, 0, 0)},
+			},
+		},
+		"malformed nameserver": {
+			resolvConf: "nameserver abc\n",
+			subnets: []net.IPNet{
+				{IP: net.IPv4zero, Mask: net.IPv4Mask(0, 0, 0, 0)},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			nameservers := filterNameservers(testCase.resolvConf, testCase.subnets)
+			assert.Equal(t, testCase.nameservers, nameservers)
+		})
+	}
+}
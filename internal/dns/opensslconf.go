@@ -0,0 +1,96 @@
+package dns
+
+import (
+	"bufio"
+	"context"
+	"io"
+	nativeos "os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/qdm12/golibs/os"
+)
+
+const (
+	openSSLConfFilepath = "/etc/unbound/openssl.cnf"
+	unboundBinary       = "/usr/sbin/unbound"
+	// defaultTLSMinVersion is the minimum TLS version OpenSSL
+	// enforces by default, which needs no OpenSSL configuration.
+	defaultTLSMinVersion = "1.2"
+)
+
+// writeOpenSSLConf writes an OpenSSL configuration file enforcing the
+// minimum TLS version given, for the Unbound process to use it when
+// connecting to its upstream servers. Unbound has no configuration
+// directive for the minimum TLS version of its outgoing connections.
+func (l *looper) writeOpenSSLConf(minVersion string) error {
+	file, err := l.openFile(openSSLConfFilepath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	lines := openSSLConfLines(minVersion)
+	if _, err := file.WriteString(strings.Join(lines, "\n")); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func openSSLConfLines(minVersion string) (lines []string) {
+	return []string{
+		"openssl_conf = default_conf",
+		"[default_conf]",
+		"ssl_conf = ssl_sect",
+		"[ssl_sect]",
+		"system_default = system_default_sect",
+		"[system_default_sect]",
+		"MinProtocol = TLSv" + minVersion,
+		"",
+	}
+}
+
+// startUnboundWithOpenSSLConf starts Unbound as the Unbound configurator
+// does, but with OPENSSL_CONF set in its environment only, such that other
+// processes such as OpenVPN keep the OpenSSL defaults.
+func startUnboundWithOpenSSLConf(ctx context.Context, verbosityDetailsLevel uint8) (
+	stdoutLines, stderrLines chan string, waitError chan error, err error) {
+	args := []string{"-d", "-c", unboundConfFilepath}
+	if verbosityDetailsLevel > 0 {
+		args = append(args, "-"+strings.Repeat("v", int(verbosityDetailsLevel)))
+	}
+	cmd := exec.CommandContext(ctx, unboundBinary, args...)
+	cmd.Env = append(nativeos.Environ(), "OPENSSL_CONF="+openSSLConfFilepath)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, nil, nil, err
+	}
+
+	wg := &sync.WaitGroup{}
+	stdoutLines, stderrLines = make(chan string), make(chan string)
+	wg.Add(2) //nolint:gomnd
+	go streamLines(wg, stdout, stdoutLines)
+	go streamLines(wg, stderr, stderrLines)
+	waitError = make(chan error)
+	go func() {
+		wg.Wait() // streams must be read entirely before waiting
+		waitError <- cmd.Wait()
+	}()
+	return stdoutLines, stderrLines, waitError, nil
+}
+
+func streamLines(wg *sync.WaitGroup, stream io.Reader, lines chan<- string) {
+	defer wg.Done()
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		lines <- scanner.Text()
+	}
+}
@@ -0,0 +1,55 @@
+package dns
+
+import (
+	"net"
+	"strings"
+
+	"github.com/qdm12/gluetun/internal/settings"
+	"github.com/qdm12/golibs/os"
+)
+
+// ResolvConf returns the content of /etc/resolv.conf gluetun would write
+// for the DNS settings given, without modifying any file.
+func ResolvConf(openFile os.OpenFileFunc, settings settings.DNS) (content string, err error) {
+	b, err := readFile(openFile, resolvConfFilepath)
+	if err != nil {
+		return "", err
+	}
+
+	var interfaceNameservers []net.IP
+	if len(settings.KeepNameserverInterface) > 0 {
+		subnets, err := interfaceSubnets(settings.KeepNameserverInterface)
+		if err != nil {
+			return "", err
+		}
+		interfaceNameservers = filterNameservers(string(b), subnets)
+	}
+
+	ip := settings.PlaintextAddress
+	if settings.Enabled {
+		ip = net.IP{127, 0, 0, 1} // Unbound
+	}
+
+	return buildResolvConf(string(b), ip, settings.KeepNameserver, interfaceNameservers), nil
+}
+
+// buildResolvConf mirrors the changes made to the resolv.conf content
+// given when using the nameserver IP address system wide.
+func buildResolvConf(resolvConf string, ip net.IP, keepNameserver bool,
+	interfaceNameservers []net.IP) string {
+	if len(interfaceNameservers) > 0 {
+		keepNameserver = false
+	}
+	lines := []string{"nameserver " + ip.String()}
+	for _, line := range strings.Split(strings.TrimSuffix(resolvConf, "\n"), "\n") {
+		if line == "" ||
+			(!keepNameserver && strings.HasPrefix(line, "nameserver ")) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	for _, nameserver := range interfaceNameservers {
+		lines = append(lines, "nameserver "+nameserver.String())
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
@@ -0,0 +1,52 @@
+package dns
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_buildResolvConf(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		resolvConf           string
+		ip                   net.IP
+		keepNameserver       bool
+		interfaceNameservers []net.IP
+		expected             string
+	}{
+		"empty": {
+			ip:       net.IP{127, 0, 0, 1},
+			expected: "nameserver 127.0.0.1\n",
+		},
+		"replace nameservers": {
+			resolvConf: "search lan\nnameserver 172.17.0.1\noptions ndots:0\n",
+			ip:         net.IP{127, 0, 0, 1},
+			expected:   "nameserver 127.0.0.1\nsearch lan\noptions ndots:0\n",
+		},
+		"keep nameservers": {
+			resolvConf:     "search lan\nnameserver 172.17.0.1\n",
+			ip:             net.IP{1, 1, 1, 1},
+			keepNameserver: true,
+			expected:       "nameserver 1.1.1.1\nsearch lan\nnameserver 172.17.0.1\n",
+		},
+		"keep interface nameservers": {
+			resolvConf:           "nameserver 172.17.0.1\nnameserver 8.8.8.8\n",
+			ip:                   net.IP{127, 0, 0, 1},
+			keepNameserver:       true,
+			interfaceNameservers: []net.IP{{172, 17, 0, 1}},
+			expected:             "nameserver 127.0.0.1\nnameserver 172.17.0.1\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			content := buildResolvConf(testCase.resolvConf, testCase.ip,
+				testCase.keepNameserver, testCase.interfaceNameservers)
+			assert.Equal(t, testCase.expected, content)
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package dns
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/qdm12/golibs/os"
+)
+
+const (
+	selfSignedCertFilepath = "/etc/unbound/tls-service.pem"
+	selfSignedKeyFilepath  = "/etc/unbound/tls-service.key"
+)
+
+// setupServeTLSCertificate returns the certificate and key file paths Unbound
+// should use to serve DNS over TLS. It validates the certificate and key pair
+// given, or creates a self-signed certificate if no file path is given.
+func (l *looper) setupServeTLSCertificate(certFile, keyFile string) (
+	certFilepath, keyFilepath string, err error) {
+	if len(certFile) > 0 {
+		if err := l.checkKeyPair(certFile, keyFile); err != nil {
+			return "", "", err
+		}
+		return certFile, keyFile, nil
+	}
+
+	if err := l.checkKeyPair(selfSignedCertFilepath, selfSignedKeyFilepath); err == nil {
+		return selfSignedCertFilepath, selfSignedKeyFilepath, nil // re-use existing one
+	}
+	l.logger.Info("generating self-signed certificate to serve DNS over TLS")
+	certPEM, keyPEM, err := generateSelfSignedCert(time.Now())
+	if err != nil {
+		return "", "", err
+	}
+	if err := l.writeUnboundFile(selfSignedCertFilepath, certPEM); err != nil {
+		return "", "", err
+	}
+	if err := l.writeUnboundFile(selfSignedKeyFilepath, keyPEM); err != nil {
+		return "", "", err
+	}
+	return selfSignedCertFilepath, selfSignedKeyFilepath, nil
+}
+
+func (l *looper) checkKeyPair(certFile, keyFile string) error {
+	certPEM, err := readFile(l.openFile, certFile)
+	if err != nil {
+		return fmt.Errorf("cannot read certificate: %w", err)
+	}
+	keyPEM, err := readFile(l.openFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("cannot read private key: %w", err)
+	}
+	if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		return fmt.Errorf("invalid certificate and private key pair: %w", err)
+	}
+	return nil
+}
+
+func (l *looper) writeUnboundFile(path string, data []byte) error {
+	file, err := l.openFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err := file.Chown(l.puid, l.pgid); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// generateSelfSignedCert generates a PEM encoded self-signed ECDSA
+// certificate valid for 10 years and its PEM encoded private key.
+func generateSelfSignedCert(now time.Time) (certPEM, keyPEM []byte, err error) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	const serialNumberBits = 128
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), serialNumberBits))
+	if err != nil {
+		return nil, nil, err
+	}
+	const validityYears = 10
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{CommonName: "gluetun"},
+		NotBefore:             now,
+		NotAfter:              now.AddDate(validityYears, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
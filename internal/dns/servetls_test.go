@@ -0,0 +1,24 @@
+package dns
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_generateSelfSignedCert(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	certPEM, keyPEM, err := generateSelfSignedCert(now)
+	require.NoError(t, err)
+	keyPair, err := tls.X509KeyPair(certPEM, keyPEM)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(keyPair.Certificate[0])
+	require.NoError(t, err)
+	assert.Equal(t, "gluetun", certificate.Subject.CommonName)
+	assert.Equal(t, now.AddDate(10, 0, 0), certificate.NotAfter)
+}
@@ -0,0 +1,103 @@
+package dns
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrStatsDisabled    = errors.New("DNS statistics are disabled")
+	ErrControlCommand   = errors.New("Unbound control command failed")
+	ErrControlMalformed = errors.New("malformed Unbound control output")
+)
+
+// Stats contains query statistics obtained through
+// the Unbound remote control interface.
+type Stats struct {
+	Queries     uint64 `json:"queries"`
+	CacheHits   uint64 `json:"cache_hits"`
+	CacheMisses uint64 `json:"cache_misses"`
+	Prefetches  uint64 `json:"prefetches"`
+}
+
+const controlTimeout = 3 * time.Second
+
+func (l *looper) GetStats(ctx context.Context) (stats Stats, err error) {
+	if !l.GetSettings().Stats {
+		return stats, ErrStatsDisabled
+	}
+	lines, err := runControlCommand(ctx, unboundControlSocket, "stats_noreset")
+	if err != nil {
+		return stats, err
+	}
+	return parseStats(lines)
+}
+
+// runControlCommand runs the command on the Unbound remote control unix
+// socket, which does not use TLS, and returns the output lines.
+func runControlCommand(ctx context.Context, socketPath, command string) (
+	lines []string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, controlTimeout)
+	defer cancel()
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	const protocolVersion = "UBCT1 "
+	if _, err := conn.Write([]byte(protocolVersion + command + "\n")); err != nil {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "error") {
+			return nil, fmt.Errorf("%w: %s: %s", ErrControlCommand, command, line)
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// parseStats parses the key=value lines of the stats command
+// to extract the total counters of all the Unbound threads.
+func parseStats(lines []string) (stats Stats, err error) {
+	for _, line := range lines {
+		parts := strings.SplitN(line, "=", 2) //nolint:gomnd
+		if len(parts) != 2 {                  //nolint:gomnd
+			return stats, fmt.Errorf("%w: line %q", ErrControlMalformed, line)
+		}
+		key, value := parts[0], parts[1]
+		var field *uint64
+		switch key {
+		case "total.num.queries":
+			field = &stats.Queries
+		case "total.num.cachehits":
+			field = &stats.CacheHits
+		case "total.num.cachemiss":
+			field = &stats.CacheMisses
+		case "total.num.prefetch":
+			field = &stats.Prefetches
+		default:
+			continue
+		}
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return stats, fmt.Errorf("%w: %s: %s", ErrControlMalformed, key, err)
+		}
+		*field = n
+	}
+	return stats, nil
+}
@@ -0,0 +1,53 @@
+package dns
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseStats(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		lines []string
+		stats Stats
+		err   error
+	}{
+		"no line": {},
+		"totals": {
+			lines: []string{
+				"thread0.num.queries=3",
+				"total.num.queries=10",
+				"total.num.cachehits=7",
+				"total.num.cachemiss=3",
+				"total.num.prefetch=1",
+				"time.up=12.5",
+			},
+			stats: Stats{Queries: 10, CacheHits: 7, CacheMisses: 3, Prefetches: 1},
+		},
+		"malformed line": {
+			lines: []string{"total.num.queries"},
+			err:   errors.New(`malformed Unbound control output: line "total.num.queries"`),
+		},
+		"malformed value": {
+			lines: []string{"total.num.queries=a"},
+			err: errors.New(`malformed Unbound control output: total.num.queries: ` +
+				`strconv.ParseUint: parsing "a": invalid syntax`),
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			stats, err := parseStats(testCase.lines)
+			if testCase.err != nil {
+				assert.EqualError(t, err, testCase.err.Error())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.stats, stats)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package dns
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/settings"
+	"github.com/qdm12/golibs/os"
+)
+
+const (
+	unboundConfFilepath  = "/etc/unbound/unbound.conf"
+	unboundControlSocket = "/etc/unbound/control.sock"
+	unboundIncludeConf   = "/etc/unbound/include.conf"
+)
+
+// customizeUnboundConf modifies the Unbound configuration file written
+// by the Unbound configurator according to the settings it does not support.
+func (l *looper) customizeUnboundConf(settings settings.DNS) error {
+	b, err := readFile(l.openFile, unboundConfFilepath)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(b), "\n")
+	lines = customizeUnboundLines(lines, settings)
+	file, err := l.openFile(unboundConfFilepath, os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(strings.Join(lines, "\n")); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func customizeUnboundLines(lines []string, settings settings.DNS) []string {
+	if !settings.Unbound.IPv6 {
+		lines = removeIPv6ForwardAddrs(lines)
+	}
+	if settings.IPFamily == constants.IPv6Family {
+		lines = removeIPv4ForwardAddrs(lines)
+	}
+	if settings.Threads > 0 {
+		lines = setServerDirective(lines, "num-threads", strconv.Itoa(settings.Threads))
+		slabs := strconv.Itoa(slabsForThreads(settings.Threads))
+		for _, directive := range []string{"msg-cache-slabs", "rrset-cache-slabs",
+			"infra-cache-slabs", "key-cache-slabs"} {
+			lines = setServerDirective(lines, directive, slabs)
+		}
+	}
+	if settings.RateLimit > 0 {
+		rateLimit := strconv.Itoa(settings.RateLimit)
+		lines = setServerDirective(lines, "ratelimit", rateLimit)
+		lines = setServerDirective(lines, "ip-ratelimit", rateLimit)
+	}
+	if settings.MaxQueries > 0 {
+		lines = setServerDirective(lines, "num-queries-per-thread", strconv.Itoa(settings.MaxQueries))
+		// Unbound recommends twice as many outgoing ports as queries per thread
+		lines = setServerDirective(lines, "outgoing-range", strconv.Itoa(2*settings.MaxQueries)) //nolint:gomnd
+	}
+	prefetch := "no"
+	if settings.Prefetch {
+		prefetch = "yes"
+	}
+	lines = setServerDirective(lines, "prefetch", prefetch)
+	lines = setServerDirective(lines, "prefetch-key", prefetch)
+	if settings.SoRcvBuf > 0 {
+		lines = setServerDirective(lines, "so-rcvbuf", strconv.FormatUint(settings.SoRcvBuf, 10))
+	}
+	if settings.SoSndBuf > 0 {
+		lines = setServerDirective(lines, "so-sndbuf", strconv.FormatUint(settings.SoSndBuf, 10))
+	}
+	if len(settings.LogFile) > 0 {
+		lines = setServerDirective(lines, "logfile", strconv.Quote(settings.LogFile))
+	}
+	if len(settings.CABundle) > 0 {
+		lines = setServerDirective(lines, "tls-cert-bundle", strconv.Quote(settings.CABundle))
+	}
+	if len(settings.RootHints) > 0 {
+		lines = setServerDirective(lines, "root-hints", strconv.Quote(settings.RootHints))
+	}
+	if settings.ServeTLS {
+		host, port, _ := net.SplitHostPort(settings.ServeTLSAddress)
+		lines = addServerDirective(lines, "interface", host+"@"+port)
+		lines = setServerDirective(lines, "tls-port", port)
+		lines = setServerDirective(lines, "tls-service-pem", strconv.Quote(settings.ServeTLSCertFile))
+		lines = setServerDirective(lines, "tls-service-key", strconv.Quote(settings.ServeTLSKeyFile))
+	}
+	if settings.Stats {
+		lines = append(lines, "remote-control:",
+			serverIndent+"control-enable: yes",
+			serverIndent+"control-interface: "+strconv.Quote(unboundControlSocket),
+			serverIndent+"control-use-cert: no")
+	}
+	return lines
+}
+
+// removeIPv6ForwardAddrs removes the forward address lines using an IPv6
+// address, such that Unbound only uses the IPv4 endpoints of the providers.
+func removeIPv6ForwardAddrs(lines []string) []string {
+	return removeForwardAddrs(lines, func(ip net.IP) bool { return ip.To4() == nil })
+}
+
+// removeIPv4ForwardAddrs removes the forward address lines using an IPv4
+// address, such that Unbound only uses the IPv6 endpoints of the providers.
+func removeIPv4ForwardAddrs(lines []string) []string {
+	return removeForwardAddrs(lines, func(ip net.IP) bool { return ip.To4() != nil })
+}
+
+func removeForwardAddrs(lines []string, remove func(ip net.IP) bool) []string {
+	filtered := make([]string, 0, len(lines))
+	for _, line := range lines {
+		value := strings.TrimPrefix(strings.TrimSpace(line), "forward-addr:")
+		if value != strings.TrimSpace(line) {
+			host := strings.TrimSpace(strings.SplitN(value, "@", 2)[0]) //nolint:gomnd
+			if ip := net.ParseIP(host); ip != nil && remove(ip) {
+				continue
+			}
+		}
+		filtered = append(filtered, line)
+	}
+	return filtered
+}
+
+// createLogFile creates the Unbound log file if it does not exist,
+// and makes sure it is writable by the Unbound user.
+func (l *looper) createLogFile(path string) error {
+	file, err := l.openFile(path, os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("cannot create Unbound log file: %w", err)
+	}
+	if err := file.Chown(l.puid, l.pgid); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("cannot set ownership of Unbound log file: %w", err)
+	}
+	return file.Close()
+}
+
+// createIncludeConf creates the empty file included by the Unbound
+// configuration if it does not exist, as it is normally created
+// once the cryptographic files are downloaded.
+func (l *looper) createIncludeConf() error {
+	file, err := l.openFile(unboundIncludeConf, os.O_CREATE, 0644)
+	if err != nil {
+		return fmt.Errorf("cannot create Unbound include file: %w", err)
+	}
+	return file.Close()
+}
+
+// slabsForThreads returns the smallest power of 2 greater or equal
+// to the number of threads, as Unbound cache slabs must be a power of 2
+// and should be close to the number of threads to reduce lock contention.
+func slabsForThreads(threads int) (slabs int) {
+	slabs = 1
+	for slabs < threads {
+		slabs *= 2
+	}
+	return slabs
+}
+
+const serverIndent = "  "
+
+// setServerDirective sets the value of the directive in the server clause,
+// replacing its existing value or adding it at the start of the clause.
+func setServerDirective(lines []string, directive, value string) []string {
+	newLine := serverIndent + directive + ": " + value
+	serverIndex := -1
+	for i, line := range lines {
+		switch {
+		case line == "server:":
+			serverIndex = i
+		case serverIndex == -1:
+		case !strings.HasPrefix(line, " "): // end of server clause
+			return insertLine(lines, serverIndex+1, newLine)
+		case strings.HasPrefix(strings.TrimSpace(line), directive+":"):
+			lines[i] = newLine
+			return lines
+		}
+	}
+	if serverIndex == -1 {
+		lines = append(lines, "server:")
+		serverIndex = len(lines) - 1
+	}
+	return insertLine(lines, serverIndex+1, newLine)
+}
+
+// addServerDirective adds the directive with its value at the start of the
+// server clause, keeping existing lines using the same directive.
+func addServerDirective(lines []string, directive, value string) []string {
+	newLine := serverIndent + directive + ": " + value
+	for i, line := range lines {
+		if line == "server:" {
+			return insertLine(lines, i+1, newLine)
+		}
+	}
+	return append(lines, "server:", newLine)
+}
+
+func insertLine(lines []string, index int, line string) []string {
+	result := make([]string, 0, len(lines)+1)
+	result = append(result, lines[:index]...)
+	result = append(result, line)
+	result = append(result, lines[index:]...)
+	return result
+}
@@ -0,0 +1,106 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_setServerDirective(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		lines     []string
+		directive string
+		value     string
+		expected  []string
+	}{
+		"no server clause": {
+			directive: "ratelimit",
+			value:     "10",
+			expected:  []string{"server:", "  ratelimit: 10"},
+		},
+		"add directive": {
+			lines:     []string{"server:", "  port: 53", "forward-zone:", `  name: "."`},
+			directive: "ratelimit",
+			value:     "10",
+			expected:  []string{"server:", "  ratelimit: 10", "  port: 53", "forward-zone:", `  name: "."`},
+		},
+		"replace directive": {
+			lines:     []string{"server:", "  num-threads: 2", "forward-zone:", "  num-threads: 1"},
+			directive: "num-threads",
+			value:     "4",
+			expected:  []string{"server:", "  num-threads: 4", "forward-zone:", "  num-threads: 1"},
+		},
+		"directive prefix of another": {
+			lines:     []string{"server:", "  ip-ratelimit: 5"},
+			directive: "ratelimit",
+			value:     "10",
+			expected:  []string{"server:", "  ratelimit: 10", "  ip-ratelimit: 5"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			lines := setServerDirective(testCase.lines, testCase.directive, testCase.value)
+			assert.Equal(t, testCase.expected, lines)
+		})
+	}
+}
+
+func Test_addServerDirective(t *testing.T) {
+	t.Parallel()
+	lines := []string{"server:", "  interface: 0.0.0.0", "forward-zone:"}
+	lines = addServerDirective(lines, "interface", "0.0.0.0@853")
+	assert.Equal(t, []string{"server:", "  interface: 0.0.0.0@853",
+		"  interface: 0.0.0.0", "forward-zone:"}, lines)
+}
+
+func Test_slabsForThreads(t *testing.T) {
+	t.Parallel()
+	testCases := map[int]int{
+		1:  1,
+		2:  2,
+		3:  4,
+		8:  8,
+		9:  16,
+		16: 16,
+	}
+	for threads, expectedSlabs := range testCases {
+		slabs := slabsForThreads(threads)
+		assert.Equal(t, expectedSlabs, slabs)
+	}
+}
+
+func Test_removeIPv6ForwardAddrs(t *testing.T) {
+	t.Parallel()
+	lines := []string{
+		"forward-zone:",
+		"  forward-addr: 1.1.1.1@853#cloudflare-dns.com",
+		"  forward-addr: 2606:4700:4700::1111@853#cloudflare-dns.com",
+		"  forward-tls-upstream: yes",
+	}
+	lines = removeIPv6ForwardAddrs(lines)
+	assert.Equal(t, []string{
+		"forward-zone:",
+		"  forward-addr: 1.1.1.1@853#cloudflare-dns.com",
+		"  forward-tls-upstream: yes",
+	}, lines)
+}
+
+func Test_removeIPv4ForwardAddrs(t *testing.T) {
+	t.Parallel()
+	lines := []string{
+		"forward-zone:",
+		"  forward-addr: 1.1.1.1@853#cloudflare-dns.com",
+		"  forward-addr: 2606:4700:4700::1111@853#cloudflare-dns.com",
+		"  forward-tls-upstream: yes",
+	}
+	lines = removeIPv4ForwardAddrs(lines)
+	assert.Equal(t, []string{
+		"forward-zone:",
+		"  forward-addr: 2606:4700:4700::1111@853#cloudflare-dns.com",
+		"  forward-tls-upstream: yes",
+	}, lines)
+}
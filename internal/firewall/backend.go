@@ -0,0 +1,63 @@
+package firewall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/qdm12/gluetun/internal/constants"
+)
+
+var ErrNoFirewallBackend = errors.New("no firewall backend is available")
+
+const (
+	iptablesLegacyBinary = "iptables"
+	// iptablesNftBinary runs the same rules as iptables
+	// but sets them using the nftables kernel backend.
+	iptablesNftBinary = "iptables-nft"
+)
+
+// SetBackend selects the binary used to set the firewall rules for the backend given,
+// which can be iptables, nftables or auto. If the backend chosen is not available,
+// it falls back on the other backend with a warning.
+func (c *configurator) SetBackend(ctx context.Context, backend string) (err error) {
+	backends := []string{constants.IptablesBackend, constants.NftablesBackend}
+	if backend == constants.NftablesBackend {
+		backends[0], backends[1] = backends[1], backends[0]
+	}
+
+	for i, candidate := range backends {
+		binary := backendBinary(candidate)
+		if err := c.checkBinary(ctx, binary); err != nil {
+			if backend != constants.AutoFirewallBackend || i > 0 {
+				c.logger.Warn("%s backend is not available: %s", candidate, err)
+			}
+			continue
+		}
+		if backend != constants.AutoFirewallBackend && candidate != backend {
+			c.logger.Warn("falling back on the %s backend", candidate)
+		}
+		c.iptablesMutex.Lock()
+		c.iptablesBinary = binary
+		c.iptablesMutex.Unlock()
+		c.logger.Info("using %s backend", candidate)
+		return nil
+	}
+	return ErrNoFirewallBackend
+}
+
+func backendBinary(backend string) string {
+	if backend == constants.NftablesBackend {
+		return iptablesNftBinary
+	}
+	return iptablesLegacyBinary
+}
+
+// checkBinary verifies the binary can list the rules, which fails
+// if the binary is missing or if the kernel lacks support for its backend.
+func (c *configurator) checkBinary(ctx context.Context, binary string) error {
+	if output, err := c.commander.Run(ctx, binary, "--list", "--numeric"); err != nil {
+		return fmt.Errorf("%s: %w", output, err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package firewall
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/golibs/command/mock_command"
+	"github.com/qdm12/golibs/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_configurator_SetBackend(t *testing.T) {
+	t.Parallel()
+	errNotFound := errors.New("not found")
+	testCases := map[string]struct {
+		backend   string
+		available map[string]bool // binary to availability
+		checked   []string
+		binary    string
+		err       error
+	}{
+		"iptables available": {
+			backend:   constants.IptablesBackend,
+			available: map[string]bool{iptablesLegacyBinary: true},
+			checked:   []string{iptablesLegacyBinary},
+			binary:    iptablesLegacyBinary,
+		},
+		"nftables available": {
+			backend:   constants.NftablesBackend,
+			available: map[string]bool{iptablesNftBinary: true},
+			checked:   []string{iptablesNftBinary},
+			binary:    iptablesNftBinary,
+		},
+		"nftables falls back on iptables": {
+			backend:   constants.NftablesBackend,
+			available: map[string]bool{iptablesLegacyBinary: true},
+			checked:   []string{iptablesNftBinary, iptablesLegacyBinary},
+			binary:    iptablesLegacyBinary,
+		},
+		"iptables falls back on nftables": {
+			backend:   constants.IptablesBackend,
+			available: map[string]bool{iptablesNftBinary: true},
+			checked:   []string{iptablesLegacyBinary, iptablesNftBinary},
+			binary:    iptablesNftBinary,
+		},
+		"auto prefers iptables": {
+			backend:   constants.AutoFirewallBackend,
+			available: map[string]bool{iptablesLegacyBinary: true, iptablesNftBinary: true},
+			checked:   []string{iptablesLegacyBinary},
+			binary:    iptablesLegacyBinary,
+		},
+		"auto uses nftables": {
+			backend:   constants.AutoFirewallBackend,
+			available: map[string]bool{iptablesNftBinary: true},
+			checked:   []string{iptablesLegacyBinary, iptablesNftBinary},
+			binary:    iptablesNftBinary,
+		},
+		"no backend available": {
+			backend: constants.AutoFirewallBackend,
+			checked: []string{iptablesLegacyBinary, iptablesNftBinary},
+			binary:  iptablesLegacyBinary,
+			err:     ErrNoFirewallBackend,
+		},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+			logger, err := logging.NewEmptyLogger()
+			require.NoError(t, err)
+
+			commander := mock_command.NewMockCommander(ctrl)
+			var previousCall *gomock.Call
+			for _, binary := range testCase.checked {
+				var checkErr error
+				if !testCase.available[binary] {
+					checkErr = errNotFound
+				}
+				call := commander.EXPECT().Run(ctx, binary, "--list", "--numeric").Return("", checkErr)
+				if previousCall != nil {
+					call.After(previousCall)
+				}
+				previousCall = call
+			}
+			c := &configurator{
+				commander:      commander,
+				logger:         logger,
+				iptablesBinary: iptablesLegacyBinary,
+			}
+
+			err = c.SetBackend(ctx, testCase.backend)
+			if testCase.err != nil {
+				assert.True(t, errors.Is(err, testCase.err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.binary, c.iptablesBinary)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/qdm12/gluetun/internal/models"
+)
+
+// SetVPNCandidates temporarily allows outbound traffic to the VPN server
+// connections given, in addition to the VPN connection, such that they can be
+// tested before picking one. Call it with no connection to remove them.
+func (c *configurator) SetVPNCandidates(ctx context.Context, connections []models.OpenVPNConnection) (err error) {
+	c.stateMutex.Lock()
+	defer c.stateMutex.Unlock()
+
+	if !c.enabled {
+		c.vpnCandidates = connections
+		return nil
+	}
+
+	remove := true
+	for _, connection := range c.vpnCandidates {
+		if connection.Equal(c.vpnConnection) {
+			continue
+		}
+		if err := c.acceptOutputTrafficToVPN(ctx, c.defaultInterface, connection, remove); err != nil {
+			c.logger.Error("cannot remove VPN candidate connection through firewall: %s", err)
+		}
+	}
+	c.vpnCandidates = nil
+
+	remove = false
+	for i, connection := range connections {
+		if connection.Equal(c.vpnConnection) {
+			continue
+		}
+		if err := c.acceptOutputTrafficToVPN(ctx, c.defaultInterface, connection, remove); err != nil {
+			c.vpnCandidates = connections[:i]
+			return fmt.Errorf("cannot set VPN candidate connection through firewall: %w", err)
+		}
+	}
+	c.vpnCandidates = connections
+	return nil
+}
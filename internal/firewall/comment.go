@@ -0,0 +1,112 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SetRuleComment sets the comment to tag each rule set with, such that only
+// rules with this comment are removed when the firewall is disabled.
+// It is meant to be called only once before enabling the firewall.
+func (c *configurator) SetRuleComment(comment string) {
+	c.iptablesMutex.Lock()
+	defer c.iptablesMutex.Unlock()
+	c.ruleComment = comment
+}
+
+// withComment adds the comment match to the instruction if it appends,
+// inserts or deletes a rule and does not already have a comment.
+func withComment(instruction, comment string) string {
+	if len(comment) == 0 || strings.Contains(instruction, "--comment") {
+		return instruction
+	}
+	for _, flag := range strings.Fields(instruction) {
+		switch flag {
+		case "-A", "--append", "-I", "--insert", "-D", "--delete":
+			return instruction + " -m comment --comment " + comment
+		}
+	}
+	return instruction
+}
+
+// commentedRulesDeletions returns the instructions to delete each rule
+// tagged with the comment from the output of iptables --list-rules, followed
+// by the instructions to delete the user chains only holding tagged rules,
+// such as the chains created by the user post rules.
+func commentedRulesDeletions(rules, comment string) (instructions []string) {
+	var chains []string
+	chainRules := make(map[string]int)
+	chainTaggedRules := make(map[string]int)
+	for _, line := range strings.Split(rules, "\n") {
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 2 && fields[0] == "-N": //nolint:gomnd
+			chains = append(chains, fields[1])
+			continue
+		case len(fields) < 2 || fields[0] != "-A": //nolint:gomnd
+			continue
+		}
+		chain := fields[1]
+		chainRules[chain]++
+		tagged := false
+		for i := 1; i < len(fields)-1; i++ {
+			if fields[i] == "--comment" && strings.Trim(fields[i+1], `"`) == comment {
+				fields[i+1] = comment
+				tagged = true
+				break
+			}
+		}
+		if tagged {
+			chainTaggedRules[chain]++
+			fields[0] = "-D"
+			instructions = append(instructions, strings.Join(fields, " "))
+		}
+	}
+	for _, chain := range chains {
+		if chainRules[chain] > 0 && chainRules[chain] == chainTaggedRules[chain] {
+			instructions = append(instructions, "--delete-chain "+chain)
+		}
+	}
+	return instructions
+}
+
+// commentedTables are the iptables tables from which the rules
+// tagged with the rule comment are removed.
+var commentedTables = []string{"filter", "nat", "mangle"} //nolint:gochecknoglobals
+
+// listCommentedRulesDeletions returns the instructions to delete the rules
+// tagged with the comment and the user chains only holding such rules,
+// for each table. A table other than the filter table which cannot be
+// listed, for example if its kernel module is not loaded, is skipped.
+func (c *configurator) listCommentedRulesDeletions(ctx context.Context, binary, comment string) (
+	instructions []string, err error) {
+	for _, table := range commentedTables {
+		output, err := c.commander.Run(ctx, binary, "--table", table, "--list-rules")
+		if err != nil {
+			if table != "filter" {
+				c.logger.Debug("cannot list rules of table %s: %s: %s", table, output, err)
+				continue
+			}
+			return nil, fmt.Errorf("cannot list rules: %s: %w", output, err)
+		}
+		for _, instruction := range commentedRulesDeletions(output, comment) {
+			instructions = append(instructions, "--table "+table+" "+instruction)
+		}
+	}
+	return instructions, nil
+}
+
+// clearCommentedRules removes the rules tagged with the rule comment
+// from the filter, nat and mangle tables, as well as the user chains
+// only holding such rules, leaving rules set by other programs untouched.
+func (c *configurator) clearCommentedRules(ctx context.Context) error {
+	c.iptablesMutex.Lock()
+	binary, comment := c.iptablesBinary, c.ruleComment
+	c.iptablesMutex.Unlock()
+	instructions, err := c.listCommentedRulesDeletions(ctx, binary, comment)
+	if err != nil {
+		return err
+	}
+	return c.runIptablesInstructions(ctx, instructions)
+}
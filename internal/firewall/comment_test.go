@@ -0,0 +1,160 @@
+package firewall
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/qdm12/golibs/command/mock_command"
+	"github.com/qdm12/golibs/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_withComment(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		instruction string
+		comment     string
+		expected    string
+	}{
+		"no comment": {
+			instruction: "--append INPUT -i lo -j ACCEPT",
+			expected:    "--append INPUT -i lo -j ACCEPT",
+		},
+		"append rule": {
+			instruction: "--append INPUT -i lo -j ACCEPT",
+			comment:     "gluetun",
+			expected:    "--append INPUT -i lo -j ACCEPT -m comment --comment gluetun",
+		},
+		"table and delete rule": {
+			instruction: "-t nat -D POSTROUTING -o eth0 -j MASQUERADE",
+			comment:     "gluetun",
+			expected:    "-t nat -D POSTROUTING -o eth0 -j MASQUERADE -m comment --comment gluetun",
+		},
+		"already commented": {
+			instruction: "-D INPUT -i lo -m comment --comment gluetun -j ACCEPT",
+			comment:     "gluetun",
+			expected:    "-D INPUT -i lo -m comment --comment gluetun -j ACCEPT",
+		},
+		"policy": {
+			instruction: "--policy INPUT DROP",
+			comment:     "gluetun",
+			expected:    "--policy INPUT DROP",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			instruction := withComment(testCase.instruction, testCase.comment)
+			assert.Equal(t, testCase.expected, instruction)
+		})
+	}
+}
+
+func Test_commentedRulesDeletions(t *testing.T) {
+	t.Parallel()
+	const rules = `-P INPUT DROP
+-P OUTPUT DROP
+-A INPUT -i lo -m comment --comment gluetun -j ACCEPT
+-A INPUT -p tcp -m tcp --dport 22 -j ACCEPT
+-A OUTPUT -o tun0 -m comment --comment "gluetun" -j ACCEPT
+-N USER
+-N OTHER
+-N EMPTY
+-A OUTPUT -o eth0 -m comment --comment other -j ACCEPT
+-A OUTPUT -m comment --comment gluetun -j USER
+-A USER -d 10.0.0.0/8 -m comment --comment gluetun -j ACCEPT
+-A OTHER -d 10.0.0.0/8 -m comment --comment gluetun -j ACCEPT
+-A OTHER -j DROP
+`
+	instructions := commentedRulesDeletions(rules, "gluetun")
+	expected := []string{
+		"-D INPUT -i lo -m comment --comment gluetun -j ACCEPT",
+		"-D OUTPUT -o tun0 -m comment --comment gluetun -j ACCEPT",
+		"-D OUTPUT -m comment --comment gluetun -j USER",
+		"-D USER -d 10.0.0.0/8 -m comment --comment gluetun -j ACCEPT",
+		"-D OTHER -d 10.0.0.0/8 -m comment --comment gluetun -j ACCEPT",
+		"--delete-chain USER",
+	}
+	assert.Equal(t, expected, instructions)
+}
+
+func Test_configurator_clearCommentedRules(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		tableRules   map[string]string
+		tableErrs    map[string]error
+		instructions []string
+		err          error
+	}{
+		"all tables": {
+			tableRules: map[string]string{
+				"filter": "-A INPUT -i lo -m comment --comment gluetun -j ACCEPT\n",
+				"nat": "-N USER\n-A POSTROUTING -m comment --comment gluetun -j USER\n" +
+					"-A USER -o eth0 -m comment --comment gluetun -j MASQUERADE\n",
+				"mangle": "-A OUTPUT -j MARK --set-mark 1\n",
+			},
+			instructions: []string{
+				"--table filter -D INPUT -i lo -m comment --comment gluetun -j ACCEPT",
+				"--table nat -D POSTROUTING -m comment --comment gluetun -j USER",
+				"--table nat -D USER -o eth0 -m comment --comment gluetun -j MASQUERADE",
+				"--table nat --delete-chain USER",
+			},
+		},
+		"nat table unavailable": {
+			tableRules: map[string]string{
+				"filter": "-A INPUT -i lo -m comment --comment gluetun -j ACCEPT\n",
+			},
+			tableErrs: map[string]error{"nat": errors.New("no table")},
+			instructions: []string{
+				"--table filter -D INPUT -i lo -m comment --comment gluetun -j ACCEPT",
+			},
+		},
+		"filter table error": {
+			tableErrs: map[string]error{"filter": errors.New("permission denied")},
+			err:       errors.New("cannot list rules: : permission denied"),
+		},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+			logger, err := logging.NewEmptyLogger()
+			require.NoError(t, err)
+
+			commander := mock_command.NewMockCommander(ctrl)
+			for _, table := range commentedTables {
+				commander.EXPECT().Run(ctx, iptablesLegacyBinary, "--table", table, "--list-rules").
+					Return(testCase.tableRules[table], testCase.tableErrs[table])
+				if table == "filter" && testCase.tableErrs[table] != nil {
+					break
+				}
+			}
+			for _, instruction := range testCase.instructions {
+				commander.EXPECT().Run(ctx, iptablesLegacyBinary, strings.Fields(instruction)).Return("", nil)
+			}
+			c := &configurator{
+				commander:      commander,
+				logger:         logger,
+				iptablesBinary: iptablesLegacyBinary,
+				ruleComment:    "gluetun",
+			}
+
+			err = c.clearCommentedRules(ctx)
+			if testCase.err != nil {
+				require.Error(t, err)
+				assert.Equal(t, testCase.err.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
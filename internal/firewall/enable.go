@@ -11,6 +11,11 @@ func (c *configurator) SetEnabled(ctx context.Context, enabled bool) (err error)
 	c.stateMutex.Lock()
 	defer c.stateMutex.Unlock()
 
+	if c.pauseTimer != nil {
+		c.pauseTimer.Stop()
+		c.pauseTimer = nil
+	}
+
 	if enabled == c.enabled {
 		if enabled {
 			c.logger.Info("already enabled")
@@ -48,6 +53,9 @@ func (c *configurator) disable(ctx context.Context) (err error) {
 	if err = c.setAllPolicies(ctx, "ACCEPT"); err != nil {
 		return fmt.Errorf("cannot disable firewall: %w", err)
 	}
+	if err = c.disableIPv6(ctx); err != nil {
+		return fmt.Errorf("cannot disable firewall: %w", err)
+	}
 	return nil
 }
 
@@ -90,8 +98,26 @@ func (c *configurator) enable(ctx context.Context) (err error) {
 			return fmt.Errorf("cannot enable firewall: %w", err)
 		}
 	}
-	if err = c.acceptOutputThroughInterface(ctx, string(constants.TUN), remove); err != nil {
-		return fmt.Errorf("cannot enable firewall: %w", err)
+	for _, connection := range c.vpnCandidates {
+		if connection.Equal(c.vpnConnection) {
+			continue
+		}
+		if err = c.acceptOutputTrafficToVPN(ctx, c.defaultInterface, connection, remove); err != nil {
+			return fmt.Errorf("cannot enable firewall: %w", err)
+		}
+	}
+	if c.preConnectProxy.IP != nil {
+		if err = c.acceptOutputTrafficToVPN(ctx, c.defaultInterface, c.preConnectProxy, remove); err != nil {
+			return fmt.Errorf("cannot enable firewall: %w", err)
+		}
+	}
+	c.iptablesMutex.Lock()
+	family := c.ipFamily
+	c.iptablesMutex.Unlock()
+	if family != constants.IPv6Family {
+		if err = c.acceptOutputThroughInterface(ctx, string(constants.TUN), remove); err != nil {
+			return fmt.Errorf("cannot enable firewall: %w", err)
+		}
 	}
 
 	if err := c.acceptOutputFromIPToSubnet(ctx, c.defaultInterface, c.localIP, c.localSubnet, remove); err != nil {
@@ -116,6 +142,10 @@ func (c *configurator) enable(ctx context.Context) (err error) {
 		}
 	}
 
+	if err := c.enableIPv6(ctx); err != nil {
+		return fmt.Errorf("cannot enable firewall: %w", err)
+	}
+
 	if err := c.runUserPostRules(ctx, "/iptables/post-rules.txt", remove); err != nil {
 		return fmt.Errorf("cannot enable firewall: %w", err)
 	}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"net"
 	"sync"
+	"time"
 
+	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/models"
 	"github.com/qdm12/gluetun/internal/routing"
 	"github.com/qdm12/golibs/command"
@@ -16,10 +18,17 @@ import (
 type Configurator interface {
 	Version(ctx context.Context) (string, error)
 	SetEnabled(ctx context.Context, enabled bool) (err error)
+	Pause(ctx context.Context, duration time.Duration) (err error)
 	SetVPNConnection(ctx context.Context, connection models.OpenVPNConnection) (err error)
+	SetVPNCandidates(ctx context.Context, connections []models.OpenVPNConnection) (err error)
+	SetPreConnectProxy(ctx context.Context, ip net.IP, port uint16) (err error)
 	SetAllowedPort(ctx context.Context, port uint16, intf string) (err error)
 	SetOutboundSubnets(ctx context.Context, subnets []net.IPNet) (err error)
+	SetInputSources(ctx context.Context, sources []net.IPNet) (err error)
 	RemoveAllowedPort(ctx context.Context, port uint16) (err error)
+	SetBackend(ctx context.Context, backend string) (err error)
+	SetRuleComment(comment string)
+	SetIPFamily(family string)
 	SetDebug()
 	// SetNetworkInformation is meant to be called only once
 	SetNetworkInformation(defaultInterface string, defaultGateway net.IP, localSubnet net.IPNet, localIP net.IP)
@@ -31,6 +40,9 @@ type configurator struct { //nolint:maligned
 	routing          routing.Routing
 	openFile         os.OpenFileFunc // for custom iptables rules
 	iptablesMutex    sync.Mutex
+	iptablesBinary   string
+	ruleComment      string
+	ipFamily         string
 	debug            bool
 	defaultInterface string
 	defaultGateway   net.IP
@@ -41,8 +53,13 @@ type configurator struct { //nolint:maligned
 	// State
 	enabled           bool
 	vpnConnection     models.OpenVPNConnection
+	vpnCandidates     []models.OpenVPNConnection
+	preConnectProxy   models.OpenVPNConnection
 	outboundSubnets   []net.IPNet
+	inputSources      []net.IPNet
 	allowedInputPorts map[uint16]string // port to interface mapping
+	pauseTimer        *time.Timer
+	pauseID           uint64
 	stateMutex        sync.Mutex
 }
 
@@ -53,6 +70,8 @@ func NewConfigurator(logger logging.Logger, routing routing.Routing, openFile os
 		logger:            logger.WithPrefix("firewall: "),
 		routing:           routing,
 		openFile:          openFile,
+		iptablesBinary:    iptablesLegacyBinary,
+		ipFamily:          constants.IPv4Family,
 		allowedInputPorts: make(map[uint16]string),
 	}
 }
@@ -0,0 +1,49 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+// SetInputSources sets the source subnets allowed to reach the input ports
+// allowed through the default interface. If no source subnet is set, any
+// source can reach these ports.
+func (c *configurator) SetInputSources(ctx context.Context, sources []net.IPNet) (err error) {
+	c.stateMutex.Lock()
+	defer c.stateMutex.Unlock()
+
+	if !c.enabled {
+		c.logger.Info("firewall disabled, only updating input sources internal list")
+		c.inputSources = make([]net.IPNet, len(sources))
+		copy(c.inputSources, sources)
+		return nil
+	}
+
+	c.logger.Info("setting input sources through firewall...")
+
+	for port, intf := range c.allowedInputPorts {
+		if intf != c.defaultInterface {
+			continue
+		}
+		const remove = true
+		if err := c.acceptInputToPort(ctx, intf, port, remove); err != nil {
+			return fmt.Errorf("cannot remove allowed port %d through interface %s: %w", port, intf, err)
+		}
+	}
+
+	c.inputSources = make([]net.IPNet, len(sources))
+	copy(c.inputSources, sources)
+
+	for port, intf := range c.allowedInputPorts {
+		if intf != c.defaultInterface {
+			continue
+		}
+		const remove = false
+		if err := c.acceptInputToPort(ctx, intf, port, remove); err != nil {
+			return fmt.Errorf("cannot set allowed port %d through interface %s: %w", port, intf, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/qdm12/gluetun/internal/constants"
+)
+
+// SetIPFamily sets the IP family allowed through the tunnel, which can be
+// ipv4, ipv6 or dual. It is meant to be called only once before enabling the firewall.
+func (c *configurator) SetIPFamily(family string) {
+	c.iptablesMutex.Lock()
+	defer c.iptablesMutex.Unlock()
+	c.ipFamily = family
+}
+
+// ip6tablesBinary returns the ip6tables binary of the same backend
+// as the iptables binary given.
+func ip6tablesBinary(iptablesBinary string) string {
+	return strings.Replace(iptablesBinary, "iptables", "ip6tables", 1)
+}
+
+func (c *configurator) runIP6tablesInstructions(ctx context.Context, instructions []string) error {
+	c.iptablesMutex.Lock() // only one iptables command at once
+	defer c.iptablesMutex.Unlock()
+	binary := ip6tablesBinary(c.iptablesBinary)
+	for _, instruction := range instructions {
+		instruction = withComment(instruction, c.ruleComment)
+		if c.debug {
+			fmt.Printf("%s %s\n", binary, instruction)
+		}
+		flags := strings.Fields(instruction)
+		if output, err := c.commander.Run(ctx, binary, flags...); err != nil {
+			return fmt.Errorf("failed executing \"%s %s\": %s: %w", binary, instruction, output, err)
+		}
+	}
+	return nil
+}
+
+// enableIPv6 drops all IPv6 traffic except loopback and established traffic,
+// and allows IPv6 traffic out through the tunnel only if the IP family uses IPv6.
+// It only warns if ip6tables is not available, since IPv6 is then not usable either.
+func (c *configurator) enableIPv6(ctx context.Context) error {
+	c.iptablesMutex.Lock()
+	binary, family := ip6tablesBinary(c.iptablesBinary), c.ipFamily
+	c.iptablesMutex.Unlock()
+	if err := c.checkBinary(ctx, binary); err != nil {
+		c.logger.Warn("cannot filter IPv6 traffic: %s", err)
+		return nil
+	}
+	instructions := []string{
+		"--policy INPUT DROP",
+		"--policy OUTPUT DROP",
+		"--policy FORWARD DROP",
+		"--append INPUT -i lo -j ACCEPT",
+		"--append OUTPUT -o lo -j ACCEPT",
+		"--append OUTPUT -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT",
+		"--append INPUT -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT",
+	}
+	if family != constants.IPv4Family {
+		instructions = append(instructions, "--append OUTPUT -o "+string(constants.TUN)+" -j ACCEPT")
+	}
+	return c.runIP6tablesInstructions(ctx, instructions)
+}
+
+// disableIPv6 removes the IPv6 rules and accepts all IPv6 traffic.
+func (c *configurator) disableIPv6(ctx context.Context) error {
+	c.iptablesMutex.Lock()
+	binary, comment := ip6tablesBinary(c.iptablesBinary), c.ruleComment
+	c.iptablesMutex.Unlock()
+	if err := c.checkBinary(ctx, binary); err != nil {
+		return nil //nolint:nilerr
+	}
+	instructions := []string{"--flush", "--delete-chain"}
+	if len(comment) > 0 {
+		var err error
+		instructions, err = c.listCommentedRulesDeletions(ctx, binary, comment)
+		if err != nil {
+			return err
+		}
+	}
+	instructions = append(instructions,
+		"--policy INPUT ACCEPT",
+		"--policy OUTPUT ACCEPT",
+		"--policy FORWARD ACCEPT",
+	)
+	return c.runIP6tablesInstructions(ctx, instructions)
+}
@@ -0,0 +1,103 @@
+package firewall
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/golibs/command/mock_command"
+	"github.com/qdm12/golibs/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_configurator_enableIPv6(t *testing.T) {
+	t.Parallel()
+	dropInstructions := []string{
+		"--policy INPUT DROP",
+		"--policy OUTPUT DROP",
+		"--policy FORWARD DROP",
+		"--append INPUT -i lo -j ACCEPT",
+		"--append OUTPUT -o lo -j ACCEPT",
+		"--append OUTPUT -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT",
+		"--append INPUT -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT",
+	}
+	testCases := map[string]struct {
+		family          string
+		iptablesBinary  string
+		ip6tablesBinary string
+		unavailable     bool
+		instructions    []string
+	}{
+		"ipv4 family": {
+			family:          constants.IPv4Family,
+			iptablesBinary:  iptablesLegacyBinary,
+			ip6tablesBinary: "ip6tables",
+			instructions:    dropInstructions,
+		},
+		"ipv6 family": {
+			family:          constants.IPv6Family,
+			iptablesBinary:  iptablesLegacyBinary,
+			ip6tablesBinary: "ip6tables",
+			instructions:    append(dropInstructions, "--append OUTPUT -o tun0 -j ACCEPT"),
+		},
+		"ipv4 family with nftables": {
+			family:          constants.IPv4Family,
+			iptablesBinary:  iptablesNftBinary,
+			ip6tablesBinary: "ip6tables-nft",
+			instructions:    dropInstructions,
+		},
+		"dual family with nftables": {
+			family:          constants.DualStackFamily,
+			iptablesBinary:  iptablesNftBinary,
+			ip6tablesBinary: "ip6tables-nft",
+			instructions:    append(dropInstructions, "--append OUTPUT -o tun0 -j ACCEPT"),
+		},
+		"ip6tables unavailable": {
+			family:          constants.IPv4Family,
+			iptablesBinary:  iptablesLegacyBinary,
+			ip6tablesBinary: "ip6tables",
+			unavailable:     true,
+		},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+			logger, err := logging.NewEmptyLogger()
+			require.NoError(t, err)
+
+			commander := mock_command.NewMockCommander(ctrl)
+			binary := testCase.ip6tablesBinary
+			var checkErr error
+			if testCase.unavailable {
+				checkErr = errors.New("not found")
+			}
+			commander.EXPECT().Run(ctx, binary, "--list", "--numeric").Return("", checkErr)
+			for _, instruction := range testCase.instructions {
+				commander.EXPECT().Run(ctx, binary, strings.Fields(instruction)).Return("", nil)
+			}
+			c := &configurator{
+				commander:      commander,
+				logger:         logger,
+				iptablesBinary: testCase.iptablesBinary,
+				ipFamily:       testCase.family,
+			}
+
+			err = c.enableIPv6(ctx)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_ip6tablesBinary(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "ip6tables", ip6tablesBinary(iptablesLegacyBinary))
+	assert.Equal(t, "ip6tables-nft", ip6tablesBinary(iptablesNftBinary))
+}
@@ -36,14 +36,17 @@ func flipRule(rule string) string {
 
 // Version obtains the version of the installed iptables.
 func (c *configurator) Version(ctx context.Context) (string, error) {
-	output, err := c.commander.Run(ctx, "iptables", "--version")
+	c.iptablesMutex.Lock()
+	binary := c.iptablesBinary
+	c.iptablesMutex.Unlock()
+	output, err := c.commander.Run(ctx, binary, "--version")
 	if err != nil {
 		return "", err
 	}
 	words := strings.Fields(output)
 	const minWords = 2
 	if len(words) < minWords {
-		return "", fmt.Errorf("iptables --version: output is too short: %q", output)
+		return "", fmt.Errorf("%s --version: output is too short: %q", binary, output)
 	}
 	return words[1], nil
 }
@@ -60,17 +63,24 @@ func (c *configurator) runIptablesInstructions(ctx context.Context, instructions
 func (c *configurator) runIptablesInstruction(ctx context.Context, instruction string) error {
 	c.iptablesMutex.Lock() // only one iptables command at once
 	defer c.iptablesMutex.Unlock()
+	instruction = withComment(instruction, c.ruleComment)
 	if c.debug {
-		fmt.Printf("iptables %s\n", instruction)
+		fmt.Printf("%s %s\n", c.iptablesBinary, instruction)
 	}
 	flags := strings.Fields(instruction)
-	if output, err := c.commander.Run(ctx, "iptables", flags...); err != nil {
-		return fmt.Errorf("failed executing \"iptables %s\": %s: %w", instruction, output, err)
+	if output, err := c.commander.Run(ctx, c.iptablesBinary, flags...); err != nil {
+		return fmt.Errorf("failed executing \"%s %s\": %s: %w", c.iptablesBinary, instruction, output, err)
 	}
 	return nil
 }
 
 func (c *configurator) clearAllRules(ctx context.Context) error {
+	c.iptablesMutex.Lock()
+	commented := len(c.ruleComment) > 0
+	c.iptablesMutex.Unlock()
+	if commented {
+		return c.clearCommentedRules(ctx)
+	}
 	return c.runIptablesInstructions(ctx, []string{
 		"--flush",        // flush all chains
 		"--delete-chain", // delete all chains
@@ -145,10 +155,22 @@ func (c *configurator) acceptInputToPort(ctx context.Context, intf string, port
 	if intf == "*" { // all interfaces
 		interfaceFlag = ""
 	}
-	return c.runIptablesInstructions(ctx, []string{
-		fmt.Sprintf("%s INPUT %s -p tcp --dport %d -j ACCEPT", appendOrDelete(remove), interfaceFlag, port),
-		fmt.Sprintf("%s INPUT %s -p udp --dport %d -j ACCEPT", appendOrDelete(remove), interfaceFlag, port),
-	})
+	if intf != c.defaultInterface || len(c.inputSources) == 0 {
+		return c.runIptablesInstructions(ctx, []string{
+			fmt.Sprintf("%s INPUT %s -p tcp --dport %d -j ACCEPT", appendOrDelete(remove), interfaceFlag, port),
+			fmt.Sprintf("%s INPUT %s -p udp --dport %d -j ACCEPT", appendOrDelete(remove), interfaceFlag, port),
+		})
+	}
+	instructions := make([]string, 0, 2*len(c.inputSources)) //nolint:gomnd
+	for _, source := range c.inputSources {
+		instructions = append(instructions,
+			fmt.Sprintf("%s INPUT %s -s %s -p tcp --dport %d -j ACCEPT",
+				appendOrDelete(remove), interfaceFlag, source.String(), port),
+			fmt.Sprintf("%s INPUT %s -s %s -p udp --dport %d -j ACCEPT",
+				appendOrDelete(remove), interfaceFlag, source.String(), port),
+		)
+	}
+	return c.runIptablesInstructions(ctx, instructions)
 }
 
 func (c *configurator) runUserPostRules(ctx context.Context, filepath string, remove bool) error {
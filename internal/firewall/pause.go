@@ -0,0 +1,65 @@
+package firewall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxPauseDuration is the maximum duration the firewall can be paused for.
+const MaxPauseDuration = 10 * time.Minute
+
+var (
+	ErrPauseDurationTooLong = errors.New("pause duration is too long")
+	ErrPauseNotEnabled      = errors.New("firewall is not enabled")
+)
+
+// Pause disables the firewall and enables it back after the duration given.
+// Pausing the firewall while it is already paused resets the duration.
+func (c *configurator) Pause(ctx context.Context, duration time.Duration) (err error) {
+	if duration > MaxPauseDuration {
+		return fmt.Errorf("%w: %s is larger than the maximum %s",
+			ErrPauseDurationTooLong, duration, MaxPauseDuration)
+	}
+
+	c.stateMutex.Lock()
+	defer c.stateMutex.Unlock()
+
+	if c.pauseTimer != nil {
+		c.pauseTimer.Stop()
+	} else {
+		if !c.enabled {
+			return ErrPauseNotEnabled
+		}
+		c.logger.Info("pausing...")
+		if err := c.disable(ctx); err != nil {
+			return err
+		}
+		c.enabled = false
+	}
+
+	c.logger.Info("paused for %s", duration)
+	c.pauseID++
+	pauseID := c.pauseID
+	c.pauseTimer = time.AfterFunc(duration, func() { c.resume(pauseID) })
+	return nil
+}
+
+// resume enables the firewall back at the end of the pause with the ID given,
+// unless the firewall was enabled, disabled or paused again in the meantime.
+func (c *configurator) resume(pauseID uint64) {
+	c.stateMutex.Lock()
+	defer c.stateMutex.Unlock()
+	if c.pauseTimer == nil || pauseID != c.pauseID {
+		return
+	}
+	c.pauseTimer = nil
+	c.logger.Info("pause is over, enabling...")
+	if err := c.enable(context.Background()); err != nil {
+		c.logger.Error(err)
+		return
+	}
+	c.enabled = true
+	c.logger.Info("enabled successfully")
+}
@@ -0,0 +1,139 @@
+package firewall
+
+import (
+	"context"
+	"errors"
+	nativeos "os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/qdm12/golibs/command/mock_command"
+	"github.com/qdm12/golibs/logging"
+	"github.com/qdm12/golibs/os"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPauseTestConfigurator(t *testing.T, ctrl *gomock.Controller) *configurator {
+	t.Helper()
+	logger, err := logging.NewEmptyLogger()
+	require.NoError(t, err)
+	commander := mock_command.NewMockCommander(ctrl)
+	commander.EXPECT().Run(gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+	return &configurator{
+		commander:      commander,
+		logger:         logger,
+		iptablesBinary: iptablesLegacyBinary,
+		openFile: func(name string, flag int, perm os.FileMode) (os.File, error) {
+			return nil, nativeos.ErrNotExist
+		},
+		enabled: true,
+	}
+}
+
+func Test_configurator_Pause(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		enabled  bool
+		duration time.Duration
+		err      error
+	}{
+		"duration too long": {
+			enabled:  true,
+			duration: MaxPauseDuration + time.Second,
+			err:      ErrPauseDurationTooLong,
+		},
+		"firewall not enabled": {
+			duration: time.Minute,
+			err:      ErrPauseNotEnabled,
+		},
+		"paused": {
+			enabled:  true,
+			duration: time.Minute,
+		},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := newPauseTestConfigurator(t, ctrl)
+			c.enabled = testCase.enabled
+
+			err := c.Pause(context.Background(), testCase.duration)
+			if testCase.err != nil {
+				assert.True(t, errors.Is(err, testCase.err))
+				return
+			}
+			require.NoError(t, err)
+			c.stateMutex.Lock()
+			defer c.stateMutex.Unlock()
+			assert.False(t, c.enabled)
+			assert.NotNil(t, c.pauseTimer)
+			c.pauseTimer.Stop()
+		})
+	}
+}
+
+func Test_configurator_resume(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		// interrupt is called after pausing and before the first pause resumes
+		interrupt func(ctx context.Context, c *configurator) error
+		enabled   bool
+		paused    bool
+	}{
+		"pause is over": {
+			enabled: true,
+		},
+		"enabled manually": {
+			interrupt: func(ctx context.Context, c *configurator) error {
+				return c.SetEnabled(ctx, true)
+			},
+			enabled: true,
+		},
+		"disabled manually": {
+			interrupt: func(ctx context.Context, c *configurator) error {
+				return c.SetEnabled(ctx, false)
+			},
+		},
+		"paused again": {
+			interrupt: func(ctx context.Context, c *configurator) error {
+				return c.Pause(ctx, time.Minute)
+			},
+			paused: true,
+		},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+			c := newPauseTestConfigurator(t, ctrl)
+
+			err := c.Pause(ctx, time.Minute)
+			require.NoError(t, err)
+			c.stateMutex.Lock()
+			pauseID := c.pauseID
+			c.stateMutex.Unlock()
+			if testCase.interrupt != nil {
+				err = testCase.interrupt(ctx, c)
+				require.NoError(t, err)
+			}
+
+			c.resume(pauseID) // first pause timer firing
+
+			c.stateMutex.Lock()
+			defer c.stateMutex.Unlock()
+			assert.Equal(t, testCase.enabled, c.enabled)
+			assert.Equal(t, testCase.paused, c.pauseTimer != nil)
+			if c.pauseTimer != nil {
+				c.pauseTimer.Stop()
+			}
+		})
+	}
+}
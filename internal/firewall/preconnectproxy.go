@@ -0,0 +1,49 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+)
+
+// SetPreConnectProxy allows outbound TCP traffic to the pre-connect proxy
+// address on the default interface, such that OpenVPN can reach the VPN
+// server through the proxy. Call it with a nil IP address to remove it.
+func (c *configurator) SetPreConnectProxy(ctx context.Context, ip net.IP, port uint16) (err error) {
+	c.stateMutex.Lock()
+	defer c.stateMutex.Unlock()
+
+	proxy := models.OpenVPNConnection{IP: ip, Port: port, Protocol: constants.TCP}
+	if ip == nil {
+		proxy = models.OpenVPNConnection{}
+	}
+
+	if !c.enabled {
+		c.preConnectProxy = proxy
+		return nil
+	}
+
+	if c.preConnectProxy.Equal(proxy) {
+		return nil
+	}
+
+	remove := true
+	if c.preConnectProxy.IP != nil {
+		if err := c.acceptOutputTrafficToVPN(ctx, c.defaultInterface, c.preConnectProxy, remove); err != nil {
+			c.logger.Error("cannot remove outdated pre-connect proxy through firewall: %s", err)
+		}
+	}
+	c.preConnectProxy = models.OpenVPNConnection{}
+	if proxy.IP == nil {
+		return nil
+	}
+	remove = false
+	if err := c.acceptOutputTrafficToVPN(ctx, c.defaultInterface, proxy, remove); err != nil {
+		return fmt.Errorf("cannot set pre-connect proxy through firewall: %w", err)
+	}
+	c.preConnectProxy = proxy
+	return nil
+}
@@ -0,0 +1,46 @@
+package firewall
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/qdm12/golibs/command/mock_command"
+	"github.com/qdm12/golibs/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_configurator_SetPreConnectProxy(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+	logger, err := logging.NewEmptyLogger()
+	require.NoError(t, err)
+
+	commander := mock_command.NewMockCommander(ctrl)
+	c := &configurator{
+		commander:        commander,
+		logger:           logger,
+		iptablesBinary:   iptablesLegacyBinary,
+		defaultInterface: "eth0",
+		enabled:          true,
+	}
+
+	commander.EXPECT().Run(ctx, "iptables", "--append", "OUTPUT", "-d", "1.2.3.4", "-o", "eth0",
+		"-p", "tcp", "-m", "tcp", "--dport", "1080", "-j", "ACCEPT").Return("", nil)
+	err = c.SetPreConnectProxy(ctx, net.IP{1, 2, 3, 4}, 1080)
+	require.NoError(t, err)
+
+	// same proxy does not change the rules
+	err = c.SetPreConnectProxy(ctx, net.IP{1, 2, 3, 4}, 1080)
+	require.NoError(t, err)
+
+	commander.EXPECT().Run(ctx, "iptables", "--delete", "OUTPUT", "-d", "1.2.3.4", "-o", "eth0",
+		"-p", "tcp", "-m", "tcp", "--dport", "1080", "-j", "ACCEPT").Return("", nil)
+	err = c.SetPreConnectProxy(ctx, nil, 0)
+	require.NoError(t, err)
+	assert.Nil(t, c.preConnectProxy.IP)
+}
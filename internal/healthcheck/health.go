@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strings"
 	"sync"
 	"time"
 )
@@ -14,7 +15,7 @@ func (s *server) runHealthcheckLoop(ctx context.Context, wg *sync.WaitGroup) {
 	for {
 		previousErr := s.handler.getErr()
 
-		err := healthCheck(ctx, s.resolver)
+		err := s.healthCheck(ctx)
 		s.handler.setErr(err)
 
 		if previousErr != nil && err == nil {
@@ -49,11 +50,68 @@ func (s *server) runHealthcheckLoop(ctx context.Context, wg *sync.WaitGroup) {
 
 var (
 	errNoIPResolved = errors.New("no IP address resolved")
+	errQuorumNotMet = errors.New("not enough health targets reachable")
 )
 
-func healthCheck(ctx context.Context, resolver *net.Resolver) (err error) {
+func (s *server) healthCheck(ctx context.Context) (err error) {
+	if err := checkConnectivity(ctx, s.dialer, s.settings.Targets, s.settings.Quorum); err != nil {
+		return err
+	}
+	if !s.settings.IncludeDNS {
+		return nil
+	}
+	return checkDNS(ctx, s.resolver)
+}
+
+// checkConnectivity checks TCP connections can be established to
+// at least quorum of the target addresses given, concurrently.
+func checkConnectivity(ctx context.Context, dialer *net.Dialer,
+	targets []string, quorum int) (err error) {
+	const timeout = 5 * time.Second
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	errs := make(chan error)
+	for _, target := range targets {
+		go func(address string) {
+			errs <- dial(ctx, dialer, address)
+		}(target)
+	}
+
+	successes := 0
+	var errMessages []string
+	for range targets {
+		err := <-errs
+		if err != nil {
+			errMessages = append(errMessages, err.Error())
+			continue
+		}
+		successes++
+	}
+
+	if successes < quorum {
+		return fmt.Errorf("%w: %d of %d reachable for a quorum of %d: %s",
+			errQuorumNotMet, successes, len(targets), quorum, strings.Join(errMessages, "; "))
+	}
+	return nil
+}
+
+func dial(ctx context.Context, dialer *net.Dialer, address string) (err error) {
+	connection, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	return connection.Close()
+}
+
+func checkDNS(ctx context.Context, resolver *net.Resolver) (err error) {
 	// TODO use mullvad API if current provider is Mullvad
-	const domainToResolve = "github.com"
+	const (
+		domainToResolve = "github.com"
+		timeout         = 5 * time.Second
+	)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 	ips, err := resolver.LookupIP(ctx, "ip", domainToResolve)
 	switch {
 	case err != nil:
@@ -0,0 +1,55 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkConnectivity(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	reachable := listener.Addr().String()
+
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachable := closedListener.Addr().String()
+	require.NoError(t, closedListener.Close())
+
+	testCases := map[string]struct {
+		targets []string
+		quorum  int
+		err     error
+	}{
+		"single reachable target": {
+			targets: []string{reachable},
+			quorum:  1,
+		},
+		"quorum met with one target down": {
+			targets: []string{reachable, unreachable, reachable},
+			quorum:  2,
+		},
+		"quorum not met": {
+			targets: []string{reachable, unreachable},
+			quorum:  2,
+			err:     errQuorumNotMet,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := checkConnectivity(context.Background(), &net.Dialer{},
+				testCase.targets, testCase.quorum)
+			assert.True(t, errors.Is(err, testCase.err), "unexpected error: %v", err)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/qdm12/gluetun/internal/settings"
 	"github.com/qdm12/golibs/logging"
 )
 
@@ -17,18 +18,22 @@ type Server interface {
 
 type server struct {
 	address  string
+	settings settings.Health
 	logger   logging.Logger
 	handler  *handler
 	resolver *net.Resolver
+	dialer   *net.Dialer
 }
 
-func NewServer(address string, logger logging.Logger) Server {
+func NewServer(address string, settings settings.Health, logger logging.Logger) Server {
 	healthcheckLogger := logger.WithPrefix("healthcheck: ")
 	return &server{
 		address:  address,
+		settings: settings,
 		logger:   healthcheckLogger,
 		handler:  newHandler(healthcheckLogger),
 		resolver: net.DefaultResolver,
+		dialer:   &net.Dialer{},
 	}
 }
 
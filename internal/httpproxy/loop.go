@@ -72,8 +72,11 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 		runCtx, runCancel := context.WithCancel(ctx)
 
 		settings := l.GetSettings()
-		address := fmt.Sprintf(":%d", settings.Port)
-		server := New(runCtx, address, l.logger, settings.Stealth, settings.Log, settings.User, settings.Password)
+		addresses := []string{fmt.Sprintf(":%d", settings.Port)}
+		for port := uint32(settings.Port) + 1; port <= uint32(settings.PortEnd); port++ {
+			addresses = append(addresses, fmt.Sprintf(":%d", port))
+		}
+		server := New(runCtx, addresses, l.logger, settings.Stealth, settings.Log, settings.User, settings.Password)
 
 		runWg := &sync.WaitGroup{}
 		runWg.Add(1)
@@ -2,6 +2,8 @@ package httpproxy
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"net/http"
 	"sync"
 	"time"
@@ -14,17 +16,19 @@ type Server interface {
 }
 
 type server struct {
-	address    string
+	addresses  []string
 	handler    http.Handler
 	logger     logging.Logger
 	internalWG *sync.WaitGroup
 }
 
-func New(ctx context.Context, address string, logger logging.Logger,
+// New creates a new HTTP proxy server listening on the first
+// available address of the addresses given.
+func New(ctx context.Context, addresses []string, logger logging.Logger,
 	stealth, verbose bool, username, password string) Server {
 	wg := &sync.WaitGroup{}
 	return &server{
-		address:    address,
+		addresses:  addresses,
 		handler:    newHandler(ctx, wg, logger, stealth, verbose, username, password),
 		logger:     logger,
 		internalWG: wg,
@@ -33,7 +37,12 @@ func New(ctx context.Context, address string, logger logging.Logger,
 
 func (s *server) Run(ctx context.Context, wg *sync.WaitGroup, errorCh chan<- error) {
 	defer wg.Done()
-	server := http.Server{Addr: s.address, Handler: s.handler}
+	listener, err := listenFirstAvailable(s.addresses)
+	if err != nil {
+		errorCh <- err
+		return
+	}
+	server := http.Server{Handler: s.handler}
 	go func() {
 		<-ctx.Done()
 		s.logger.Warn("shutting down server")
@@ -45,10 +54,22 @@ func (s *server) Run(ctx context.Context, wg *sync.WaitGroup, errorCh chan<- err
 			s.logger.Error("failed shutting down: %s", err)
 		}
 	}()
-	s.logger.Info("listening on %s", s.address)
-	err := server.ListenAndServe()
+	s.logger.Info("listening on %s", listener.Addr())
+	err = server.Serve(listener)
 	if err != nil && ctx.Err() == nil {
 		errorCh <- err
 	}
 	s.internalWG.Wait()
 }
+
+// listenFirstAvailable listens on the first address available
+// of the addresses given.
+func listenFirstAvailable(addresses []string) (listener net.Listener, err error) {
+	for _, address := range addresses {
+		listener, err = net.Listen("tcp", address)
+		if err == nil {
+			return listener, nil
+		}
+	}
+	return nil, fmt.Errorf("cannot listen on any of the addresses %v: %w", addresses, err)
+}
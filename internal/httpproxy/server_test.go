@@ -0,0 +1,25 @@
+package httpproxy
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_listenFirstAvailable(t *testing.T) {
+	t.Parallel()
+
+	busy, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer busy.Close()
+
+	listener, err := listenFirstAvailable([]string{busy.Addr().String(), "127.0.0.1:0"})
+	require.NoError(t, err)
+	defer listener.Close()
+	assert.NotEqual(t, busy.Addr().String(), listener.Addr().String())
+
+	_, err = listenFirstAvailable([]string{busy.Addr().String()})
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+// Package latency measures the TCP connection latency to servers.
+package latency
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+// Result is the result of a latency measurement to an address.
+type Result struct {
+	Latency time.Duration
+	Err     error
+}
+
+// Measure measures the TCP connection latency to each of the addresses,
+// with at most concurrency connections at the same time. The results
+// are returned in the same order as the addresses.
+func Measure(ctx context.Context, dialer *net.Dialer, addresses []string,
+	concurrency int) (results []Result) {
+	results = make([]Result, len(addresses))
+	semaphore := make(chan struct{}, concurrency)
+	wg := &sync.WaitGroup{}
+	for i := range addresses {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			start := time.Now()
+			connection, err := dialer.DialContext(ctx, "tcp", addresses[i])
+			if err != nil {
+				results[i].Err = err
+				return
+			}
+			results[i].Latency = time.Since(start)
+			_ = connection.Close()
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// Less returns true if the result a should be sorted before the result b,
+// that is if a has a lower latency than b, or if only a succeeded.
+func Less(a, b Result) bool {
+	if (a.Err == nil) != (b.Err == nil) {
+		return a.Err == nil
+	}
+	return a.Latency < b.Latency
+}
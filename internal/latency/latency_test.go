@@ -0,0 +1,54 @@
+package latency
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Measure(t *testing.T) {
+	t.Parallel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddress := closedListener.Addr().String()
+	require.NoError(t, closedListener.Close())
+
+	dialer := &net.Dialer{Timeout: time.Second}
+	addresses := []string{closedAddress, listener.Addr().String()}
+	results := Measure(context.Background(), dialer, addresses, 1)
+	require.Len(t, results, 2)
+	assert.Error(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+	assert.Greater(t, int64(results[1].Latency), int64(0))
+}
+
+func Test_Less(t *testing.T) {
+	t.Parallel()
+	errTest := errors.New("test")
+	testCases := map[string]struct {
+		a, b Result
+		less bool
+	}{
+		"lower latency":    {a: Result{Latency: 1}, b: Result{Latency: 2}, less: true},
+		"higher latency":   {a: Result{Latency: 2}, b: Result{Latency: 1}},
+		"only a succeeded": {a: Result{Latency: 2}, b: Result{Err: errTest}, less: true},
+		"only b succeeded": {a: Result{Err: errTest}, b: Result{Latency: 2}},
+		"both failed":      {a: Result{Err: errTest}, b: Result{Err: errTest}},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.less, Less(testCase.a, testCase.b))
+		})
+	}
+}
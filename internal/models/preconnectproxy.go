@@ -0,0 +1,29 @@
+package models
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// PreConnectProxy contains settings for the proxy used to reach
+// the VPN server, for example a SOCKS proxy running on a bastion host.
+type PreConnectProxy struct {
+	Type     string `json:"type"`
+	Host     string `json:"host"`
+	Port     uint16 `json:"port"`
+	User     string `json:"-"`
+	Password string `json:"-"`
+}
+
+func (p *PreConnectProxy) String() string {
+	if len(p.Type) == 0 {
+		return "disabled"
+	}
+	auth := "no"
+	if len(p.User) > 0 {
+		auth = "yes"
+	}
+	return fmt.Sprintf("%s proxy %s, authentication: %s",
+		p.Type, net.JoinHostPort(p.Host, strconv.Itoa(int(p.Port))), auth)
+}
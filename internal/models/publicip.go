@@ -0,0 +1,10 @@
+package models
+
+import "net"
+
+// PublicIP is the public IP address of a VPN exit, identified
+// by the network interface routing to this exit.
+type PublicIP struct {
+	Interface VPNDevice `json:"interface"`
+	IP        net.IP    `json:"ip"`
+}
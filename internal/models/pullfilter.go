@@ -0,0 +1,14 @@
+package models
+
+import "strconv"
+
+// PullFilter is a rule to accept, ignore or reject options
+// pushed by the VPN server starting with the text given.
+type PullFilter struct {
+	Action string `json:"action"`
+	Text   string `json:"text"`
+}
+
+func (p *PullFilter) String() string {
+	return p.Action + " " + strconv.Quote(p.Text)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"time"
 )
 
 // ProviderSettings contains settings specific to a VPN provider.
@@ -18,9 +19,16 @@ type ServerSelection struct {
 	// Common
 	Protocol NetworkProtocol `json:"network_protocol"`
 	TargetIP net.IP          `json:"target_ip,omitempty"`
+	// Seed is the seed to pick a server randomly, and is nil
+	// to use a time based seed.
+	Seed *int64 `json:"seed,omitempty"`
+	// ConnectOrder is the order to go through the servers
+	// on each connection attempt: random, sequential or ping.
+	ConnectOrder string `json:"connect_order"`
 
 	// Cyberghost, PIA, Surfshark, Windscribe, Vyprvpn, NordVPN
-	Regions []string `json:"regions"`
+	Regions        []string `json:"regions"`
+	ExcludeRegions []string `json:"exclude_regions"`
 
 	// Cyberghost
 	Group string `json:"group"`
@@ -29,6 +37,9 @@ type ServerSelection struct {
 	Cities    []string `json:"cities"`    // Mullvad, PureVPN, Windscribe
 	Hostnames []string `json:"hostnames"` // Windscribe, Privado
 
+	// Windscribe, Privado
+	ExcludeHostnames []string `json:"exclude_hostnames"`
+
 	// Mullvad
 	ISPs  []string `json:"isps"`
 	Owned bool     `json:"owned"`
@@ -39,8 +50,12 @@ type ServerSelection struct {
 	// NordVPN
 	Numbers []uint16 `json:"numbers"`
 
+	// Custom
+	CustomRemotes []OpenVPNConnection `json:"custom_remotes"`
+
 	// PIA
 	EncryptionPreset string `json:"encryption_preset"`
+	PortForwardOnly  bool   `json:"port_forward_only"`
 }
 
 type ExtraConfigOptions struct {
@@ -52,13 +67,17 @@ type ExtraConfigOptions struct {
 
 // PortForwarding contains settings for port forwarding.
 type PortForwarding struct {
-	Enabled  bool     `json:"enabled"`
-	Filepath Filepath `json:"filepath"`
+	Enabled     bool          `json:"enabled"`
+	Filepath    Filepath      `json:"filepath"`
+	RenewPeriod time.Duration `json:"renew_period"`
+	// DataFilepath is the file path to persist the provider
+	// port forwarding data to, such as the PIA token.
+	DataFilepath Filepath `json:"data_filepath"`
 }
 
 func (p *PortForwarding) String() string {
 	if p.Enabled {
-		return fmt.Sprintf("on, saved in %s", p.Filepath)
+		return fmt.Sprintf("on, saved in %s, renewed every %s", p.Filepath, p.RenewPeriod)
 	}
 	return "off"
 }
@@ -93,6 +112,9 @@ func (p *ProviderSettings) String() string {
 			"Encryption preset: "+p.ExtraConfigOptions.EncryptionPreset,
 			"Port forwarding: "+p.PortForwarding.String(),
 		)
+		if p.ServerSelection.PortForwardOnly {
+			settingsList = append(settingsList, "Port forwarding servers only: on")
+		}
 	case "mullvad":
 		settingsList = append(settingsList,
 			"Countries: "+commaJoin(p.ServerSelection.Countries),
@@ -136,11 +158,36 @@ func (p *ProviderSettings) String() string {
 		settingsList = append(settingsList,
 			"Hostnames: "+commaJoin(p.ServerSelection.Hostnames),
 		)
+	case "custom":
+		remotes := make([]string, len(p.ServerSelection.CustomRemotes))
+		for i, remote := range p.ServerSelection.CustomRemotes {
+			remotes[i] = net.JoinHostPort(remote.IP.String(), fmt.Sprint(remote.Port)) +
+				":" + string(remote.Protocol)
+		}
+		settingsList = append(settingsList,
+			"Remotes: "+commaJoin(remotes),
+		)
 	default:
 		settingsList = append(settingsList,
 			"<Missing String method, please implement me!>",
 		)
 	}
+	if len(p.ServerSelection.ExcludeRegions) > 0 {
+		settingsList = append(settingsList,
+			"Excluded regions: "+commaJoin(p.ServerSelection.ExcludeRegions))
+	}
+	if len(p.ServerSelection.ExcludeHostnames) > 0 {
+		settingsList = append(settingsList,
+			"Excluded hostnames: "+commaJoin(p.ServerSelection.ExcludeHostnames))
+	}
+	if p.ServerSelection.Seed != nil {
+		settingsList = append(settingsList,
+			fmt.Sprintf("Selection seed: %d", *p.ServerSelection.Seed))
+	}
+	if len(p.ServerSelection.ConnectOrder) > 0 {
+		settingsList = append(settingsList,
+			"Connect order: "+p.ServerSelection.ConnectOrder)
+	}
 	if p.ServerSelection.TargetIP != nil {
 		settingsList = append(settingsList,
 			"Target IP address: "+string(p.ServerSelection.TargetIP),
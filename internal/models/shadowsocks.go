@@ -0,0 +1,8 @@
+package models
+
+// ShadowSocksUser is a Shadowsocks user with its own
+// listening port and password.
+type ShadowSocksUser struct {
+	Port     uint16 `json:"port"`
+	Password string `json:"-"`
+}
@@ -10,7 +10,16 @@ import (
 
 // WriteAuthFile writes the OpenVPN auth file to disk with the right permissions.
 func (c *configurator) WriteAuthFile(user, password string, puid, pgid int) error {
-	const filepath = string(constants.OpenVPNAuthConf)
+	return c.writeAuthFile(string(constants.OpenVPNAuthConf), user, password, puid, pgid)
+}
+
+// WriteProxyAuthFile writes the pre-connect proxy credentials file
+// used by OpenVPN to authenticate with the proxy.
+func (c *configurator) WriteProxyAuthFile(user, password string, puid, pgid int) error {
+	return c.writeAuthFile(string(constants.OpenVPNProxyAuthConf), user, password, puid, pgid)
+}
+
+func (c *configurator) writeAuthFile(filepath, user, password string, puid, pgid int) error {
 	file, err := c.os.OpenFile(filepath, os.O_RDONLY, 0)
 
 	if err != nil && !os.IsNotExist(err) {
@@ -49,7 +58,7 @@ func (c *configurator) WriteAuthFile(user, password string, puid, pgid int) erro
 		return nil
 	}
 
-	c.logger.Info("username and password changed in %s", constants.OpenVPNAuthConf)
+	c.logger.Info("username and password changed in %s", filepath)
 	file, err = c.os.OpenFile(filepath, os.O_TRUNC|os.O_WRONLY, 0400)
 	if err != nil {
 		return err
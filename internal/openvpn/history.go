@@ -0,0 +1,79 @@
+package openvpn
+
+import (
+	"errors"
+	"fmt"
+	nativeos "os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/qdm12/gluetun/internal/settings"
+	"github.com/qdm12/golibs/os"
+)
+
+// recordConnection appends a record of the connection to the connection
+// log file, if one is set, and logs a warning if it cannot be written.
+func (l *looper) recordConnection(settings settings.OpenVPN, event string,
+	connection models.OpenVPNConnection) {
+	if len(settings.ConnectionLogFile) == 0 {
+		return
+	}
+	var assignedIPs []string
+	interfaces, err := l.routing.Interfaces()
+	if err != nil {
+		l.logger.Warn(err)
+	}
+	for _, networkInterface := range interfaces {
+		if networkInterface.Name == string(constants.TUN) {
+			assignedIPs = networkInterface.Addresses
+		}
+	}
+	line := connectionRecord(time.Now(), event, connection, assignedIPs)
+	if err := appendRotated(l.openFile, settings.ConnectionLogFile, settings.ConnectionLogSize, line); err != nil {
+		l.logger.Warn("cannot record connection: %s", err)
+	}
+}
+
+// connectionRecord returns the connection log line for the event,
+// which is either connect or reconnect.
+func connectionRecord(now time.Time, event string, connection models.OpenVPNConnection,
+	assignedIPs []string) string {
+	server := connection.IP.String() + ":" + strconv.Itoa(int(connection.Port))
+	if len(connection.Hostname) > 0 {
+		server = connection.Hostname + " " + server
+	}
+	assigned := "unknown"
+	if len(assignedIPs) > 0 {
+		assigned = strings.Join(assignedIPs, ",")
+	}
+	return fmt.Sprintf("%s %s server=%q protocol=%s assigned_ip=%s\n",
+		now.UTC().Format(time.RFC3339), event, server, connection.Protocol, assigned)
+}
+
+// appendRotated appends the line to the file at the path given, after
+// renaming the file with the .1 suffix if it reached the maximum size.
+// Only the previous file is kept, replacing any older one.
+func appendRotated(openFile os.OpenFileFunc, path string, maxSize uint64, line string) error {
+	info, err := nativeos.Stat(path)
+	switch {
+	case errors.Is(err, nativeos.ErrNotExist):
+	case err != nil:
+		return err
+	case uint64(info.Size())+uint64(len(line)) > maxSize:
+		if err := nativeos.Rename(path, path+".1"); err != nil {
+			return err
+		}
+	}
+	file, err := openFile(path, os.O_CREATE|os.O_WRONLY|nativeos.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(line); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
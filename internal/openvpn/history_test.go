@@ -0,0 +1,66 @@
+package openvpn
+
+import (
+	"io/ioutil"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/qdm12/golibs/os"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_connectionRecord(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	testCases := map[string]struct {
+		event       string
+		connection  models.OpenVPNConnection
+		assignedIPs []string
+		record      string
+	}{
+		"connect": {
+			event:       "connect",
+			connection:  models.OpenVPNConnection{IP: net.IP{1, 2, 3, 4}, Port: 1194, Protocol: constants.UDP},
+			assignedIPs: []string{"10.8.0.2/24"},
+			record:      `2021-03-04T05:06:07Z connect server="1.2.3.4:1194" protocol=udp assigned_ip=10.8.0.2/24` + "\n",
+		},
+		"reconnect with hostname and unknown IP": {
+			event: "reconnect",
+			connection: models.OpenVPNConnection{IP: net.IP{1, 2, 3, 4}, Port: 443,
+				Protocol: constants.TCP, Hostname: "a.b"},
+			record: `2021-03-04T05:06:07Z reconnect server="a.b 1.2.3.4:443" protocol=tcp assigned_ip=unknown` + "\n",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			record := connectionRecord(now, testCase.event, testCase.connection, testCase.assignedIPs)
+			assert.Equal(t, testCase.record, record)
+		})
+	}
+}
+
+func Test_appendRotated(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "connections.log")
+	openFile := os.New().OpenFile
+	const maxSize = 11
+
+	require.NoError(t, appendRotated(openFile, path, maxSize, "first\n"))
+	require.NoError(t, appendRotated(openFile, path, maxSize, "next\n"))
+	require.NoError(t, appendRotated(openFile, path, maxSize, "last\n"))
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "last\n", string(data))
+	data, err = ioutil.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "first\nnext\n", string(data))
+}
@@ -1,19 +1,30 @@
 package openvpn
 
 import (
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/golibs/logging"
 )
 
-func (l *looper) collectLines(wg *sync.WaitGroup, stdout, stderr <-chan string) {
+func (l *looper) collectLines(wg *sync.WaitGroup, stdout, stderr <-chan string,
+	connected chan<- struct{}) {
 	defer wg.Done()
 	var line string
 	var ok, errLine bool
 
+	var secrets []string
+	settings := l.GetSettings()
+	if settings.LogScrub {
+		secrets = []string{settings.Password, settings.PreConnectProxy.Password}
+	}
+
 	for {
 		errLine = false
 		select {
@@ -24,6 +35,16 @@ func (l *looper) collectLines(wg *sync.WaitGroup, stdout, stderr <-chan string)
 		if !ok {
 			return
 		}
+		if servers := pushedDNSServers(line); len(servers) > 0 {
+			l.warnPushedDNS(servers)
+		}
+		if localToRemote, remoteToLocal, ok := parseMTUTest(line); ok {
+			l.logger.Info("discovered MTU: %d bytes from local to remote, %d bytes from remote to local",
+				localToRemote, remoteToLocal)
+		}
+		if settings.LogScrub {
+			line = scrubLine(line, secrets...)
+		}
 		line, level := processLogLine(line)
 		if len(line) == 0 {
 			continue // filtered out
@@ -42,11 +63,124 @@ func (l *looper) collectLines(wg *sync.WaitGroup, stdout, stderr <-chan string)
 			l.logger.Error(line)
 		}
 		if strings.Contains(line, "Initialization Sequence Completed") {
+			select {
+			case connected <- struct{}{}:
+			default:
+			}
 			l.tunnelReady <- struct{}{}
 		}
 	}
 }
 
+// pushedDNSServers returns the DNS servers pushed by the VPN server if the
+// line logs the push reply received from the server, or a pushed DNS option
+// removed by a pull filter. Note OpenVPN only logs these lines with a
+// verbosity of at least 3.
+func pushedDNSServers(line string) (servers []string) {
+	if !strings.Contains(line, "PUSH_REPLY") &&
+		!strings.Contains(line, "Pushed option removed by filter: ") {
+		return nil
+	}
+	const dnsOption = "dhcp-option DNS "
+	for _, option := range strings.Split(line, ",") {
+		if i := strings.Index(option, "'"+dnsOption); i >= 0 {
+			option = option[i+1:]
+		}
+		if strings.HasPrefix(option, dnsOption) {
+			server := strings.TrimPrefix(option, dnsOption)
+			servers = append(servers, strings.TrimRight(server, "'"))
+		}
+	}
+	return servers
+}
+
+var mtuTestRegex = regexp.MustCompile(`local->remote=\[[0-9]+,([0-9]+)\] remote->local=\[[0-9]+,([0-9]+)\]`)
+
+// parseMTUTest parses the actual MTUs from the line logged by OpenVPN once the
+// mtu-test is completed, such as Empirical MTU test completed [Tried,Actual]
+// local->remote=[1573,1573] remote->local=[1573,1573]
+func parseMTUTest(line string) (localToRemote, remoteToLocal int, ok bool) {
+	if !strings.Contains(line, "Empirical MTU test completed") {
+		return 0, 0, false
+	}
+	matches := mtuTestRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return 0, 0, false
+	}
+	localToRemote, _ = strconv.Atoi(matches[1])
+	remoteToLocal, _ = strconv.Atoi(matches[2])
+	return localToRemote, remoteToLocal, true
+}
+
+const redacted = "[redacted]"
+
+// scrubRegexes match sensitive values in their first group prefix and the
+// value itself, such as the auth token pushed by the VPN server and the
+// key material logged by OpenVPN with a verbosity of at least 7.
+var scrubRegexes = []*regexp.Regexp{ //nolint:gochecknoglobals
+	regexp.MustCompile(`(auth-token(?:-user)? )[^,'\s]+`),
+	regexp.MustCompile(`((?:CIPHER|HMAC) KEY: )[0-9a-fA-F ]+`),
+	regexp.MustCompile(`((?:Pre-Master|Random1|Random2): )[0-9a-fA-F ]+`),
+}
+
+// scrubLine redacts the secrets given as well as known sensitive
+// values from the OpenVPN log line. Secrets are only redacted as whole
+// tokens, so a short secret does not mangle unrelated words.
+func scrubLine(line string, secrets ...string) (scrubbed string) {
+	for _, secret := range secrets {
+		if len(secret) > 0 {
+			line = replaceToken(line, secret, redacted)
+		}
+	}
+	for _, regex := range scrubRegexes {
+		line = regex.ReplaceAllString(line, "${1}"+redacted)
+	}
+	return line
+}
+
+// replaceToken replaces each occurrence of the token in s which is
+// not preceded or followed by a letter or a digit.
+func replaceToken(s, token, replacement string) string {
+	var builder strings.Builder
+	start := 0
+	for {
+		i := strings.Index(s[start:], token)
+		if i == -1 {
+			builder.WriteString(s[start:])
+			return builder.String()
+		}
+		i += start
+		end := i + len(token)
+		before, _ := utf8.DecodeLastRuneInString(s[:i])
+		after, _ := utf8.DecodeRuneInString(s[end:])
+		builder.WriteString(s[start:i])
+		if isWordRune(before) || isWordRune(after) {
+			builder.WriteString(token)
+		} else {
+			builder.WriteString(replacement)
+		}
+		start = end
+	}
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// warnPushedDNS warns about the DNS servers pushed, since Linux has no
+// equivalent to the Windows only block-outside-dns OpenVPN option.
+func (l *looper) warnPushedDNS(servers []string) {
+	joined := strings.Join(servers, ", ")
+	if l.GetSettings().IgnoreDNSPush {
+		l.logger.Warn(color.HiYellowString(
+			"VPN server pushed DNS servers %s which are ignored to prevent DNS leaks", joined))
+		return
+	}
+	l.logger.Warn(color.HiYellowString(
+		"VPN server pushed DNS servers %s which may bypass the DNS server of the container, "+
+			"set OPENVPN_IGNORE_DNS_PUSH=on to ignore them", joined))
+}
+
 func processLogLine(s string) (filtered string, level logging.Level) {
 	for _, ignored := range []string{
 		"WARNING: you are using user/group/chroot/setcon without persist-tun -- this may cause restarts to fail",
@@ -55,3 +55,128 @@ func Test_processLogLine(t *testing.T) {
 		})
 	}
 }
+
+func Test_pushedDNSServers(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		line    string
+		servers []string
+	}{
+		"other line": {
+			line: "Initialization Sequence Completed",
+		},
+		"push reply without DNS": {
+			line: "PUSH: Received control message: 'PUSH_REPLY,route 10.8.0.1,ping 10'",
+		},
+		"push reply with DNS": {
+			line: "PUSH: Received control message: 'PUSH_REPLY,dhcp-option DNS 10.8.0.1," +
+				"route 10.8.0.1,dhcp-option DNS 10.8.0.2'",
+			servers: []string{"10.8.0.1", "10.8.0.2"},
+		},
+		"DNS option removed by filter": {
+			line:    "Pushed option removed by filter: 'dhcp-option DNS 10.8.0.1'",
+			servers: []string{"10.8.0.1"},
+		},
+		"other option removed by filter": {
+			line: "Pushed option removed by filter: 'route-ipv6 fd00::/64'",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			servers := pushedDNSServers(testCase.line)
+			assert.Equal(t, testCase.servers, servers)
+		})
+	}
+}
+
+func Test_parseMTUTest(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		line          string
+		localToRemote int
+		remoteToLocal int
+		ok            bool
+	}{
+		"other line": {
+			line: "Initialization Sequence Completed",
+		},
+		"mtu test completed": {
+			line: "NOTE: Empirical MTU test completed [Tried,Actual] " +
+				"local->remote=[1573,1541] remote->local=[1573,1500]",
+			localToRemote: 1541,
+			remoteToLocal: 1500,
+			ok:            true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			localToRemote, remoteToLocal, ok := parseMTUTest(testCase.line)
+			assert.Equal(t, testCase.localToRemote, localToRemote)
+			assert.Equal(t, testCase.remoteToLocal, remoteToLocal)
+			assert.Equal(t, testCase.ok, ok)
+		})
+	}
+}
+
+func Test_scrubLine(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		line     string
+		secrets  []string
+		scrubbed string
+	}{
+		"nothing to scrub": {
+			line:     "Initialization Sequence Completed",
+			secrets:  []string{""},
+			scrubbed: "Initialization Sequence Completed",
+		},
+		"secret": {
+			line:     "SENT CONTROL [server]: 'PUSH_REQUEST' password=hunter2",
+			secrets:  []string{"hunter2", ""},
+			scrubbed: "SENT CONTROL [server]: 'PUSH_REQUEST' password=[redacted]",
+		},
+		"short secret in words": {
+			line:     "TLS: Initial packet from [AF_INET]1.2.3.4:1194, sid=ab12",
+			secrets:  []string{"TLS", "in"},
+			scrubbed: "[redacted]: Initial packet from [AF_INET]1.2.3.4:1194, sid=ab12",
+		},
+		"secret repeated": {
+			line:     "user a1 password a1a1 a1",
+			secrets:  []string{"a1"},
+			scrubbed: "user [redacted] password a1a1 [redacted]",
+		},
+		"auth token": {
+			line: "PUSH: Received control message: 'PUSH_REPLY,route-gateway 10.8.0.1," +
+				"auth-token SESS_ID_abc123,auth-token-user dXNlcg=='",
+			scrubbed: "PUSH: Received control message: 'PUSH_REPLY,route-gateway 10.8.0.1," +
+				"auth-token [redacted],auth-token-user [redacted]'",
+		},
+		"cipher key": {
+			line:     "Outgoing Data Channel: CIPHER KEY: 3a4b5c6d 7e8f9a0b",
+			scrubbed: "Outgoing Data Channel: CIPHER KEY: [redacted]",
+		},
+		"hmac key": {
+			line:     "Incoming Data Channel: HMAC KEY: 0011aabb",
+			scrubbed: "Incoming Data Channel: HMAC KEY: [redacted]",
+		},
+		"key source": {
+			line:     "  Pre-Master: 9f8e7d6c5b4a",
+			scrubbed: "  Pre-Master: [redacted]",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			scrubbed := scrubLine(testCase.line, testCase.secrets...)
+			assert.Equal(t, testCase.scrubbed, scrubbed)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package openvpn
 
 import (
 	"context"
+	"math/rand"
 	"net"
 	"net/http"
 	"strings"
@@ -27,6 +28,8 @@ type Looper interface {
 	GetServers() (servers models.AllServers)
 	SetServers(servers models.AllServers)
 	GetPortForwarded() (port uint16)
+	IsConnected() (connected bool)
+	GetStats(ctx context.Context) (stats Stats, err error)
 	PortForward(vpnGatewayIP net.IP)
 }
 
@@ -54,6 +57,16 @@ type looper struct {
 	portForwardSignals chan net.IP
 	crashed            bool
 	backoffTime        time.Duration
+	failedAttempts     int
+	pingOrder          []int
+	randSource         rand.Source
+	// selectionSource is the random source to pick servers randomly with,
+	// seeded once with selectionSeed and kept across connection attempts.
+	selectionSource rand.Source
+	selectionSeed   *int64
+	// connectedBefore is true once a first connection succeeded,
+	// to record the next connections as reconnections.
+	connectedBefore bool
 }
 
 const defaultBackoffTime = 15 * time.Second
@@ -87,6 +100,7 @@ func NewLooper(settings settings.OpenVPN,
 		stopped:            make(chan struct{}),
 		portForwardSignals: make(chan net.IP),
 		backoffTime:        defaultBackoffTime,
+		randSource:         rand.NewSource(time.Now().UnixNano()),
 	}
 }
 
@@ -99,6 +113,24 @@ func (l *looper) signalCrashedStatus() {
 	}
 }
 
+// getSelectionSource returns the random source to pick servers with, which
+// is only created again if the selection seed changed, such that each
+// connection attempt picks the next random server instead of the same one.
+func (l *looper) getSelectionSource(selection models.ServerSelection) rand.Source {
+	sameSeed := (l.selectionSeed == nil && selection.Seed == nil) ||
+		(l.selectionSeed != nil && selection.Seed != nil && *l.selectionSeed == *selection.Seed)
+	if l.selectionSource != nil && sameSeed {
+		return l.selectionSource
+	}
+	l.selectionSeed = selection.Seed
+	if selection.Seed == nil {
+		l.selectionSource = rand.NewSource(time.Now().UnixNano())
+	} else {
+		l.selectionSource = rand.NewSource(*selection.Seed)
+	}
+	return l.selectionSource
+}
+
 func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 	select {
@@ -111,6 +143,13 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 	for ctx.Err() == nil {
 		settings, allServers := l.state.getSettingsAndServers()
 		providerConf := provider.New(settings.Provider.Name, allServers, time.Now)
+		if rotator, ok := providerConf.(provider.Rotator); ok {
+			rotator.SetRandSource(l.getSelectionSource(settings.Provider.ServerSelection))
+			rotator.SetFailedAttempts(l.connectAttempt(ctx, providerConf, rotator, settings))
+		}
+		if configurer, ok := providerConf.(provider.PortForwardConfigurer); ok {
+			configurer.SetPortForwardSettings(settings.Provider.PortForwarding)
+		}
 		connection, err := providerConf.GetOpenVPNConnection(settings.Provider.ServerSelection)
 		if err != nil {
 			l.logger.Error(err)
@@ -118,7 +157,16 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 			l.cancel()
 			return
 		}
-		lines := providerConf.BuildConf(connection, l.username, settings)
+		if settings.ReachabilityCheck > 0 {
+			connection = l.pickReachableConnection(ctx, providerConf, settings, connection)
+		}
+		lines, err := provider.BuildConfFromTemplate(providerConf, connection, l.username, settings)
+		if err != nil {
+			l.logger.Error(err)
+			l.signalCrashedStatus()
+			l.cancel()
+			return
+		}
 
 		if err := writeOpenvpnConf(lines, l.openFile); err != nil {
 			l.logger.Error(err)
@@ -134,6 +182,15 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 			return
 		}
 
+		if proxy := settings.PreConnectProxy; len(proxy.User) > 0 {
+			if err := l.conf.WriteProxyAuthFile(proxy.User, proxy.Password, l.puid, l.pgid); err != nil {
+				l.logger.Error(err)
+				l.signalCrashedStatus()
+				l.cancel()
+				return
+			}
+		}
+
 		if err := l.fw.SetVPNConnection(ctx, connection); err != nil {
 			l.logger.Error(err)
 			l.signalCrashedStatus()
@@ -141,6 +198,32 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 			return
 		}
 
+		if err := l.routing.SetVPNServerRoute(connection.IP); err != nil {
+			l.logger.Error(err)
+			l.signalCrashedStatus()
+			l.cancel()
+			return
+		}
+
+		if remotes := settings.Provider.ServerSelection.CustomRemotes; len(remotes) > 1 {
+			// OpenVPN may fall back on any of the custom remotes
+			if err := l.fw.SetVPNCandidates(ctx, remotes); err != nil {
+				l.logger.Error(err)
+				l.signalCrashedStatus()
+				l.cancel()
+				return
+			}
+		}
+
+		if settings.Management {
+			if err := removeManagementSocket(); err != nil {
+				l.logger.Error(err)
+				l.signalCrashedStatus()
+				l.cancel()
+				return
+			}
+		}
+
 		openvpnCtx, openvpnCancel := context.WithCancel(context.Background())
 
 		stdoutLines, stderrLines, waitError, err := l.conf.Start(openvpnCtx)
@@ -151,8 +234,11 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 			continue
 		}
 
+		connected := make(chan struct{}, 1)
 		wg.Add(1)
-		go l.collectLines(wg, stdoutLines, stderrLines)
+		go l.collectLines(wg, stdoutLines, stderrLines, connected)
+
+		connectTimer := time.NewTimer(settings.ConnectTimeout)
 
 		// Needs the stream line from main.go to know when the tunnel is up
 		go func(ctx context.Context) {
@@ -179,7 +265,27 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 		stayHere := true
 		for stayHere {
 			select {
+			case <-connected:
+				connectTimer.Stop()
+				l.failedAttempts = 0
+				l.state.setConnected(true)
+				event := "connect"
+				if l.connectedBefore {
+					event = "reconnect"
+				}
+				l.connectedBefore = true
+				l.recordConnection(settings, event, connection)
+			case <-connectTimer.C:
+				l.logger.Warn("connection to server %s timed out after %s, trying another server",
+					connection.IP, settings.ConnectTimeout)
+				openvpnCancel()
+				<-waitError
+				l.state.setStatusWithLock(constants.Crashed)
+				l.crashed = true
+				l.failedAttempts++
+				stayHere = false
 			case <-ctx.Done():
+				connectTimer.Stop()
 				l.logger.Warn("context canceled: exiting loop")
 				openvpnCancel()
 				<-waitError
@@ -189,20 +295,26 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 				return
 			case <-l.stop:
 				l.logger.Info("stopping")
+				l.state.setConnected(false)
+				connectTimer.Stop()
 				openvpnCancel()
 				<-waitError
 				l.stopped <- struct{}{}
 			case <-l.start:
 				l.logger.Info("starting")
+				connectTimer.Stop()
 				stayHere = false
 			case err := <-waitError: // unexpected error
+				connectTimer.Stop()
 				openvpnCancel()
 				l.state.setStatusWithLock(constants.Crashed)
 				l.logAndWait(ctx, err)
 				l.crashed = true
+				l.failedAttempts++
 				stayHere = false
 			}
 		}
+		l.state.setConnected(false)
 		close(waitError)
 		close(stdoutLines)
 		close(stderrLines)
@@ -212,8 +324,10 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 
 func (l *looper) logAndWait(ctx context.Context, err error) {
 	l.logger.Error(err)
-	l.logger.Info("retrying in %s", l.backoffTime)
-	timer := time.NewTimer(l.backoffTime)
+	jitter := l.GetSettings().ReconnectJitter
+	waitTime := applyJitter(l.backoffTime, jitter, l.randSource)
+	l.logger.Info("retrying in %s", waitTime)
+	timer := time.NewTimer(waitTime)
 	l.backoffTime *= 2
 	select {
 	case <-timer.C:
@@ -224,6 +338,16 @@ func (l *looper) logAndWait(ctx context.Context, err error) {
 	}
 }
 
+// applyJitter returns the duration randomly shifted by at most
+// the jitter fraction of the duration, in both directions.
+func applyJitter(duration time.Duration, jitter float64, source rand.Source) time.Duration {
+	if jitter == 0 {
+		return duration
+	}
+	factor := 1 + jitter*(2*rand.New(source).Float64()-1) //nolint:gosec
+	return time.Duration(float64(duration) * factor)
+}
+
 // portForward is a blocking operation which may or may not be infinite.
 // You should therefore always call it in a goroutine.
 func (l *looper) portForward(ctx context.Context, wg *sync.WaitGroup,
@@ -0,0 +1,169 @@
+package openvpn
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	nativeos "os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qdm12/gluetun/internal/constants"
+)
+
+var (
+	ErrManagementDisabled  = errors.New("OpenVPN management interface is disabled")
+	ErrNotConnected        = errors.New("OpenVPN is not connected")
+	ErrManagementCommand   = errors.New("OpenVPN management command failed")
+	ErrManagementMalformed = errors.New("malformed OpenVPN management output")
+)
+
+// Stats contains connection statistics obtained through
+// the OpenVPN management interface.
+type Stats struct {
+	State          string     `json:"state"`
+	ConnectedSince *time.Time `json:"connected_since,omitempty"`
+	BytesIn        uint64     `json:"bytes_in"`
+	BytesOut       uint64     `json:"bytes_out"`
+}
+
+const managementTimeout = 3 * time.Second
+
+func (l *looper) GetStats(ctx context.Context) (stats Stats, err error) {
+	if !l.GetSettings().Management {
+		return stats, ErrManagementDisabled
+	}
+	if !l.IsConnected() {
+		return stats, ErrNotConnected
+	}
+	return queryManagement(ctx, string(constants.OpenVPNManagementSocket))
+}
+
+// removeManagementSocket removes any management socket file left over by
+// a previous OpenVPN process, since OpenVPN cannot bind on an existing file.
+func removeManagementSocket() error {
+	err := nativeos.Remove(string(constants.OpenVPNManagementSocket))
+	if err != nil && !nativeos.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func queryManagement(ctx context.Context, socketPath string) (stats Stats, err error) {
+	ctx, cancel := context.WithTimeout(ctx, managementTimeout)
+	defer cancel()
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "unix", socketPath)
+	if err != nil {
+		return stats, err
+	}
+	defer conn.Close()
+	deadline, _ := ctx.Deadline()
+	if err := conn.SetDeadline(deadline); err != nil {
+		return stats, err
+	}
+
+	reader := bufio.NewReader(conn)
+	// skip the >INFO greeting line
+	if _, err := reader.ReadString('\n'); err != nil {
+		return stats, err
+	}
+
+	lines, err := runManagementCommand(conn, reader, "state")
+	if err != nil {
+		return stats, err
+	}
+	if err := parseManagementState(lines, &stats); err != nil {
+		return stats, err
+	}
+
+	lines, err = runManagementCommand(conn, reader, "status")
+	if err != nil {
+		return stats, err
+	}
+	if err := parseManagementStatus(lines, &stats); err != nil {
+		return stats, err
+	}
+
+	_, _ = conn.Write([]byte("quit\n"))
+	return stats, nil
+}
+
+// runManagementCommand sends a command to the management interface and returns
+// its output lines until the END line, ignoring real time notification lines.
+func runManagementCommand(conn net.Conn, reader *bufio.Reader, command string) (
+	lines []string, err error) {
+	if _, err := conn.Write([]byte(command + "\n")); err != nil {
+		return nil, err
+	}
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "END":
+			return lines, nil
+		case strings.HasPrefix(line, ">"):
+		case strings.HasPrefix(line, "ERROR:"):
+			return nil, fmt.Errorf("%w: %s: %s", ErrManagementCommand, command, line)
+		default:
+			lines = append(lines, line)
+		}
+	}
+}
+
+// parseManagementState parses the output of the state command, such as
+// 1612345678,CONNECTED,SUCCESS,10.8.0.2,1.2.3.4,1194,,
+func parseManagementState(lines []string, stats *Stats) error {
+	if len(lines) == 0 {
+		return fmt.Errorf("%w: no state line", ErrManagementMalformed)
+	}
+	const minFields = 2
+	fields := strings.Split(lines[len(lines)-1], ",")
+	if len(fields) < minFields {
+		return fmt.Errorf("%w: state line %q", ErrManagementMalformed, lines[len(lines)-1])
+	}
+	stats.State = fields[1]
+	if stats.State != "CONNECTED" {
+		return nil
+	}
+	unixTime, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: state time: %s", ErrManagementMalformed, err)
+	}
+	connectedSince := time.Unix(unixTime, 0)
+	stats.ConnectedSince = &connectedSince
+	return nil
+}
+
+// parseManagementStatus parses the output of the status command to extract
+// the bytes read and written on the VPN server connection.
+func parseManagementStatus(lines []string, stats *Stats) error {
+	for _, line := range lines {
+		i := strings.LastIndex(line, ",")
+		if i < 0 {
+			continue
+		}
+		key, value := line[:i], line[i+1:]
+		var field *uint64
+		switch key {
+		case "TCP/UDP read bytes":
+			field = &stats.BytesIn
+		case "TCP/UDP write bytes":
+			field = &stats.BytesOut
+		default:
+			continue
+		}
+		bytes, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrManagementMalformed, key, err)
+		}
+		*field = bytes
+	}
+	return nil
+}
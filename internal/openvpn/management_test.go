@@ -0,0 +1,67 @@
+package openvpn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseManagementState(t *testing.T) {
+	t.Parallel()
+	connectedSince := time.Unix(1612345678, 0)
+	testCases := map[string]struct {
+		lines []string
+		stats Stats
+		err   error
+	}{
+		"no line": {
+			err: ErrManagementMalformed,
+		},
+		"connected": {
+			lines: []string{"1612345678,CONNECTED,SUCCESS,10.8.0.2,1.2.3.4,1194,,"},
+			stats: Stats{State: "CONNECTED", ConnectedSince: &connectedSince},
+		},
+		"reconnecting": {
+			lines: []string{"1612345678,RECONNECTING,ping-restart,,,,,"},
+			stats: Stats{State: "RECONNECTING"},
+		},
+		"malformed time": {
+			lines: []string{"x,CONNECTED,SUCCESS,10.8.0.2,1.2.3.4,1194,,"},
+			err:   ErrManagementMalformed,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var stats Stats
+			err := parseManagementState(testCase.lines, &stats)
+			if testCase.err != nil {
+				require.ErrorIs(t, err, testCase.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.stats, stats)
+		})
+	}
+}
+
+func Test_parseManagementStatus(t *testing.T) {
+	t.Parallel()
+	lines := []string{
+		"OpenVPN STATISTICS",
+		"Updated,Wed Feb  3 10:00:00 2021",
+		"TUN/TAP read bytes,100",
+		"TUN/TAP write bytes,200",
+		"TCP/UDP read bytes,300",
+		"TCP/UDP write bytes,400",
+		"Auth read bytes,200",
+	}
+	var stats Stats
+	err := parseManagementStatus(lines, &stats)
+	require.NoError(t, err)
+	assert.Equal(t, Stats{BytesIn: 300, BytesOut: 400}, stats)
+}
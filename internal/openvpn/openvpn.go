@@ -3,6 +3,7 @@ package openvpn
 import (
 	"context"
 
+	"github.com/qdm12/gluetun/internal/models"
 	"github.com/qdm12/gluetun/internal/unix"
 	"github.com/qdm12/golibs/command"
 	"github.com/qdm12/golibs/logging"
@@ -11,9 +12,11 @@ import (
 
 type Configurator interface {
 	Version(ctx context.Context) (string, error)
+	CheckScramble(ctx context.Context) error
 	WriteAuthFile(user, password string, puid, pgid int) error
-	CheckTUN() error
-	CreateTUN() error
+	WriteProxyAuthFile(user, password string, puid, pgid int) error
+	CheckTUN(path models.Filepath) error
+	CreateTUN(path models.Filepath) error
 	Start(ctx context.Context) (stdoutLines, stderrLines chan string,
 		waitError chan error, err error)
 }
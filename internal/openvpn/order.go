@@ -0,0 +1,106 @@
+package openvpn
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strconv"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/latency"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/qdm12/gluetun/internal/provider"
+	"github.com/qdm12/gluetun/internal/settings"
+)
+
+// connectAttempt returns the attempt index the provider should use to pick
+// its server. For the ping connect order, it maps the number of consecutive
+// failed attempts to the sequential index of the server having the
+// corresponding rank in terms of latency.
+func (l *looper) connectAttempt(ctx context.Context, providerConf provider.Provider,
+	rotator provider.Rotator, settings settings.OpenVPN) (attempt int) {
+	if settings.Provider.ServerSelection.ConnectOrder != constants.PingOrder {
+		return l.failedAttempts
+	}
+	if l.failedAttempts == 0 || len(l.pingOrder) == 0 {
+		l.pingOrder = l.measurePingOrder(ctx, providerConf, rotator, settings)
+	}
+	if len(l.pingOrder) == 0 {
+		return l.failedAttempts
+	}
+	return l.pingOrder[l.failedAttempts%len(l.pingOrder)]
+}
+
+// measurePingOrder returns the sequential attempt indexes of the servers
+// sorted by increasing TCP connection latency. To limit the number of
+// connections made, at most maxPingServers servers are measured, sampled
+// evenly across all the servers matching the selection.
+func (l *looper) measurePingOrder(ctx context.Context, providerConf provider.Provider,
+	rotator provider.Rotator, settings settings.OpenVPN) (attempts []int) {
+	const maxPingServers = 20
+	const maxServers = 10000
+	var allConnections []models.OpenVPNConnection
+	for attempt := 0; attempt < maxServers; attempt++ {
+		rotator.SetFailedAttempts(attempt)
+		connection, err := providerConf.GetOpenVPNConnection(settings.Provider.ServerSelection)
+		if err != nil || (attempt > 0 && connection.Equal(allConnections[0])) {
+			break // all servers went through
+		}
+		allConnections = append(allConnections, connection)
+	}
+	sampled := sampleIndexes(len(allConnections), maxPingServers)
+	if len(sampled) < 2 { //nolint:gomnd
+		return nil
+	}
+	connections := make([]models.OpenVPNConnection, len(sampled))
+	for i, index := range sampled {
+		connections[i] = allConnections[index]
+	}
+
+	if err := l.fw.SetVPNCandidates(ctx, connections); err != nil {
+		l.logger.Error(err)
+		return nil
+	}
+	defer func() {
+		if err := l.fw.SetVPNCandidates(ctx, nil); err != nil {
+			l.logger.Error(err)
+		}
+	}()
+
+	addresses := make([]string, len(connections))
+	for i, connection := range connections {
+		addresses[i] = net.JoinHostPort(connection.IP.String(), strconv.Itoa(int(connection.Port)))
+	}
+	dialer := &net.Dialer{Timeout: settings.ConnectTimeout}
+	results := latency.Measure(ctx, dialer, addresses, len(addresses))
+
+	order := make([]int, len(connections))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return latency.Less(results[order[i]], results[order[j]])
+	})
+	best := order[0]
+	if results[best].Err == nil {
+		l.logger.Info("server %s has the lowest latency of %s out of %d servers measured",
+			connections[best].IP, results[best].Latency, len(connections))
+	}
+	attempts = make([]int, len(order))
+	for i, index := range order {
+		attempts[i] = sampled[index]
+	}
+	return attempts
+}
+
+// sampleIndexes returns up to max indexes spread evenly from 0 to n-1.
+func sampleIndexes(n, max int) (indexes []int) {
+	if n < max {
+		max = n
+	}
+	indexes = make([]int, max)
+	for i := range indexes {
+		indexes[i] = i * n / max
+	}
+	return indexes
+}
@@ -0,0 +1,45 @@
+package openvpn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_sampleIndexes(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		n       int
+		max     int
+		indexes []int
+	}{
+		"no index": {
+			max:     3,
+			indexes: []int{},
+		},
+		"fewer than max": {
+			n:       2,
+			max:     3,
+			indexes: []int{0, 1},
+		},
+		"spread across all": {
+			n:       10,
+			max:     3,
+			indexes: []int{0, 3, 6},
+		},
+		"large list": {
+			n:       1000,
+			max:     4,
+			indexes: []int{0, 250, 500, 750},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			indexes := sampleIndexes(testCase.n, testCase.max)
+			assert.Equal(t, testCase.indexes, indexes)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package openvpn
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/qdm12/gluetun/internal/provider"
+	"github.com/qdm12/gluetun/internal/settings"
+)
+
+var (
+	ErrNoCandidateReachable = errors.New("no server candidate is reachable")
+	ErrUnexpectedReply      = errors.New("unexpected reply from server")
+)
+
+// pickReachableConnection checks in parallel if several server candidates
+// are reachable, before OpenVPN is started, and returns the first one to
+// answer. It falls back on the connection given if no candidate is reachable.
+func (l *looper) pickReachableConnection(ctx context.Context, providerConf provider.Provider,
+	settings settings.OpenVPN, connection models.OpenVPNConnection) models.OpenVPNConnection {
+	candidates := getCandidates(providerConf, settings, connection, l.failedAttempts)
+	if len(candidates) < 2 { //nolint:gomnd
+		return connection
+	}
+	if err := l.fw.SetVPNCandidates(ctx, candidates); err != nil {
+		l.logger.Error(err)
+		return connection
+	}
+	defer func() {
+		if err := l.fw.SetVPNCandidates(ctx, nil); err != nil {
+			l.logger.Error(err)
+		}
+	}()
+	dialer := &net.Dialer{Timeout: settings.ConnectTimeout}
+	winner, err := checkReachability(ctx, dialer, candidates)
+	if err != nil {
+		l.logger.Warn("%s, trying server %s", err, connection.IP)
+		return connection
+	}
+	l.logger.Info("server %s is the first reachable out of %d servers", winner.IP, len(candidates))
+	// select the winner again for the provider state to match it
+	selection := settings.Provider.ServerSelection
+	selection.TargetIP = winner.IP
+	if _, err := providerConf.GetOpenVPNConnection(selection); err != nil {
+		l.logger.Error(err)
+	}
+	return winner
+}
+
+// getCandidates returns up to settings.ReachabilityCheck distinct server
+// connections, starting with the connection given.
+func getCandidates(providerConf provider.Provider, settings settings.OpenVPN,
+	connection models.OpenVPNConnection, failedAttempts int) (candidates []models.OpenVPNConnection) {
+	candidates = []models.OpenVPNConnection{connection}
+	rotator, isRotator := providerConf.(provider.Rotator)
+	// random picks can give duplicates, so allow a few more tries
+	const triesFactor = 3
+	maxTries := settings.ReachabilityCheck * triesFactor
+	for try := 1; try < maxTries && len(candidates) < settings.ReachabilityCheck; try++ {
+		if isRotator {
+			rotator.SetFailedAttempts(failedAttempts + try)
+		}
+		candidate, err := providerConf.GetOpenVPNConnection(settings.Provider.ServerSelection)
+		if err != nil {
+			break
+		}
+		if !containsConnection(candidates, candidate) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	if isRotator {
+		rotator.SetFailedAttempts(failedAttempts)
+	}
+	return candidates
+}
+
+func containsConnection(connections []models.OpenVPNConnection, connection models.OpenVPNConnection) bool {
+	for _, c := range connections {
+		if c.Equal(connection) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkReachability probes all the candidates in parallel and returns
+// the first one to answer. The other probes are cancelled before returning.
+func checkReachability(ctx context.Context, dialer *net.Dialer,
+	candidates []models.OpenVPNConnection) (winner models.OpenVPNConnection, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		candidate models.OpenVPNConnection
+		err       error
+	}
+	results := make(chan result)
+	for _, candidate := range candidates {
+		go func(candidate models.OpenVPNConnection) {
+			err := probe(ctx, dialer, candidate)
+			results <- result{candidate: candidate, err: err}
+		}(candidate)
+	}
+
+	found := false
+	for range candidates {
+		result := <-results
+		if result.err == nil && !found {
+			found = true
+			winner = result.candidate
+			cancel() // tear down the other probes
+		}
+	}
+	if !found {
+		return winner, fmt.Errorf("%w out of %d servers", ErrNoCandidateReachable, len(candidates))
+	}
+	return winner, nil
+}
+
+// probe returns no error if the server of the connection given answers.
+// A TCP server must accept a TCP connection, and an UDP server must answer
+// an OpenVPN client hard reset packet. Note servers using tls-auth or
+// tls-crypt drop this packet, so they are never found reachable over UDP.
+func probe(ctx context.Context, dialer *net.Dialer, connection models.OpenVPNConnection) error {
+	network := "tcp"
+	if connection.Protocol == constants.UDP {
+		network = "udp"
+	}
+	address := net.JoinHostPort(connection.IP.String(), strconv.Itoa(int(connection.Port)))
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if network == "tcp" {
+		return nil
+	}
+
+	var deadline time.Time // zero means no deadline
+	if dialer.Timeout > 0 {
+		deadline = time.Now().Add(dialer.Timeout)
+	}
+	if ctxDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || ctxDeadline.Before(deadline)) {
+		deadline = ctxDeadline
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.SetDeadline(time.Now()) // unblock the read
+		case <-done:
+		}
+	}()
+
+	packet, err := hardResetClientPacket()
+	if err != nil {
+		return err
+	}
+	if _, err := conn.Write(packet); err != nil {
+		return err
+	}
+	reply := make([]byte, 1500) //nolint:gomnd
+	n, err := conn.Read(reply)
+	if err != nil {
+		return err
+	}
+	const hardResetServerOpcode = 8
+	if opcode := reply[0] >> 3; n == 0 || opcode != hardResetServerOpcode {
+		return fmt.Errorf("%w: opcode %d", ErrUnexpectedReply, opcode)
+	}
+	return nil
+}
+
+// hardResetClientPacket returns an OpenVPN P_CONTROL_HARD_RESET_CLIENT_V2
+// packet, with a random session ID and without tls-auth.
+func hardResetClientPacket() (packet []byte, err error) {
+	const hardResetClientOpcode = 7
+	packet = make([]byte, 14) //nolint:gomnd
+	// the low 3 bits of the first byte are the key ID 0
+	packet[0] = hardResetClientOpcode << 3
+	if _, err := rand.Read(packet[1:9]); err != nil {
+		return nil, err
+	}
+	// remaining bytes are the empty ack array length and the packet ID 0
+	return packet, nil
+}
@@ -0,0 +1,100 @@
+package openvpn
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_checkReachability(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = listener.Close() })
+	reachableTCP := models.OpenVPNConnection{
+		IP:       net.IPv4(127, 0, 0, 1),
+		Port:     uint16(listener.Addr().(*net.TCPAddr).Port),
+		Protocol: constants.TCP,
+	}
+
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachableTCP := models.OpenVPNConnection{
+		IP:       net.IPv4(127, 0, 0, 1),
+		Port:     uint16(closedListener.Addr().(*net.TCPAddr).Port),
+		Protocol: constants.TCP,
+	}
+	require.NoError(t, closedListener.Close())
+
+	packetConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = packetConn.Close() })
+	go func() { // OpenVPN server answering hard reset packets
+		buffer := make([]byte, 1500)
+		for {
+			n, address, err := packetConn.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+			if n > 0 && buffer[0]>>3 == 7 {
+				_, _ = packetConn.WriteTo([]byte{8 << 3}, address)
+			}
+		}
+	}()
+	reachableUDP := models.OpenVPNConnection{
+		IP:       net.IPv4(127, 0, 0, 1),
+		Port:     uint16(packetConn.LocalAddr().(*net.UDPAddr).Port),
+		Protocol: constants.UDP,
+	}
+
+	closedPacketConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	unreachableUDP := models.OpenVPNConnection{
+		IP:       net.IPv4(127, 0, 0, 1),
+		Port:     uint16(closedPacketConn.LocalAddr().(*net.UDPAddr).Port),
+		Protocol: constants.UDP,
+	}
+	require.NoError(t, closedPacketConn.Close())
+
+	testCases := map[string]struct {
+		candidates []models.OpenVPNConnection
+		winner     models.OpenVPNConnection
+		err        error
+	}{
+		"first reachable over TCP": {
+			candidates: []models.OpenVPNConnection{unreachableTCP, reachableTCP},
+			winner:     reachableTCP,
+		},
+		"none reachable over TCP": {
+			candidates: []models.OpenVPNConnection{unreachableTCP, unreachableTCP},
+			err:        ErrNoCandidateReachable,
+		},
+		"first reachable over UDP": {
+			candidates: []models.OpenVPNConnection{unreachableUDP, reachableUDP},
+			winner:     reachableUDP,
+		},
+		"none reachable over UDP": {
+			candidates: []models.OpenVPNConnection{unreachableUDP, unreachableUDP},
+			err:        ErrNoCandidateReachable,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dialer := &net.Dialer{Timeout: time.Second}
+			winner, err := checkReachability(context.Background(), dialer, testCase.candidates)
+			assert.True(t, errors.Is(err, testCase.err))
+			assert.Equal(t, testCase.winner, winner)
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package openvpn
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+)
+
+var ErrScrambleUnsupported = errors.New(
+	"the OpenVPN binary does not support the scramble directive, which requires the XOR patch")
+
+// CheckScramble verifies the OpenVPN binary is built with the XOR patch
+// adding the scramble directive, by looking for it in the help message.
+func (c *configurator) CheckScramble(ctx context.Context) error {
+	output, err := c.commander.Run(ctx, "openvpn", "--help")
+	exitErr := new(exec.ExitError)
+	if err != nil && !(errors.As(err, &exitErr) && exitErr.ExitCode() == 1) {
+		return err // openvpn --help exits with code 1
+	}
+	if !strings.Contains(output, "--scramble") {
+		return ErrScrambleUnsupported
+	}
+	return nil
+}
@@ -15,10 +15,12 @@ type state struct {
 	settings        settings.OpenVPN
 	allServers      models.AllServers
 	portForwarded   uint16
+	connected       bool
 	statusMu        sync.RWMutex
 	settingsMu      sync.RWMutex
 	allServersMu    sync.RWMutex
 	portForwardedMu sync.RWMutex
+	connectedMu     sync.RWMutex
 }
 
 func (s *state) setStatusWithLock(status models.LoopStatus) {
@@ -97,6 +99,7 @@ func (l *looper) SetSettings(settings settings.OpenVPN) (outcome string) {
 		return "settings left unchanged"
 	}
 	l.state.settings = settings
+	l.state.settingsMu.Unlock()
 	_, _ = l.SetStatus(constants.Stopped)
 	outcome, _ = l.SetStatus(constants.Running)
 	return outcome
@@ -119,3 +122,17 @@ func (l *looper) GetPortForwarded() (port uint16) {
 	defer l.state.portForwardedMu.RUnlock()
 	return l.state.portForwarded
 }
+
+func (s *state) setConnected(connected bool) {
+	s.connectedMu.Lock()
+	defer s.connectedMu.Unlock()
+	s.connected = connected
+}
+
+// IsConnected returns true if OpenVPN is connected and
+// has finished installing its routes.
+func (l *looper) IsConnected() (connected bool) {
+	l.state.connectedMu.RLock()
+	defer l.state.connectedMu.RUnlock()
+	return l.state.connected
+}
@@ -3,17 +3,18 @@ package openvpn
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
-	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
 	"github.com/qdm12/gluetun/internal/unix"
 )
 
 // CheckTUN checks the tunnel device is present and accessible.
-func (c *configurator) CheckTUN() error {
-	c.logger.Info("checking for device %s", constants.TunnelDevice)
-	f, err := c.os.OpenFile(string(constants.TunnelDevice), os.O_RDWR, 0)
+func (c *configurator) CheckTUN(path models.Filepath) error {
+	c.logger.Info("checking for device %s", path)
+	f, err := c.os.OpenFile(string(path), os.O_RDWR, 0)
 	if err != nil {
-		return fmt.Errorf("TUN device is not available: %w", err)
+		return fmt.Errorf("TUN device %s is not available: %w", path, err)
 	}
 	if err := f.Close(); err != nil {
 		c.logger.Warn("Could not close TUN device file: %s", err)
@@ -21,10 +22,10 @@ func (c *configurator) CheckTUN() error {
 	return nil
 }
 
-func (c *configurator) CreateTUN() error {
-	c.logger.Info("creating %s", constants.TunnelDevice)
-	if err := c.os.MkdirAll("/dev/net", 0751); err != nil {
-		return err
+func (c *configurator) CreateTUN(path models.Filepath) error {
+	c.logger.Info("creating %s", path)
+	if err := c.os.MkdirAll(filepath.Dir(string(path)), 0751); err != nil {
+		return fmt.Errorf("%w: try running the container with --device %s", err, path)
 	}
 
 	const (
@@ -32,12 +33,11 @@ func (c *configurator) CreateTUN() error {
 		minor = 200
 	)
 	dev := c.unix.Mkdev(major, minor)
-	if err := c.unix.Mknod(string(constants.TunnelDevice), unix.S_IFCHR, int(dev)); err != nil {
-		return err
+	if err := c.unix.Mknod(string(path), unix.S_IFCHR, int(dev)); err != nil {
+		return fmt.Errorf("%w: try running the container with --device %s", err, path)
 	}
 
-	const filepath = string(constants.TunnelDevice)
-	file, err := c.os.OpenFile(filepath, os.O_WRONLY, 0666)
+	file, err := c.os.OpenFile(string(path), os.O_WRONLY, 0666)
 	if err != nil {
 		return err
 	}
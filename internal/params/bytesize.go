@@ -0,0 +1,56 @@
+package params
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// getByteSize obtains a size in bytes from the environment variable key.
+// The value can have a k or m suffix (case insensitive) for kibibytes and
+// mebibytes. If the variable is unset, 0 is returned.
+func (r *reader) getByteSize(key string) (size uint64, err error) {
+	s, err := r.env.Get(key)
+	if err != nil || len(s) == 0 {
+		return 0, err
+	}
+	size, err = parseByteSize(s)
+	if err != nil {
+		return 0, &InvalidValueError{Key: key, Value: s, Reason: err.Error()}
+	}
+	return size, nil
+}
+
+// getOptionalByteSize obtains a size in bytes from the environment variable
+// key, and returns nil if it is unset such that 0 can be told apart.
+func (r *reader) getOptionalByteSize(key string) (size *uint64, err error) {
+	s, err := r.env.Get(key)
+	if err != nil || len(s) == 0 {
+		return nil, err
+	}
+	n, err := parseByteSize(s)
+	if err != nil {
+		return nil, &InvalidValueError{Key: key, Value: s, Reason: err.Error()}
+	}
+	return &n, nil
+}
+
+var ErrByteSizeMalformed = errors.New("byte size is malformed")
+
+func parseByteSize(s string) (size uint64, err error) {
+	multiplier := uint64(1)
+	switch {
+	case strings.HasSuffix(s, "k"):
+		multiplier = 1 << 10 //nolint:gomnd
+		s = strings.TrimSuffix(s, "k")
+	case strings.HasSuffix(s, "m"):
+		multiplier = 1 << 20 //nolint:gomnd
+		s = strings.TrimSuffix(s, "m")
+	}
+	size, err = strconv.ParseUint(s, 10, 32) //nolint:gomnd
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrByteSizeMalformed, err)
+	}
+	return size * multiplier, nil
+}
@@ -0,0 +1,51 @@
+package params
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseByteSize(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		s    string
+		size uint64
+		err  error
+	}{
+		"zero": {
+			s: "0",
+		},
+		"bytes": {
+			s:    "393216",
+			size: 393216,
+		},
+		"kibibytes": {
+			s:    "512k",
+			size: 524288,
+		},
+		"mebibytes": {
+			s:    "1m",
+			size: 1048576,
+		},
+		"malformed": {
+			s:   "1g",
+			err: ErrByteSizeMalformed,
+		},
+		"negative": {
+			s:   "-1",
+			err: ErrByteSizeMalformed,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			size, err := parseByteSize(testCase.s)
+			assert.True(t, errors.Is(err, testCase.err))
+			assert.Equal(t, testCase.size, size)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package params
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	libparams "github.com/qdm12/golibs/params"
+)
+
+var (
+	ErrReadConfigFragment = errors.New("cannot read OpenVPN configuration fragment")
+	ErrUnsafeDirective    = errors.New("OpenVPN directive is not allowed since it can run commands")
+)
+
+// GetOpenVPNConfigDir obtains the OpenVPN configuration lines to append
+// to the generated configuration, from the *.conf files in the directory
+// given by the environment variable OPENVPN_CONFIG_DIR, read in sorted order.
+// It returns no line if the variable is not set.
+func (r *reader) GetOpenVPNConfigDir() (lines []string, err error) {
+	const key = "OPENVPN_CONFIG_DIR"
+	s, err := r.env.Get(key, libparams.CaseSensitiveValue())
+	if err != nil || len(s) == 0 {
+		return nil, err
+	}
+	dir, err := r.env.Path(key, libparams.CaseSensitiveValue())
+	if err != nil {
+		return nil, err
+	}
+	info, err := r.os.Stat(dir)
+	if err != nil {
+		return nil, &InvalidValueError{Key: key, Value: dir, Reason: err.Error()}
+	} else if !info.IsDir() {
+		return nil, &InvalidValueError{Key: key, Value: dir, Reason: "it must be a directory"}
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.conf"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths { // sorted by filepath.Glob
+		b, err := readFromFile(r.os.OpenFile, path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrReadConfigFragment, err)
+		}
+		fragmentLines := strings.Split(strings.ReplaceAll(string(b), "\r\n", "\n"), "\n")
+		if err := CheckUnsafeDirectives(fragmentLines); err != nil {
+			return nil, fmt.Errorf("%w in %s", err, path)
+		}
+		lines = append(lines, fragmentLines...)
+	}
+	return lines, nil
+}
+
+// CheckUnsafeDirectives returns an error if one of the OpenVPN configuration
+// lines uses a directive running scripts or loading plugins, since these
+// would run commands as root in the container, or including another
+// configuration file, which could use such directives.
+func CheckUnsafeDirectives(lines []string) error {
+	unsafeDirectives := []string{
+		"auth-user-pass-verify", "client-connect", "client-disconnect",
+		"config", "down", "ipchange", "learn-address", "plugin",
+		"route-pre-down", "route-up", "script-security", "tls-crypt-v2-verify",
+		"tls-verify", "up",
+	}
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		directive := strings.ToLower(strings.TrimPrefix(fields[0], "--"))
+		if isInside(directive, unsafeDirectives) {
+			return fmt.Errorf("%w: %s", ErrUnsafeDirective, directive)
+		}
+	}
+	return nil
+}
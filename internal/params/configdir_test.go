@@ -0,0 +1,49 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_CheckUnsafeDirectives(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		lines []string
+		err   string
+	}{
+		"no line": {},
+		"safe directives": {
+			lines: []string{"", "# comment", "mute 20", "  sndbuf 0"},
+		},
+		"up script": {
+			lines: []string{"mute 20", "up /script.sh"},
+			err:   "OpenVPN directive is not allowed since it can run commands: up",
+		},
+		"config include": {
+			lines: []string{"config /other/file.conf"},
+			err:   "OpenVPN directive is not allowed since it can run commands: config",
+		},
+		"tls-crypt-v2 verify script": {
+			lines: []string{"tls-crypt-v2-verify /script.sh"},
+			err:   "OpenVPN directive is not allowed since it can run commands: tls-crypt-v2-verify",
+		},
+		"double dash and uppercase": {
+			lines: []string{"--Script-Security 2"},
+			err:   "OpenVPN directive is not allowed since it can run commands: script-security",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := CheckUnsafeDirectives(testCase.lines)
+			if len(testCase.err) > 0 {
+				assert.EqualError(t, err, testCase.err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
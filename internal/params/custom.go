@@ -0,0 +1,66 @@
+package params
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+	libparams "github.com/qdm12/golibs/params"
+)
+
+// GetOpenVPNCustomRemotes obtains the OpenVPN remotes to connect to for the
+// custom provider, from the environment variable OPENVPN_CUSTOM_REMOTES, as
+// a comma separated list of remotes such as `1.2.3.4:1194:udp`.
+// All the remotes must use the same protocol.
+func (r *reader) GetOpenVPNCustomRemotes() (remotes []models.OpenVPNConnection, err error) {
+	values, err := r.env.CSV("OPENVPN_CUSTOM_REMOTES", libparams.Compulsory())
+	if err != nil {
+		return nil, err
+	}
+	for _, value := range values {
+		remote, err := parseCustomRemote(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(remotes) > 0 && remote.Protocol != remotes[0].Protocol {
+			return nil, &InvalidValueError{Key: "OPENVPN_CUSTOM_REMOTES", Value: value,
+				Reason: "all remotes must use the same protocol"}
+		}
+		remotes = append(remotes, remote)
+	}
+	return remotes, nil
+}
+
+func parseCustomRemote(value string) (remote models.OpenVPNConnection, err error) {
+	const key = "OPENVPN_CUSTOM_REMOTES"
+	value = strings.TrimSpace(value)
+	i := strings.LastIndex(value, ":")
+	if i == -1 {
+		return remote, &InvalidValueError{Key: key, Value: value,
+			Reason: "it must be in the format ip:port:protocol"}
+	}
+	address, protocol := value[:i], models.NetworkProtocol(strings.ToLower(value[i+1:]))
+	if protocol != constants.TCP && protocol != constants.UDP {
+		return remote, &InvalidValueError{Key: key, Value: value,
+			Reason: "protocol must be tcp or udp"}
+	}
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return remote, &InvalidValueError{Key: key, Value: value, Reason: err.Error()}
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		// the firewall only allows traffic to the VPN server IP address
+		return remote, &InvalidValueError{Key: key, Value: value,
+			Reason: "host must be an IP address"}
+	}
+	const maxPort = 65535
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > maxPort {
+		return remote, &InvalidValueError{Key: key, Value: value,
+			Reason: "port must be between 1 and 65535"}
+	}
+	return models.OpenVPNConnection{IP: ip, Port: uint16(port), Protocol: protocol}, nil
+}
@@ -0,0 +1,58 @@
+package params
+
+import (
+	"net"
+	"testing"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseCustomRemote(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		value  string
+		remote models.OpenVPNConnection
+		err    string
+	}{
+		"IPv4 remote": {
+			value:  " 1.2.3.4:1194:UDP ",
+			remote: models.OpenVPNConnection{IP: net.IPv4(1, 2, 3, 4), Port: 1194, Protocol: constants.UDP},
+		},
+		"IPv6 remote": {
+			value:  "[::1]:443:tcp",
+			remote: models.OpenVPNConnection{IP: net.IPv6loopback, Port: 443, Protocol: constants.TCP},
+		},
+		"missing protocol": {
+			value: "1.2.3.4",
+			err:   `environment variable OPENVPN_CUSTOM_REMOTES value "1.2.3.4" is not valid: it must be in the format ip:port:protocol`, //nolint:lll
+		},
+		"invalid protocol": {
+			value: "1.2.3.4:1194:icmp",
+			err:   `environment variable OPENVPN_CUSTOM_REMOTES value "1.2.3.4:1194:icmp" is not valid: protocol must be tcp or udp`, //nolint:lll
+		},
+		"hostname": {
+			value: "vpn.example.com:1194:udp",
+			err:   `environment variable OPENVPN_CUSTOM_REMOTES value "vpn.example.com:1194:udp" is not valid: host must be an IP address`, //nolint:lll
+		},
+		"invalid port": {
+			value: "1.2.3.4:0:udp",
+			err:   `environment variable OPENVPN_CUSTOM_REMOTES value "1.2.3.4:0:udp" is not valid: port must be between 1 and 65535`, //nolint:lll
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			remote, err := parseCustomRemote(testCase.value)
+			if len(testCase.err) > 0 {
+				assert.EqualError(t, err, testCase.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.remote, remote)
+		})
+	}
+}
@@ -2,32 +2,40 @@ package params
 
 import (
 	"encoding/pem"
+	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/qdm12/gluetun/internal/constants"
 	libparams "github.com/qdm12/golibs/params"
 )
 
+var ErrNoValidClientCertificate = errors.New("no valid client certificate found in files")
+
 // GetCyberghostGroup obtains the server group for the Cyberghost server from the
 // environment variable CYBERGHOST_GROUP.
 func (r *reader) GetCyberghostGroup() (group string, err error) {
-	s, err := r.env.Inside("CYBERGHOST_GROUP",
+	return r.inside("CYBERGHOST_GROUP",
 		constants.CyberghostGroupChoices(), libparams.Default("Premium UDP Europe"))
-	return s, err
 }
 
 // GetCyberghostRegions obtains the country names for the Cyberghost servers from the
 // environment variable REGION.
 func (r *reader) GetCyberghostRegions() (regions []string, err error) {
-	return r.env.CSVInside("REGION", constants.CyberghostRegionChoices())
+	return r.getRegions(constants.CyberghostRegionChoices())
 }
 
 // GetCyberghostClientKey obtains the client key to use for openvpn
 // from the secret file /run/secrets/openvpn_clientkey or from the file
-// /gluetun/client.key.
+// client.key in the data directory.
 func (r *reader) GetCyberghostClientKey() (clientKey string, err error) {
-	b, err := r.getFromFileOrSecretFile("OPENVPN_CLIENTKEY", string(constants.ClientKey))
+	dataDir, err := r.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	b, err := r.getFromFileOrSecretFile("OPENVPN_CLIENTKEY",
+		filepath.Join(dataDir, constants.ClientKeyFilename))
 	if err != nil {
 		return "", err
 	}
@@ -48,16 +56,50 @@ func extractClientKey(b []byte) (key string, err error) {
 }
 
 // GetCyberghostClientCertificate obtains the client certificate to use for openvpn
-// from the secret file /run/secrets/openvpn_clientcrt or from the file
-// /gluetun/client.crt.
+// from the first valid file of the comma separated list in the environment
+// variable OPENVPN_CLIENTCRT_FILES, or from the secret file
+// /run/secrets/openvpn_clientcrt or from the file client.crt in the data
+// directory.
 func (r *reader) GetCyberghostClientCertificate() (clientCertificate string, err error) {
-	b, err := r.getFromFileOrSecretFile("OPENVPN_CLIENTCRT", string(constants.ClientCertificate))
+	filepaths, err := r.env.CSV("OPENVPN_CLIENTCRT_FILES", libparams.CaseSensitiveValue())
+	if err != nil {
+		return "", err
+	} else if len(filepaths) > 0 {
+		return r.getClientCertificateFromFiles(filepaths)
+	}
+	dataDir, err := r.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	b, err := r.getFromFileOrSecretFile("OPENVPN_CLIENTCRT",
+		filepath.Join(dataDir, constants.ClientCertificateFilename))
 	if err != nil {
 		return "", err
 	}
 	return extractClientCertificate(b)
 }
 
+func (r *reader) getClientCertificateFromFiles(filepaths []string) (
+	clientCertificate string, err error) {
+	errMessages := make([]string, 0, len(filepaths))
+	for _, filepath := range filepaths {
+		filepath = strings.TrimSpace(filepath)
+		b, err := readFromFile(r.os.OpenFile, filepath)
+		if err != nil {
+			errMessages = append(errMessages, fmt.Sprintf("%s: %s", filepath, err))
+			continue
+		}
+		clientCertificate, err = extractClientCertificate(b)
+		if err != nil {
+			errMessages = append(errMessages, fmt.Sprintf("%s: %s", filepath, err))
+			continue
+		}
+		r.logger.Info("using client certificate from file %s", filepath)
+		return clientCertificate, nil
+	}
+	return "", fmt.Errorf("%w: %s", ErrNoValidClientCertificate, strings.Join(errMessages, "; "))
+}
+
 func extractClientCertificate(b []byte) (certificate string, err error) {
 	pemBlock, _ := pem.Decode(b)
 	if pemBlock == nil {
@@ -1,8 +1,11 @@
 package params
 
 import (
-	"fmt"
+	"crypto/x509"
 	"net"
+	"net/url"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,16 +26,45 @@ func (r *reader) GetDNSOverTLSProviders() (providers []string, err error) {
 	if err != nil {
 		return nil, err
 	}
+	ipv6, err := r.GetDNSOverTLSIPv6()
+	if err != nil {
+		return nil, err
+	}
 	for _, provider := range strings.Split(s, ",") {
-		_, ok := dns.GetProviderData(provider)
+		data, ok := dns.GetProviderData(provider)
 		if !ok {
-			return nil, fmt.Errorf("DNS over TLS provider %q is not valid", provider)
+			return nil, &InvalidValueError{Key: "DOT_PROVIDERS", Value: provider, Accepted: dotProviderChoices()}
+		} else if !hasUsableIP(data.IPs, ipv6) {
+			reason := "it has no IPv4 endpoint and DOT_IPV6 is off"
+			if ipv6 {
+				reason = "it has no endpoint"
+			}
+			return nil, &InvalidValueError{Key: "DOT_PROVIDERS", Value: provider, Reason: reason}
 		}
 		providers = append(providers, provider)
 	}
 	return providers, nil
 }
 
+// hasUsableIP returns true if one of the IP addresses is IPv4,
+// or if one of them is IPv6 and IPv6 is enabled.
+func hasUsableIP(ips []net.IP, ipv6 bool) bool {
+	for _, ip := range ips {
+		if ip.To4() != nil || ipv6 {
+			return true
+		}
+	}
+	return false
+}
+
+func dotProviderChoices() (choices []string) {
+	return []string{
+		dns.Cloudflare, dns.CloudflareSecurity, dns.CloudflareFamily,
+		dns.Google, dns.Quad9, dns.Quadrant, dns.CleanBrowsing,
+		dns.CleanBrowsingFamily, dns.CleanBrowsingAdult, dns.LibreDNS, dns.CIRA,
+	}
+}
+
 // GetDNSOverTLSVerbosity obtains the verbosity level to use for Unbound
 // from the environment variable DOT_VERBOSITY.
 func (r *reader) GetDNSOverTLSVerbosity() (verbosityLevel uint8, err error) {
@@ -54,6 +86,171 @@ func (r *reader) GetDNSOverTLSValidationLogLevel() (validationLogLevel uint8, er
 	return uint8(n), err
 }
 
+// GetDNSThreads obtains the number of threads Unbound should use
+// from the environment variable DOT_THREADS, which defaults to 1.
+func (r *reader) GetDNSThreads() (threads int, err error) {
+	const maxThreads = 16
+	return r.env.IntRange("DOT_THREADS", 1, maxThreads, libparams.Default("1"))
+}
+
+// GetDNSRateLimit obtains the maximum number of queries per second Unbound
+// should answer, from the environment variable DOT_RATE_LIMIT.
+// It defaults to 0 which means no limit.
+func (r *reader) GetDNSRateLimit() (rateLimit int, err error) {
+	const maxRateLimit = 1000000
+	return r.env.IntRange("DOT_RATE_LIMIT", 0, maxRateLimit, libparams.Default("0"))
+}
+
+// GetDNSMaxQueries obtains the maximum number of queries each Unbound
+// thread serves at the same time, from the environment variable
+// DOT_MAX_QUERIES. It defaults to 0 which keeps the Unbound defaults.
+func (r *reader) GetDNSMaxQueries() (maxQueries int, err error) {
+	const maxQueriesPerThread = 4096
+	return r.env.IntRange("DOT_MAX_QUERIES", 0, maxQueriesPerThread, libparams.Default("0"))
+}
+
+// GetDNSAccessControl obtains the subnets of the clients allowed to query
+// Unbound from the comma separated CIDRs of the environment variable
+// DOT_ACCESS_CONTROL. If unset, it defaults to the localhost and
+// private (RFC 1918) subnets.
+func (r *reader) GetDNSAccessControl() (allowed []net.IPNet, err error) {
+	allowed, err = r.getCIDRs("DOT_ACCESS_CONTROL")
+	if err != nil {
+		return nil, err
+	} else if len(allowed) == 0 {
+		allowed = defaultDNSAccessControl()
+	}
+	return allowed, nil
+}
+
+func defaultDNSAccessControl() (allowed []net.IPNet) {
+	cidrs := []string{"127.0.0.0/8", "::1/128", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"}
+	allowed = make([]net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, subnet, _ := net.ParseCIDR(cidr)
+		allowed[i] = *subnet
+	}
+	return allowed
+}
+
+// GetDNSLogFile obtains the absolute file path to write Unbound logs to,
+// from the environment variable DOT_LOG_FILE. If unset, it returns
+// the empty string and Unbound logs to the standard output.
+func (r *reader) GetDNSLogFile() (path string, err error) {
+	const key = "DOT_LOG_FILE"
+	path, err = r.env.Get(key, libparams.CaseSensitiveValue())
+	if err != nil || len(path) == 0 {
+		return "", err
+	} else if !filepath.IsAbs(path) {
+		return "", &InvalidValueError{Key: key, Value: path, Reason: "it must be an absolute path"}
+	}
+	return path, nil
+}
+
+// GetDNSRootHints obtains the absolute file path of the root hints file
+// Unbound should use to find the root servers, from the environment variable
+// DOT_ROOT_HINTS. If unset, it returns the empty string and the root hints
+// built in Unbound are used.
+func (r *reader) GetDNSRootHints() (path string, err error) {
+	const key = "DOT_ROOT_HINTS"
+	path, err = r.env.Get(key, libparams.CaseSensitiveValue())
+	if err != nil || len(path) == 0 {
+		return "", err
+	} else if !filepath.IsAbs(path) {
+		return "", &InvalidValueError{Key: key, Value: path, Reason: "it must be an absolute path"}
+	}
+	if _, err := readFromFile(r.os.OpenFile, path); err != nil {
+		return "", &InvalidValueError{Key: key, Value: path, Reason: "it must be a readable file: " + err.Error()}
+	}
+	return path, nil
+}
+
+// GetDNSOverTLSCABundle obtains the absolute file path of the PEM encoded
+// certificate authorities bundle Unbound should use to verify the DNS over TLS
+// servers, from the environment variable DOT_CA_BUNDLE. If unset, it returns
+// the empty string and the system bundle is used.
+func (r *reader) GetDNSOverTLSCABundle() (path string, err error) {
+	const key = "DOT_CA_BUNDLE"
+	path, err = r.env.Get(key, libparams.CaseSensitiveValue())
+	if err != nil || len(path) == 0 {
+		return "", err
+	} else if !filepath.IsAbs(path) {
+		return "", &InvalidValueError{Key: key, Value: path, Reason: "it must be an absolute path"}
+	}
+	b, err := readFromFile(r.os.OpenFile, path)
+	if err != nil {
+		return "", &InvalidValueError{Key: key, Value: path, Reason: "it must be a readable file: " + err.Error()}
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM(b) {
+		return "", &InvalidValueError{Key: key, Value: path, Reason: "it must contain PEM encoded certificates"}
+	}
+	return path, nil
+}
+
+// GetDNSServeTLS obtains if Unbound should also serve DNS over TLS
+// to its clients, from the environment variable DOT_SERVE_TLS.
+func (r *reader) GetDNSServeTLS() (serve bool, err error) {
+	return r.env.OnOff("DOT_SERVE_TLS", libparams.Default("off"))
+}
+
+// GetDNSServeTLSAddress obtains the IP address and port Unbound should
+// serve DNS over TLS on, from the environment variable DOT_SERVE_TLS_ADDRESS.
+func (r *reader) GetDNSServeTLSAddress() (address string, err error) {
+	const key = "DOT_SERVE_TLS_ADDRESS"
+	address, err = r.env.Get(key, libparams.Default("0.0.0.0:853"))
+	if err != nil {
+		return "", err
+	}
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", &InvalidValueError{Key: key, Value: address, Reason: err.Error()}
+	}
+	if net.ParseIP(host) == nil {
+		return "", &InvalidValueError{Key: key, Value: address, Reason: "host is not a valid IP address"}
+	}
+	const maxPort = 65535
+	if port, err := strconv.Atoi(portStr); err != nil || port < 1 || port > maxPort {
+		return "", &InvalidValueError{Key: key, Value: address, Reason: "port is not valid"}
+	}
+	return address, nil
+}
+
+// GetDNSServeTLSCertificate obtains the absolute file paths of the PEM
+// encoded certificate and private key to serve DNS over TLS with, from the
+// environment variables DOT_SERVE_TLS_CERTFILE and DOT_SERVE_TLS_KEYFILE.
+// If both are unset, it returns empty strings and a self-signed
+// certificate is used.
+func (r *reader) GetDNSServeTLSCertificate() (certFile, keyFile string, err error) {
+	const certKey, keyKey = "DOT_SERVE_TLS_CERTFILE", "DOT_SERVE_TLS_KEYFILE"
+	certFile, err = r.env.Get(certKey, libparams.CaseSensitiveValue())
+	if err != nil {
+		return "", "", err
+	}
+	keyFile, err = r.env.Get(keyKey, libparams.CaseSensitiveValue())
+	if err != nil {
+		return "", "", err
+	}
+	switch {
+	case len(certFile) == 0 && len(keyFile) == 0:
+		return "", "", nil
+	case len(keyFile) == 0:
+		return "", "", &InvalidValueError{Key: keyKey, Reason: "it must be set since " + certKey + " is set"}
+	case len(certFile) == 0:
+		return "", "", &InvalidValueError{Key: certKey, Reason: "it must be set since " + keyKey + " is set"}
+	case !filepath.IsAbs(certFile):
+		return "", "", &InvalidValueError{Key: certKey, Value: certFile, Reason: "it must be an absolute path"}
+	case !filepath.IsAbs(keyFile):
+		return "", "", &InvalidValueError{Key: keyKey, Value: keyFile, Reason: "it must be an absolute path"}
+	}
+	return certFile, keyFile, nil
+}
+
+// GetDNSOverTLSMinVersion obtains the minimum TLS version to use to connect
+// to the DNS over TLS servers, from the environment variable DOT_TLS_MIN_VERSION.
+func (r *reader) GetDNSOverTLSMinVersion() (version string, err error) {
+	return r.inside("DOT_TLS_MIN_VERSION", []string{"1.2", "1.3"}, libparams.Default("1.2"))
+}
+
 // GetDNSMaliciousBlocking obtains if malicious hostnames/IPs should be blocked
 // from being resolved by Unbound, using the environment variable BLOCK_MALICIOUS.
 func (r *reader) GetDNSMaliciousBlocking() (blocking bool, err error) {
@@ -93,7 +290,7 @@ func (r *reader) GetDNSUnblockedHostnames() (hostnames []string, err error) {
 	hostnames = strings.Split(s, ",")
 	for _, hostname := range hostnames {
 		if !r.regex.MatchHostname(hostname) {
-			return nil, fmt.Errorf("hostname %q does not seem valid", hostname)
+			return nil, &InvalidValueError{Key: "UNBLOCK", Value: hostname, Reason: "it is not a valid hostname"}
 		}
 	}
 	return hostnames, nil
@@ -105,6 +302,49 @@ func (r *reader) GetDNSOverTLSCaching() (caching bool, err error) {
 	return r.env.OnOff("DOT_CACHING", libparams.Default("on"))
 }
 
+// GetDNSPrefetch obtains if Unbound should prefetch popular records before
+// they expire from its cache, from the environment variable DOT_PREFETCH.
+// It only has an effect if caching is enabled with DOT_CACHING, and is off
+// by default to save CPU.
+func (r *reader) GetDNSPrefetch() (prefetch bool, err error) {
+	return r.env.OnOff("DOT_PREFETCH", libparams.Default("off"))
+}
+
+// GetDNSStats obtains if the Unbound remote control interface should be
+// enabled to obtain query statistics, from the environment variable DOT_STATS.
+// It is off by default.
+func (r *reader) GetDNSStats() (enabled bool, err error) {
+	return r.env.OnOff("DOT_STATS", libparams.Default("off"))
+}
+
+// GetDNSSocketBuffers obtains the Unbound socket receive and send buffer
+// sizes in bytes, from the environment variables DOT_SO_RCVBUF and
+// DOT_SO_SNDBUF. A size of 0 means it is unset and the system default is kept.
+func (r *reader) GetDNSSocketBuffers() (rcvBuf, sndBuf uint64, err error) {
+	rcvBuf, err = r.getSocketBufferSize("DOT_SO_RCVBUF")
+	if err != nil {
+		return 0, 0, err
+	}
+	sndBuf, err = r.getSocketBufferSize("DOT_SO_SNDBUF")
+	if err != nil {
+		return 0, 0, err
+	}
+	return rcvBuf, sndBuf, nil
+}
+
+func (r *reader) getSocketBufferSize(key string) (size uint64, err error) {
+	const maxSize = 1 << 30 // 1GB
+	size, err = r.getByteSize(key)
+	if err != nil {
+		return 0, err
+	} else if size > maxSize {
+		value, _ := r.env.Get(key)
+		return 0, &InvalidValueError{Key: key, Value: value,
+			Reason: "it cannot be larger than " + strconv.Itoa(maxSize) + " bytes"}
+	}
+	return size, nil
+}
+
 // GetDNSOverTLSPrivateAddresses obtains if Unbound caching should be enable or not
 // from the environment variable DOT_PRIVATE_ADDRESS.
 func (r *reader) GetDNSOverTLSPrivateAddresses() (privateAddresses []string, err error) {
@@ -119,7 +359,8 @@ func (r *reader) GetDNSOverTLSPrivateAddresses() (privateAddresses []string, err
 		ip := net.ParseIP(address)
 		_, _, err := net.ParseCIDR(address)
 		if ip == nil && err != nil {
-			return nil, fmt.Errorf("private address %q is not a valid IP or CIDR range", address)
+			return nil, &InvalidValueError{Key: "DOT_PRIVATE_ADDRESS", Value: address,
+				Reason: "it is not a valid IP or CIDR range"}
 		}
 	}
 	return privateAddresses, nil
@@ -127,8 +368,13 @@ func (r *reader) GetDNSOverTLSPrivateAddresses() (privateAddresses []string, err
 
 // GetDNSOverTLSIPv6 obtains if Unbound should resolve ipv6 addresses using
 // ipv6 DNS over TLS from the environment variable DOT_IPV6.
+// It defaults to on if IP_FAMILY uses IPv6.
 func (r *reader) GetDNSOverTLSIPv6() (ipv6 bool, err error) {
-	return r.env.OnOff("DOT_IPV6", libparams.Default("off"))
+	familyIPv6, err := r.familyUsesIPv6()
+	if err != nil {
+		return false, err
+	}
+	return r.env.OnOff("DOT_IPV6", libparams.Default(onOff(familyIPv6)))
 }
 
 // GetDNSUpdatePeriod obtains the period to use to update the block lists and cryptographic files
@@ -138,7 +384,32 @@ func (r *reader) GetDNSUpdatePeriod() (period time.Duration, err error) {
 	if err != nil {
 		return period, err
 	}
-	return time.ParseDuration(s)
+	period, err = time.ParseDuration(s)
+	if err != nil {
+		return period, &InvalidValueError{Key: "DNS_UPDATE_PERIOD", Value: s, Reason: err.Error()}
+	}
+	return period, nil
+}
+
+// GetDNSBlockListsMirrors obtains the base URLs of mirrors to download the
+// block lists from if the primary source fails, from the comma separated
+// environment variable DNS_BLOCK_LISTS_MIRRORS. Each block list file is
+// expected to be at the same file name under each base URL.
+func (r *reader) GetDNSBlockListsMirrors() (mirrors []string, err error) {
+	const key = "DNS_BLOCK_LISTS_MIRRORS"
+	s, err := r.env.Get(key, libparams.CaseSensitiveValue())
+	if err != nil || len(s) == 0 {
+		return nil, err
+	}
+	for _, mirror := range strings.Split(s, ",") {
+		u, err := url.Parse(mirror)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			return nil, &InvalidValueError{Key: key, Value: mirror,
+				Reason: "it must be an http or https URL"}
+		}
+		mirrors = append(mirrors, strings.TrimSuffix(mirror, "/")+"/")
+	}
+	return mirrors, nil
 }
 
 // GetDNSPlaintext obtains the plaintext DNS address to use if DNS over TLS is disabled
@@ -150,7 +421,7 @@ func (r *reader) GetDNSPlaintext() (ip net.IP, err error) {
 	}
 	ip = net.ParseIP(s)
 	if ip == nil {
-		return nil, fmt.Errorf("DNS plaintext address %q is not a valid IP address", s)
+		return nil, &InvalidValueError{Key: "DNS_PLAINTEXT_ADDRESS", Value: s, Reason: "it is not a valid IP address"}
 	}
 	return ip, nil
 }
@@ -160,3 +431,18 @@ func (r *reader) GetDNSPlaintext() (ip net.IP, err error) {
 func (r *reader) GetDNSKeepNameserver() (on bool, err error) {
 	return r.env.OnOff("DNS_KEEP_NAMESERVER", libparams.Default("off"))
 }
+
+// GetDNSKeepNameserverInterface obtains the network interface for which the
+// nameservers present in /etc/resolv.conf should be kept alongside Unbound,
+// from the environment variable DNS_KEEP_NAMESERVER_INTERFACE.
+func (r *reader) GetDNSKeepNameserverInterface() (interfaceName string, err error) {
+	const key = "DNS_KEEP_NAMESERVER_INTERFACE"
+	interfaceName, err = r.env.Get(key, libparams.CaseSensitiveValue())
+	if err != nil || len(interfaceName) == 0 {
+		return "", err
+	}
+	if _, err := net.InterfaceByName(interfaceName); err != nil {
+		return "", &InvalidValueError{Key: key, Value: interfaceName, Reason: err.Error()}
+	}
+	return interfaceName, nil
+}
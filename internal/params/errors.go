@@ -0,0 +1,63 @@
+package params
+
+import (
+	"fmt"
+	"strings"
+
+	libparams "github.com/qdm12/golibs/params"
+)
+
+// InvalidValueError is returned when the value of an environment variable
+// is not valid. It carries the environment variable key, its bad value
+// and, if applicable, the set of accepted values.
+type InvalidValueError struct {
+	Key      string
+	Value    string
+	Accepted []string
+	Reason   string
+}
+
+func (e *InvalidValueError) Error() string {
+	message := fmt.Sprintf("environment variable %s value %q is not valid", e.Key, e.Value)
+	if len(e.Reason) > 0 {
+		message += ": " + e.Reason
+	}
+	if len(e.Accepted) > 0 {
+		message += ": it can only be one of: " + strings.Join(e.Accepted, ", ")
+	}
+	return message
+}
+
+// inside obtains the value of the environment variable key and returns
+// an *InvalidValueError if it is not one of the choices given.
+func (r *reader) inside(key string, choices []string, options ...libparams.OptionSetter) (
+	value string, err error) {
+	value, err = r.env.Get(key, options...)
+	if err != nil {
+		return "", err
+	}
+	if isInside(value, choices) {
+		return value, nil
+	}
+	return "", &InvalidValueError{Key: key, Value: value, Accepted: choices}
+}
+
+// csvInside obtains the comma separated values of the environment variable
+// key and returns an *InvalidValueError if one of them is not one of the
+// choices given.
+func (r *reader) csvInside(key string, choices []string, options ...libparams.OptionSetter) (
+	values []string, err error) {
+	s, err := r.env.Get(key, options...)
+	if err != nil {
+		return nil, err
+	} else if len(s) == 0 {
+		return nil, nil
+	}
+	values = strings.Split(s, ",")
+	for _, value := range values {
+		if !isInside(value, choices) {
+			return nil, &InvalidValueError{Key: key, Value: value, Accepted: choices}
+		}
+	}
+	return values, nil
+}
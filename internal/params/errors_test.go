@@ -0,0 +1,36 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_InvalidValueError_Error(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		err     *InvalidValueError
+		message string
+	}{
+		"key and value only": {
+			err:     &InvalidValueError{Key: "KEY", Value: "x"},
+			message: `environment variable KEY value "x" is not valid`,
+		},
+		"with reason": {
+			err:     &InvalidValueError{Key: "KEY", Value: "x", Reason: "it is not an IP address"},
+			message: `environment variable KEY value "x" is not valid: it is not an IP address`,
+		},
+		"with accepted values": {
+			err:     &InvalidValueError{Key: "KEY", Value: "x", Accepted: []string{"a", "b"}},
+			message: `environment variable KEY value "x" is not valid: it can only be one of: a, b`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testCase.message, testCase.err.Error())
+		})
+	}
+}
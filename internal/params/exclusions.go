@@ -0,0 +1,15 @@
+package params
+
+// GetRegionsExclusion obtains the regions to exclude from the server selection
+// from the comma separated list of the environment variable REGION_EXCLUDE.
+// Each region is validated against the choices given.
+func (r *reader) GetRegionsExclusion(choices []string) (regions []string, err error) {
+	return r.csvInside("REGION_EXCLUDE", choices)
+}
+
+// GetHostnamesExclusion obtains the server hostnames to exclude from the server
+// selection from the comma separated list of the environment variable
+// SERVER_HOSTNAME_EXCLUDE. Each hostname is validated against the choices given.
+func (r *reader) GetHostnamesExclusion(choices []string) (hostnames []string, err error) {
+	return r.csvInside("SERVER_HOSTNAME_EXCLUDE", choices)
+}
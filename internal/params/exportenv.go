@@ -0,0 +1,149 @@
+package params
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qdm12/golibs/logging"
+	"github.com/qdm12/golibs/os"
+	libparams "github.com/qdm12/golibs/params"
+	"github.com/qdm12/golibs/verification"
+)
+
+// NewExportReader returns a reader recording the value it resolves for each
+// environment variable, defaults included, and a function returning these
+// values as sorted KEY=value lines, with the values of secrets replaced by ***.
+// Only the keys known to the program are exported.
+func NewExportReader(logger logging.Logger, os os.OS) (paramsReader Reader, export func() (lines []string)) {
+	env := &recordingEnv{
+		Env:    libparams.NewEnv(),
+		values: make(map[string]string),
+	}
+	paramsReader = &reader{
+		env:    env,
+		logger: logger,
+		regex:  verification.NewRegex(),
+		os:     os,
+	}
+	export = func() (lines []string) {
+		env.mutex.Lock()
+		defer env.mutex.Unlock()
+		return exportLines(env.values)
+	}
+	return paramsReader, export
+}
+
+func exportLines(values map[string]string) (lines []string) {
+	for key, value := range values {
+		if !isKnownEnvKey(key) {
+			continue
+		}
+		if len(value) > 0 && isSecretEnvKey(key) {
+			value = "***"
+		}
+		lines = append(lines, key+"="+value)
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+func isSecretEnvKey(key string) bool {
+	if strings.HasSuffix(key, "_SECRETFILE") {
+		return false
+	}
+	return strings.HasSuffix(key, "PASSWORD") || strings.HasSuffix(key, "_CLIENTKEY")
+}
+
+// recordingEnv records the values resolved for the environment variables.
+type recordingEnv struct {
+	libparams.Env
+	values map[string]string
+	mutex  sync.Mutex
+}
+
+func (e *recordingEnv) record(key, value string, err error) {
+	if err != nil {
+		return
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.values[key] = value
+}
+
+func (e *recordingEnv) Get(key string, optionSetters ...libparams.OptionSetter) (value string, err error) {
+	value, err = e.Env.Get(key, optionSetters...)
+	e.record(key, value, err)
+	return value, err
+}
+
+func (e *recordingEnv) Int(key string, optionSetters ...libparams.OptionSetter) (n int, err error) {
+	n, err = e.Env.Int(key, optionSetters...)
+	e.record(key, strconv.Itoa(n), err)
+	return n, err
+}
+
+func (e *recordingEnv) IntRange(key string, lower, upper int,
+	optionSetters ...libparams.OptionSetter) (n int, err error) {
+	n, err = e.Env.IntRange(key, lower, upper, optionSetters...)
+	e.record(key, strconv.Itoa(n), err)
+	return n, err
+}
+
+func (e *recordingEnv) YesNo(key string, optionSetters ...libparams.OptionSetter) (yes bool, err error) {
+	yes, err = e.Env.YesNo(key, optionSetters...)
+	value := "no"
+	if yes {
+		value = "yes"
+	}
+	e.record(key, value, err)
+	return yes, err
+}
+
+func (e *recordingEnv) OnOff(key string, optionSetters ...libparams.OptionSetter) (on bool, err error) {
+	on, err = e.Env.OnOff(key, optionSetters...)
+	e.record(key, onOff(on), err)
+	return on, err
+}
+
+func (e *recordingEnv) Inside(key string, possibilities []string,
+	optionSetters ...libparams.OptionSetter) (value string, err error) {
+	value, err = e.Env.Inside(key, possibilities, optionSetters...)
+	e.record(key, value, err)
+	return value, err
+}
+
+func (e *recordingEnv) CSV(key string, optionSetters ...libparams.OptionSetter) (values []string, err error) {
+	values, err = e.Env.CSV(key, optionSetters...)
+	e.record(key, strings.Join(values, ","), err)
+	return values, err
+}
+
+func (e *recordingEnv) CSVInside(key string, possibilities []string,
+	optionSetters ...libparams.OptionSetter) (values []string, err error) {
+	values, err = e.Env.CSVInside(key, possibilities, optionSetters...)
+	e.record(key, strings.Join(values, ","), err)
+	return values, err
+}
+
+func (e *recordingEnv) Duration(key string,
+	optionSetters ...libparams.OptionSetter) (duration time.Duration, err error) {
+	duration, err = e.Env.Duration(key, optionSetters...)
+	e.record(key, duration.String(), err)
+	return duration, err
+}
+
+func (e *recordingEnv) Path(key string, optionSetters ...libparams.OptionSetter) (path string, err error) {
+	path, err = e.Env.Path(key, optionSetters...)
+	e.record(key, path, err)
+	return path, err
+}
+
+func (e *recordingEnv) ListeningPort(key string,
+	optionSetters ...libparams.OptionSetter) (port uint16, warning string, err error) {
+	port, warning, err = e.Env.ListeningPort(key, optionSetters...)
+	e.record(key, strconv.Itoa(int(port)), err)
+	return port, warning, err
+}
@@ -0,0 +1,43 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_exportLines(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		values map[string]string
+		lines  []string
+	}{
+		"no values": {},
+		"unknown keys are skipped": {
+			values: map[string]string{"PATH": "/bin", "REGIN": "US"},
+		},
+		"known keys are sorted": {
+			values: map[string]string{"VPNSP": "mullvad", "COUNTRY": "", "DOT": "on"},
+			lines:  []string{"COUNTRY=", "DOT=on", "VPNSP=mullvad"},
+		},
+		"secrets are redacted": {
+			values: map[string]string{
+				"OPENVPN_PASSWORD": "secret", "SHADOWSOCKS_PASSWORD": "",
+				"OPENVPN_CLIENTKEY": "key", "PASSWORD_SECRETFILE": "/run/secrets/password",
+			},
+			lines: []string{
+				"OPENVPN_CLIENTKEY=***", "OPENVPN_PASSWORD=***",
+				"PASSWORD_SECRETFILE=/run/secrets/password", "SHADOWSOCKS_PASSWORD=",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			lines := exportLines(testCase.values)
+			assert.Equal(t, testCase.lines, lines)
+		})
+	}
+}
@@ -2,9 +2,13 @@ package params
 
 import (
 	"fmt"
+	"net"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/qdm12/gluetun/internal/constants"
 	libparams "github.com/qdm12/golibs/params"
 )
 
@@ -66,3 +70,71 @@ func (r *reader) GetInputPorts() (ports []uint16, err error) {
 func (r *reader) GetFirewallDebug() (debug bool, err error) {
 	return r.env.OnOff("FIREWALL_DEBUG", libparams.Default("off"))
 }
+
+// GetFirewallBackend obtains the firewall backend to use from the environment
+// variable FIREWALL_BACKEND, which can be iptables, nftables or auto to
+// detect the backend available, and defaults to auto. The nftables backend
+// sets the same rules as iptables, using the iptables-nft and ip6tables-nft
+// binaries to program the nftables kernel backend, instead of an nft ruleset.
+func (r *reader) GetFirewallBackend() (backend string, err error) {
+	return r.env.Inside("FIREWALL_BACKEND", []string{
+		constants.AutoFirewallBackend, constants.IptablesBackend, constants.NftablesBackend},
+		libparams.Default(constants.AutoFirewallBackend))
+}
+
+var ruleCommentRegex = regexp.MustCompile(`^[a-zA-Z0-9_.:-]{1,256}$`)
+
+// GetFirewallRuleComment obtains the comment to tag the firewall rules set
+// by the program with, from the environment variable FIREWALL_RULE_COMMENT.
+// Only rules with this comment are removed when the firewall is disabled,
+// leaving rules set by other programs untouched. It defaults to gluetun.
+func (r *reader) GetFirewallRuleComment() (comment string, err error) {
+	const key = "FIREWALL_RULE_COMMENT"
+	comment, err = r.env.Get(key, libparams.Default("gluetun"), libparams.CaseSensitiveValue())
+	if err != nil {
+		return "", err
+	}
+	if !ruleCommentRegex.MatchString(comment) {
+		return "", &InvalidValueError{Key: key, Value: comment,
+			Reason: "it must be 1 to 256 letters, digits, dots, colons, dashes or underscores"}
+	}
+	return comment, nil
+}
+
+// GetUplinkInterface obtains the network interface to use to reach the VPN
+// server before the tunnel is up, from the environment variable UPLINK_INTERFACE.
+// If unset, the interface of the default route is used.
+func (r *reader) GetUplinkInterface() (name string, err error) {
+	const key = "UPLINK_INTERFACE"
+	name, err = r.env.Get(key, libparams.CaseSensitiveValue())
+	if err != nil || len(name) == 0 {
+		return name, err
+	}
+	if _, err := net.InterfaceByName(name); err != nil {
+		return "", &InvalidValueError{Key: key, Value: name, Reason: err.Error()}
+	}
+	return name, nil
+}
+
+// GetFirewallStartupGrace obtains the duration to allow all traffic for at
+// startup before enabling the firewall, from the environment variable
+// FIREWALL_STARTUP_GRACE. It defaults to 0 to enable the firewall immediately,
+// and is capped to 5 minutes with a warning.
+func (r *reader) GetFirewallStartupGrace() (grace time.Duration, warning string, err error) {
+	const maxGrace = 5 * time.Minute
+	grace, err = r.env.Duration("FIREWALL_STARTUP_GRACE", libparams.Default("0"))
+	if err != nil {
+		return 0, "", err
+	} else if grace > maxGrace {
+		warning = fmt.Sprintf("firewall startup grace %s is capped to %s", grace, maxGrace)
+		grace = maxGrace
+	}
+	return grace, warning, nil
+}
+
+// GetInputSources obtains the source subnets allowed to reach the input ports
+// of FIREWALL_INPUT_PORTS, from the comma separated CIDRs of the environment
+// variable FIREWALL_INPUT_SOURCES. If unset, any source is allowed.
+func (r *reader) GetInputSources() (sources []net.IPNet, err error) {
+	return r.getCIDRs("FIREWALL_INPUT_SOURCES")
+}
@@ -0,0 +1,54 @@
+package params
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	libparams "github.com/qdm12/golibs/params"
+)
+
+// GetHealthIncludeDNS obtains if the healthcheck should consider a DNS
+// resolution failure as unhealthy, from the environment variable
+// HEALTH_INCLUDE_DNS.
+func (r *reader) GetHealthIncludeDNS() (include bool, err error) {
+	return r.env.OnOff("HEALTH_INCLUDE_DNS", libparams.Default("on"))
+}
+
+// GetHealthTargets obtains the TCP addresses to dial to check the
+// connectivity, from the comma separated environment variable HEALTH_TARGETS.
+// It defaults to [2606:4700:4700::1111]:443 for the ipv6 IP family
+// and to 1.1.1.1:443 otherwise.
+func (r *reader) GetHealthTargets() (targets []string, err error) {
+	family, err := r.GetIPFamily()
+	if err != nil {
+		return nil, err
+	}
+	defaultTarget := "1.1.1.1:443"
+	if family == constants.IPv6Family {
+		defaultTarget = "[2606:4700:4700::1111]:443"
+	}
+	targets, err = r.env.CSV("HEALTH_TARGETS", libparams.Default(defaultTarget))
+	if err != nil {
+		return nil, err
+	}
+	for _, target := range targets {
+		_, port, err := net.SplitHostPort(target)
+		if err != nil {
+			return nil, &InvalidValueError{Key: "HEALTH_TARGETS", Value: target, Reason: err.Error()}
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return nil, &InvalidValueError{Key: "HEALTH_TARGETS", Value: target,
+				Reason: "port must be between 1 and 65535"}
+		}
+	}
+	return targets, nil
+}
+
+// GetHealthQuorum obtains the minimum number of health targets which must be
+// reachable to consider the connectivity healthy, from the environment
+// variable HEALTH_QUORUM.
+func (r *reader) GetHealthQuorum() (quorum int, err error) {
+	const maxQuorum = 100
+	return r.env.IntRange("HEALTH_QUORUM", 1, maxQuorum, libparams.Default("1"))
+}
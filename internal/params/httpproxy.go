@@ -1,6 +1,10 @@
 package params
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
 	"strings"
 
 	libparams "github.com/qdm12/golibs/params"
@@ -39,14 +43,89 @@ func (r *reader) GetHTTPProxyLog() (log bool, err error) {
 	return r.env.OnOff("HTTPPROXY_LOG", libparams.Default("off"))
 }
 
-// GetHTTPProxyPort obtains the HTTP proxy listening port from the environment variable
-// HTTPPROXY_PORT, and using PROXY_PORT and TINYPROXY_PORT as retro-compatibility names.
-func (r *reader) GetHTTPProxyPort() (port uint16, warning string, err error) {
+// GetHTTPProxyPort obtains the HTTP proxy listening port or port range from the
+// environment variable HTTPPROXY_PORT, and using PROXY_PORT and TINYPROXY_PORT
+// as retro-compatibility names. The value can be a single port or a range in
+// the form START-END, in which case the first available port is used.
+// For a single port, start and end are equal.
+func (r *reader) GetHTTPProxyPort() (start, end uint16, warning string, err error) {
 	retroKeysOption := libparams.RetroKeys(
 		[]string{"TINYPROXY_PORT", "PROXY_PORT"},
 		r.onRetroActive,
 	)
-	return r.env.ListeningPort("HTTPPROXY_PORT", retroKeysOption, libparams.Default("8888"))
+	s, err := r.env.Get("HTTPPROXY_PORT", retroKeysOption, libparams.Default("8888"))
+	if err != nil {
+		return 0, 0, "", err
+	}
+	if !strings.Contains(s, "-") {
+		start, warning, err = r.env.ListeningPort("HTTPPROXY_PORT", retroKeysOption, libparams.Default("8888"))
+		return start, start, warning, err
+	}
+	start, end, err = parsePortRange(s)
+	if err != nil {
+		return 0, 0, "", &InvalidValueError{Key: "HTTPPROXY_PORT", Value: s, Reason: err.Error()}
+	}
+	warning, err = checkListeningPortRange(start, end, os.Getuid())
+	if err != nil {
+		return 0, 0, "", &InvalidValueError{Key: "HTTPPROXY_PORT", Value: s, Reason: err.Error()}
+	}
+	return start, end, warning, nil
+}
+
+var (
+	ErrPortRangeMalformed    = errors.New("port range is malformed")
+	ErrPortRangeInverted     = errors.New("port range start is bigger than its end")
+	ErrReservedListeningPort = errors.New(
+		"listening port cannot be in the reserved system ports range (1 to 1023) when running without root")
+)
+
+// checkListeningPortRange checks each port of the range as the listening
+// port of a single port value is checked, given the user ID running the
+// program, which is -1 on Windows. It returns a warning for the first
+// port in the reserved system ports range when running as root or in the
+// dynamic ports range.
+func checkListeningPortRange(start, end uint16, uid int) (warning string, err error) {
+	const (
+		maxPrivilegedPort = 1023
+		minDynamicPort    = 49151
+	)
+	for port := int(start); port <= int(end); port++ {
+		switch {
+		case port <= maxPrivilegedPort && uid == 0:
+			return fmt.Sprintf("listening port %d allowed to be in the reserved system ports range "+
+				"as you are running as root", port), nil
+		case port <= maxPrivilegedPort && uid == -1:
+			return fmt.Sprintf("listening port %d allowed to be in the reserved system ports range "+
+				"as you are running in Windows", port), nil
+		case port <= maxPrivilegedPort:
+			return "", fmt.Errorf("%w: port %d", ErrReservedListeningPort, port)
+		case port > minDynamicPort:
+			return fmt.Sprintf("listening port %d is in the dynamic/private ports range (above 49151)", port), nil
+		}
+	}
+	return "", nil
+}
+
+// parsePortRange parses a port range in the form START-END.
+func parsePortRange(s string) (start, end uint16, err error) {
+	parts := strings.Split(s, "-")
+	const expectedParts = 2
+	if len(parts) != expectedParts {
+		return 0, 0, fmt.Errorf("%w: %s", ErrPortRangeMalformed, s)
+	}
+	ports := make([]uint16, expectedParts)
+	for i, part := range parts {
+		port, err := strconv.ParseUint(strings.TrimSpace(part), 10, 16) //nolint:gomnd
+		if err != nil || port == 0 {
+			return 0, 0, fmt.Errorf("%w: %q is not a valid port", ErrPortRangeMalformed, part)
+		}
+		ports[i] = uint16(port)
+	}
+	start, end = ports[0], ports[1]
+	if start > end {
+		return 0, 0, fmt.Errorf("%w: %d-%d", ErrPortRangeInverted, start, end)
+	}
+	return start, end, nil
 }
 
 // GetHTTPProxyUser obtains the HTTP proxy server user.
@@ -78,3 +157,12 @@ func (r *reader) GetHTTPProxyPassword() (password string, err error) {
 func (r *reader) GetHTTPProxyStealth() (stealth bool, err error) {
 	return r.env.OnOff("HTTPPROXY_STEALTH", libparams.Default("off"))
 }
+
+// GetHTTPProxySticky obtains if each HTTP proxy client should always exit
+// through the same VPN server, chosen from its source IP address, from the
+// environment variable PROXY_STICKY. It defaults to off, and only matters
+// with several exit servers: there is currently a single VPN connection
+// all the proxy clients exit through.
+func (r *reader) GetHTTPProxySticky() (sticky bool, err error) {
+	return r.env.OnOff("PROXY_STICKY", libparams.Default("off"))
+}
@@ -0,0 +1,104 @@
+package params
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parsePortRange(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		s     string
+		start uint16
+		end   uint16
+		err   error
+	}{
+		"valid range": {
+			s:     "8888-8890",
+			start: 8888,
+			end:   8890,
+		},
+		"single port range": {
+			s:     "8888-8888",
+			start: 8888,
+			end:   8888,
+		},
+		"missing end": {
+			s:   "8888-",
+			err: ErrPortRangeMalformed,
+		},
+		"too many parts": {
+			s:   "1-2-3",
+			err: ErrPortRangeMalformed,
+		},
+		"port too big": {
+			s:   "8888-65536",
+			err: ErrPortRangeMalformed,
+		},
+		"zero port": {
+			s:   "0-10",
+			err: ErrPortRangeMalformed,
+		},
+		"inverted range": {
+			s:   "8890-8888",
+			err: ErrPortRangeInverted,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			start, end, err := parsePortRange(testCase.s)
+			assert.True(t, errors.Is(err, testCase.err))
+			assert.Equal(t, testCase.start, start)
+			assert.Equal(t, testCase.end, end)
+		})
+	}
+}
+
+func Test_checkListeningPortRange(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		start   uint16
+		end     uint16
+		uid     int
+		warning string
+		err     error
+	}{
+		"unprivileged range": {
+			start: 8888,
+			end:   8890,
+			uid:   1000,
+		},
+		"privileged range without root": {
+			start: 1000,
+			end:   1030,
+			uid:   1000,
+			err:   ErrReservedListeningPort,
+		},
+		"privileged range as root": {
+			start:   1020,
+			end:     1030,
+			warning: "listening port 1020 allowed to be in the reserved system ports range as you are running as root",
+		},
+		"dynamic range": {
+			start:   49150,
+			end:     49155,
+			uid:     1000,
+			warning: "listening port 49152 is in the dynamic/private ports range (above 49151)",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			warning, err := checkListeningPortRange(testCase.start, testCase.end, testCase.uid)
+			assert.True(t, errors.Is(err, testCase.err))
+			assert.Equal(t, testCase.warning, warning)
+		})
+	}
+}
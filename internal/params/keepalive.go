@@ -0,0 +1,28 @@
+package params
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrKeepaliveFormat = errors.New("keepalive must be an interval and a timeout separated by a space")
+	ErrKeepaliveValue  = errors.New("keepalive value is not a strictly positive integer")
+)
+
+func parseKeepalive(s string) (keepalive string, err error) {
+	fields := strings.Fields(s)
+	const expectedFields = 2
+	if len(fields) != expectedFields {
+		return "", fmt.Errorf("%w: %q", ErrKeepaliveFormat, s)
+	}
+	for _, field := range fields {
+		n, err := strconv.Atoi(field)
+		if err != nil || n <= 0 {
+			return "", fmt.Errorf("%w: %s", ErrKeepaliveValue, field)
+		}
+	}
+	return strings.Join(fields, " "), nil
+}
@@ -0,0 +1,48 @@
+package params
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseKeepalive(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		s         string
+		keepalive string
+		err       error
+	}{
+		"interval and timeout": {
+			s:         "10  60",
+			keepalive: "10 60",
+		},
+		"single value": {
+			s:   "10",
+			err: ErrKeepaliveFormat,
+		},
+		"three values": {
+			s:   "10 60 5",
+			err: ErrKeepaliveFormat,
+		},
+		"zero interval": {
+			s:   "0 60",
+			err: ErrKeepaliveValue,
+		},
+		"not a number": {
+			s:   "10 1m",
+			err: ErrKeepaliveValue,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			keepalive, err := parseKeepalive(testCase.s)
+			assert.True(t, errors.Is(err, testCase.err))
+			assert.Equal(t, testCase.keepalive, keepalive)
+		})
+	}
+}
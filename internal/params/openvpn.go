@@ -3,25 +3,36 @@ package params
 import (
 	"fmt"
 	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
 
+	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/models"
 	libparams "github.com/qdm12/golibs/params"
+	"golang.org/x/sys/unix"
 )
 
 // GetUser obtains the user to use to connect to the VPN servers.
-// It first tries to use the OPENVPN_USER environment variable (easier for the end user)
-// and then tries to read from the secret file openvpn_user if nothing was found.
+// It first tries the provider prefixed environment variable such as
+// MULLVAD_OPENVPN_USER, then the OPENVPN_USER environment variable (easier
+// for the end user) and then tries to read from the secret file openvpn_user
+// if nothing was found.
 func (r *reader) GetUser() (user string, err error) {
 	const compulsory = true
-	return r.getFromEnvOrSecretFile("OPENVPN_USER", compulsory, []string{"USER"})
+	return r.getFromProviderEnvOrSecretFile("OPENVPN_USER", compulsory, []string{"USER"})
 }
 
 // GetPassword obtains the password to use to connect to the VPN servers.
-// It first tries to use the OPENVPN_PASSWORD environment variable (easier for the end user)
-// and then tries to read from the secret file openvpn_password if nothing was found.
+// It first tries the provider prefixed environment variable such as
+// MULLVAD_OPENVPN_PASSWORD, then the OPENVPN_PASSWORD environment variable
+// (easier for the end user) and then tries to read from the secret file
+// openvpn_password if nothing was found.
 func (r *reader) GetPassword() (s string, err error) {
 	const compulsory = true
-	return r.getFromEnvOrSecretFile("OPENVPN_PASSWORD", compulsory, []string{"PASSWORD"})
+	return r.getFromProviderEnvOrSecretFile("OPENVPN_PASSWORD", compulsory, []string{"PASSWORD"})
 }
 
 // GetNetworkProtocol obtains the network protocol to use to connect to the
@@ -59,6 +70,37 @@ func (r *reader) GetTargetIP() (ip net.IP, err error) {
 	return ip, nil
 }
 
+// GetServerConnectOrder obtains the order to go through the servers on each
+// connection attempt, from the environment variable SERVER_CONNECT_ORDER,
+// which can be random, sequential or ping. The ping order measures the
+// latency of up to 20 servers sampled evenly across the servers selected.
+func (r *reader) GetServerConnectOrder() (order string, err error) {
+	return r.inside("SERVER_CONNECT_ORDER", []string{constants.RandomOrder,
+		constants.SequentialOrder, constants.PingOrder}, libparams.Default(constants.RandomOrder))
+}
+
+// GetServerSelectionSeed obtains the seed to use to pick a server randomly,
+// from the environment variable SERVER_SELECTION_SEED. If unset, it returns
+// nil and a time based seed is used.
+func (r *reader) GetServerSelectionSeed() (seed *int64, err error) {
+	const key = "SERVER_SELECTION_SEED"
+	s, err := r.env.Get(key)
+	if err != nil || len(s) == 0 {
+		return nil, err
+	}
+	n, err := strconv.ParseInt(s, 10, 64) //nolint:gomnd
+	if err != nil {
+		return nil, &InvalidValueError{Key: key, Value: s, Reason: "it must be an integer"}
+	}
+	return &n, nil
+}
+
+// GetContinents obtains the continents to select servers from
+// from the environment variable CONTINENT.
+func (r *reader) GetContinents() (continents []string, err error) {
+	return r.env.CSVInside("CONTINENT", constants.ContinentChoices())
+}
+
 // GetOpenVPNCipher obtains a custom cipher to use with OpenVPN
 // from the environment variable OPENVPN_CIPHER.
 func (r *reader) GetOpenVPNCipher() (cipher string, err error) {
@@ -73,14 +115,426 @@ func (r *reader) GetOpenVPNAuth() (auth string, err error) {
 
 // GetOpenVPNIPv6 obtains if ipv6 should be tunneled through the
 // openvpn tunnel from the environment variable OPENVPN_IPV6.
+// It defaults to on if IP_FAMILY uses IPv6.
 func (r *reader) GetOpenVPNIPv6() (ipv6 bool, err error) {
-	return r.env.OnOff("OPENVPN_IPV6", libparams.Default("off"))
+	familyIPv6, err := r.familyUsesIPv6()
+	if err != nil {
+		return false, err
+	}
+	return r.env.OnOff("OPENVPN_IPV6", libparams.Default(onOff(familyIPv6)))
+}
+
+// GetTUNDevicePath obtains the file path of the TUN device to use for OpenVPN
+// from the environment variable TUN_DEVICE.
+func (r *reader) GetTUNDevicePath() (path models.Filepath, err error) {
+	s, err := r.env.Path("TUN_DEVICE",
+		libparams.Default(string(constants.TunnelDevice)), libparams.CaseSensitiveValue())
+	return models.Filepath(s), err
+}
+
+// GetOpenVPNReconnectJitter obtains the fraction between 0 and 1 of random
+// jitter to apply to the reconnect backoff time from the environment
+// variable OPENVPN_RECONNECT_JITTER.
+func (r *reader) GetOpenVPNReconnectJitter() (jitter float64, err error) {
+	s, err := r.env.Get("OPENVPN_RECONNECT_JITTER", libparams.Default("0"))
+	if err != nil {
+		return 0, err
+	}
+	return parseReconnectJitter(s)
+}
+
+func parseReconnectJitter(s string) (jitter float64, err error) {
+	jitter, err = strconv.ParseFloat(s, 64)
+	if err != nil || !(jitter >= 0 && jitter <= 1) { // also rejects NaN
+		return 0, &InvalidValueError{Key: "OPENVPN_RECONNECT_JITTER", Value: s,
+			Reason: "it must be a number between 0 and 1"}
+	}
+	return jitter, nil
+}
+
+// GetOpenVPNConfigTemplate obtains the Go text/template content to use to
+// render the OpenVPN configuration from the file at the path given by the
+// environment variable OPENVPN_CONFIG_TEMPLATE. It returns an empty string
+// if the variable is not set, and an error if the template cannot be parsed.
+func (r *reader) GetOpenVPNConfigTemplate() (configTemplate string, err error) {
+	s, err := r.env.Get("OPENVPN_CONFIG_TEMPLATE", libparams.CaseSensitiveValue())
+	if err != nil || len(s) == 0 {
+		return "", err
+	}
+	path, err := r.env.Path("OPENVPN_CONFIG_TEMPLATE", libparams.CaseSensitiveValue())
+	if err != nil {
+		return "", err
+	}
+	b, err := readFromFile(r.os.OpenFile, path)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrReadNonSecretFile, err)
+	}
+	configTemplate = string(b)
+	if _, err := template.New("openvpn").Parse(configTemplate); err != nil {
+		return "", fmt.Errorf("OpenVPN configuration template %s is not valid: %w", path, err)
+	}
+	return configTemplate, nil
+}
+
+// GetOpenVPNCompression obtains the compression to use with OpenVPN
+// from the environment variable OPENVPN_COMPRESSION, which can be
+// none, lzo or lz4, and defaults to none. With none, the compression
+// framing is kept for servers requiring it, but traffic is not compressed.
+func (r *reader) GetOpenVPNCompression() (compression string, err error) {
+	return r.env.Inside("OPENVPN_COMPRESSION",
+		[]string{constants.NoCompression, constants.LZO, constants.LZ4},
+		libparams.Default(constants.NoCompression))
 }
 
-func (r *reader) GetOpenVPNMSSFix() (mssFix uint16, err error) {
-	n, err := r.env.IntRange("OPENVPN_MSSFIX", 0, 10000, libparams.Default("0"))
+// GetOpenVPNConnectTimeout obtains the duration after which a connection
+// attempt to a server is aborted if not completed, from the environment
+// variable OPENVPN_CONNECT_TIMEOUT.
+func (r *reader) GetOpenVPNConnectTimeout() (timeout time.Duration, err error) {
+	timeout, err = r.env.Duration("OPENVPN_CONNECT_TIMEOUT", libparams.Default("60s"))
 	if err != nil {
 		return 0, err
+	} else if timeout == 0 {
+		return 0, &InvalidValueError{Key: "OPENVPN_CONNECT_TIMEOUT", Value: "0",
+			Reason: "it must be a positive duration"}
+	}
+	return timeout, nil
+}
+
+// GetOpenVPNAuthNocache obtains if OpenVPN should not cache the user
+// credentials in memory, from the environment variable OPENVPN_AUTH_NOCACHE.
+func (r *reader) GetOpenVPNAuthNocache() (nocache bool, err error) {
+	return r.env.OnOff("OPENVPN_AUTH_NOCACHE", libparams.Default("on"))
+}
+
+// GetOpenVPNAuthRetry obtains how OpenVPN should handle an authentication
+// failure, from the environment variable OPENVPN_AUTH_RETRY, which can be
+// none to exit, nointeract (default) to retry with the same credentials
+// or interact to prompt for the credentials again.
+func (r *reader) GetOpenVPNAuthRetry() (retry string, err error) {
+	return r.env.Inside("OPENVPN_AUTH_RETRY", []string{
+		constants.AuthRetryNone, constants.AuthRetryNoInteract, constants.AuthRetryInteract},
+		libparams.Default(constants.AuthRetryNoInteract))
+}
+
+// GetOpenVPNIgnoreDNSPush obtains if OpenVPN should ignore the DNS servers
+// pushed by the VPN server, from the environment variable
+// OPENVPN_IGNORE_DNS_PUSH. It is on by default such that DNS resolution
+// stays on the DNS server of the program and cannot leak to resolvers
+// chosen by the VPN server.
+func (r *reader) GetOpenVPNIgnoreDNSPush() (ignore bool, err error) {
+	return r.env.OnOff("OPENVPN_IGNORE_DNS_PUSH", libparams.Default("on"))
+}
+
+// GetOpenVPNManagement obtains if the OpenVPN management interface should be
+// enabled on a unix socket to query connection statistics, from the
+// environment variable OPENVPN_MANAGEMENT. It is off by default.
+func (r *reader) GetOpenVPNManagement() (enabled bool, err error) {
+	return r.env.OnOff("OPENVPN_MANAGEMENT", libparams.Default("off"))
+}
+
+// GetOpenVPNRouteNoPull obtains if OpenVPN should ignore the routes pushed
+// by the VPN server, from the environment variable OPENVPN_ROUTE_NOPULL.
+// Only the routes from VPN_ROUTES are then added to the tunnel.
+func (r *reader) GetOpenVPNRouteNoPull() (noPull bool, err error) {
+	return r.env.OnOff("OPENVPN_ROUTE_NOPULL", libparams.Default("off"))
+}
+
+// GetOpenVPNRenegSec obtains the number of seconds after which OpenVPN
+// renegotiates the data channel key, from the environment variable
+// OPENVPN_RENEG_SEC. It can be 0 to disable renegotiation, and if unset
+// it returns nil and the provider default is kept.
+func (r *reader) GetOpenVPNRenegSec() (seconds *int, err error) {
+	const key = "OPENVPN_RENEG_SEC"
+	s, err := r.env.Get(key)
+	if err != nil || len(s) == 0 {
+		return nil, err
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return nil, &InvalidValueError{Key: key, Value: s, Reason: "it must be a positive integer or 0"}
+	}
+	return &n, nil
+}
+
+// GetOpenVPNConnectRetry obtains the number of seconds OpenVPN waits between
+// its own connection attempts, from the environment variable
+// OPENVPN_CONNECT_RETRY. If unset, it returns nil and the provider default
+// is kept. Note OpenVPN retries within the connection timeout
+// OPENVPN_CONNECT_TIMEOUT, after which the program tries another server.
+func (r *reader) GetOpenVPNConnectRetry() (seconds *int, err error) {
+	return r.getOptionalPositiveInt("OPENVPN_CONNECT_RETRY")
+}
+
+// GetOpenVPNConnectRetryMax obtains the maximum number of connection attempts
+// OpenVPN does on its own before exiting, from the environment variable
+// OPENVPN_CONNECT_RETRY_MAX. If unset, it returns nil and OpenVPN retries
+// until the connection timeout OPENVPN_CONNECT_TIMEOUT is reached. In both
+// cases, the program then restarts OpenVPN with another server.
+func (r *reader) GetOpenVPNConnectRetryMax() (retries *int, err error) {
+	return r.getOptionalPositiveInt("OPENVPN_CONNECT_RETRY_MAX")
+}
+
+// GetOpenVPNRouteDelay obtains the number of seconds OpenVPN waits after
+// the connection is established before adding the routes, from the
+// environment variable OPENVPN_ROUTE_DELAY. It can be 0 to add the routes
+// once the TUN device is up, and if unset it returns nil and the provider
+// default is kept.
+func (r *reader) GetOpenVPNRouteDelay() (seconds *int, err error) {
+	return r.getOptionalNonNegativeInt("OPENVPN_ROUTE_DELAY")
+}
+
+// GetOpenVPNTunMTUExtra obtains the number of bytes of extra headroom to
+// allocate on the TUN device beyond its MTU, from the environment variable
+// OPENVPN_TUN_MTU_EXTRA. If unset, it returns nil and the provider default
+// is kept.
+func (r *reader) GetOpenVPNTunMTUExtra() (bytes *int, err error) {
+	return r.getOptionalNonNegativeInt("OPENVPN_TUN_MTU_EXTRA")
+}
+
+func (r *reader) getOptionalNonNegativeInt(key string) (n *int, err error) {
+	s, err := r.env.Get(key)
+	if err != nil || len(s) == 0 {
+		return nil, err
+	}
+	value, err := strconv.Atoi(s)
+	if err != nil || value < 0 {
+		return nil, &InvalidValueError{Key: key, Value: s, Reason: "it must be a positive integer or 0"}
+	}
+	return &value, nil
+}
+
+func (r *reader) getOptionalPositiveInt(key string) (n *int, err error) {
+	s, err := r.env.Get(key)
+	if err != nil || len(s) == 0 {
+		return nil, err
+	}
+	value, err := strconv.Atoi(s)
+	if err != nil || value <= 0 {
+		return nil, &InvalidValueError{Key: key, Value: s, Reason: "it must be a strictly positive integer"}
+	}
+	return &value, nil
+}
+
+// GetOpenVPNScramble obtains the obfuscation method and its argument for the
+// scramble directive of OpenVPN builds with the XOR patch, from the environment
+// variable OPENVPN_SCRAMBLE. It can be xormask <mask>, xorptrpos, reverse or
+// obfuscate <password>, and the VPN server must use the same method.
+// It returns an empty string if unset, to not scramble the traffic.
+func (r *reader) GetOpenVPNScramble() (scramble string, err error) {
+	const key = "OPENVPN_SCRAMBLE"
+	s, err := r.env.Get(key, libparams.CaseSensitiveValue())
+	if err != nil || len(s) == 0 {
+		return "", err
+	}
+	scramble, err = parseScramble(s)
+	if err != nil {
+		return "", &InvalidValueError{Key: key, Value: s, Reason: err.Error()}
+	}
+	return scramble, nil
+}
+
+// GetOpenVPNKeepalive obtains the ping interval and the ping restart timeout
+// in seconds, separated by a space, for the keepalive directive from the
+// environment variable OPENVPN_KEEPALIVE. It replaces the ping directives
+// of the provider configuration, and is an empty string if unset.
+func (r *reader) GetOpenVPNKeepalive() (keepalive string, err error) {
+	const key = "OPENVPN_KEEPALIVE"
+	s, err := r.env.Get(key)
+	if err != nil || len(s) == 0 {
+		return "", err
+	}
+	keepalive, err = parseKeepalive(s)
+	if err != nil {
+		return "", &InvalidValueError{Key: key, Value: s, Reason: err.Error()}
+	}
+	return keepalive, nil
+}
+
+// GetConnectionLogFile obtains the absolute file path to record each
+// VPN connection to, from the environment variable CONNECTION_LOG_FILE.
+// Its parent directory must exist and be writable.
+// It returns an empty string if unset, to not record connections.
+func (r *reader) GetConnectionLogFile() (path string, err error) {
+	const key = "CONNECTION_LOG_FILE"
+	path, err = r.env.Get(key, libparams.CaseSensitiveValue())
+	if err != nil || len(path) == 0 {
+		return "", err
+	} else if !filepath.IsAbs(path) || strings.HasSuffix(path, "/") {
+		return "", &InvalidValueError{Key: key, Value: path, Reason: "it must be an absolute file path"}
+	}
+	dir := filepath.Dir(path)
+	info, err := r.os.Stat(dir)
+	if err != nil {
+		return "", &InvalidValueError{Key: key, Value: path, Reason: err.Error()}
+	} else if !info.IsDir() {
+		return "", &InvalidValueError{Key: key, Value: path, Reason: dir + " is not a directory"}
+	} else if err := unix.Access(dir, unix.W_OK); err != nil {
+		return "", &InvalidValueError{Key: key, Value: path,
+			Reason: "directory " + dir + " is not writable: " + err.Error()}
+	}
+	return path, nil
+}
+
+// GetConnectionLogMaxSize obtains the size in bytes the connection log file
+// is rotated at, from the environment variable CONNECTION_LOG_MAX_SIZE.
+// It can have a k or m suffix and defaults to 1m.
+func (r *reader) GetConnectionLogMaxSize() (size uint64, err error) {
+	const key = "CONNECTION_LOG_MAX_SIZE"
+	const minSize, maxSize = 1 << 10, 1 << 30 // 1KB, 1GB
+	s, err := r.env.Get(key, libparams.Default("1m"))
+	if err != nil {
+		return 0, err
+	}
+	size, err = parseByteSize(s)
+	if err != nil {
+		return 0, &InvalidValueError{Key: key, Value: s, Reason: err.Error()}
+	} else if size < minSize || size > maxSize {
+		return 0, &InvalidValueError{Key: key, Value: s, Reason: "it must be between 1k and 1024m"}
+	}
+	return size, nil
+}
+
+// GetOpenVPNLogScrub obtains if secrets such as credentials, auth tokens
+// and private keys should be redacted from the OpenVPN logs, from the
+// environment variable OPENVPN_LOG_SCRUB. It is on by default.
+func (r *reader) GetOpenVPNLogScrub() (scrub bool, err error) {
+	return r.env.OnOff("OPENVPN_LOG_SCRUB", libparams.Default("on"))
+}
+
+// GetOpenVPNPersistTun obtains if OpenVPN should keep the tun device
+// across reconnections, from the environment variable OPENVPN_PERSIST_TUN.
+// It is on by default, and can only be off if OpenVPN runs as root.
+func (r *reader) GetOpenVPNPersistTun() (persist bool, err error) {
+	return r.env.OnOff("OPENVPN_PERSIST_TUN", libparams.Default("on"))
+}
+
+// GetOpenVPNPersistKey obtains if OpenVPN should keep the keys in memory
+// across reconnections instead of reading them again, from the environment
+// variable OPENVPN_PERSIST_KEY. It is on by default.
+func (r *reader) GetOpenVPNPersistKey() (persist bool, err error) {
+	return r.env.OnOff("OPENVPN_PERSIST_KEY", libparams.Default("on"))
+}
+
+// GetOpenVPNResolvRetry obtains for how long OpenVPN should retry resolving
+// the VPN server hostname, from the environment variable OPENVPN_RESOLV_RETRY.
+// It can be infinite (default) or a positive number of seconds.
+func (r *reader) GetOpenVPNResolvRetry() (retry string, err error) {
+	const key = "OPENVPN_RESOLV_RETRY"
+	retry, err = r.env.Get(key, libparams.Default("infinite"))
+	if err != nil || retry == "infinite" {
+		return retry, err
+	}
+	seconds, err := strconv.Atoi(retry)
+	if err != nil || seconds <= 0 {
+		return "", &InvalidValueError{Key: key, Value: retry,
+			Reason: "it must be infinite or a positive number of seconds"}
+	}
+	return retry, nil
+}
+
+// GetOpenVPNExplicitExitNotify obtains if OpenVPN should notify the server
+// when exiting, from the environment variable OPENVPN_EXPLICIT_EXIT_NOTIFY.
+// If unset, it returns nil.
+func (r *reader) GetOpenVPNExplicitExitNotify() (notify *bool, err error) {
+	const key = "OPENVPN_EXPLICIT_EXIT_NOTIFY"
+	s, err := r.env.Get(key)
+	if err != nil || len(s) == 0 {
+		return nil, err
+	}
+	on, err := r.env.OnOff(key)
+	if err != nil {
+		return nil, err
+	}
+	return &on, nil
+}
+
+// GetOpenVPNFastIO obtains if OpenVPN should use its fast-io optimization,
+// from the environment variable OPENVPN_FAST_IO.
+func (r *reader) GetOpenVPNFastIO() (fastIO bool, err error) {
+	return r.env.OnOff("OPENVPN_FAST_IO", libparams.Default("off"))
+}
+
+// GetVPNMTUProbe obtains if OpenVPN should empirically test the MTU of
+// the tunnel on connection, from the environment variable VPN_MTU_PROBE.
+// It is off by default since it slows down the connection.
+func (r *reader) GetVPNMTUProbe() (probe bool, err error) {
+	return r.env.OnOff("VPN_MTU_PROBE", libparams.Default("off"))
+}
+
+// GetOpenVPNSndBuf obtains the OpenVPN socket send buffer size in bytes
+// from the environment variable OPENVPN_SNDBUF. It can be 0 to let the
+// operating system tune the buffer size, and if unset it returns nil
+// and the provider default is kept.
+func (r *reader) GetOpenVPNSndBuf() (size *uint64, err error) {
+	return r.getOptionalByteSize("OPENVPN_SNDBUF")
+}
+
+// GetOpenVPNRcvBuf obtains the OpenVPN socket receive buffer size in bytes
+// from the environment variable OPENVPN_RCVBUF. It can be 0 to let the
+// operating system tune the buffer size, and if unset it returns nil
+// and the provider default is kept.
+func (r *reader) GetOpenVPNRcvBuf() (size *uint64, err error) {
+	return r.getOptionalByteSize("OPENVPN_RCVBUF")
+}
+
+// GetOpenVPNMSSFix obtains the OpenVPN mssfix value in bytes from the
+// environment variable OPENVPN_MSSFIX. It is independent from the tunnel MTU.
+// It can be 0 to disable the MSS clamping, and if unset it returns nil
+// and the provider default is kept.
+func (r *reader) GetOpenVPNMSSFix() (mssFix *uint16, err error) {
+	const (
+		key       = "OPENVPN_MSSFIX"
+		maxMSSFix = 10000
+	)
+	s, err := r.env.Get(key)
+	if err != nil || len(s) == 0 {
+		return nil, err
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > maxMSSFix {
+		return nil, &InvalidValueError{Key: key, Value: s,
+			Reason: fmt.Sprintf("it must be an integer between 0 and %d", maxMSSFix)}
+	}
+	value := uint16(n)
+	return &value, nil
+}
+
+// GetOpenVPNReachabilityCheck obtains the number of servers to check in
+// parallel before starting OpenVPN, such that OpenVPN connects to the first
+// server answering, from the environment variable OPENVPN_REACHABILITY_CHECK.
+// Over TCP a server must accept a TCP connection, and over UDP it must answer
+// an OpenVPN reset packet, which servers using tls-auth never do.
+// It must be between 2 and 5, and 0 (the default) disables it.
+func (r *reader) GetOpenVPNReachabilityCheck() (count int, err error) {
+	const (
+		key      = "OPENVPN_REACHABILITY_CHECK"
+		minCount = 2
+		maxCount = 5
+	)
+	count, err = r.env.Int(key, libparams.Default("0"))
+	if err != nil {
+		return 0, err
+	}
+	if count != 0 && (count < minCount || count > maxCount) {
+		return 0, &InvalidValueError{Key: key, Value: strconv.Itoa(count),
+			Reason: fmt.Sprintf("it must be 0 or between %d and %d", minCount, maxCount)}
+	}
+	return count, nil
+}
+
+// GetOpenVPNTLSVersionMin obtains the minimum TLS version OpenVPN should
+// use for its control channel, from the environment variable
+// OPENVPN_TLS_VERSION_MIN, which can be 1.2 or 1.3. If unset, the provider
+// default is kept.
+func (r *reader) GetOpenVPNTLSVersionMin() (version string, err error) {
+	const key = "OPENVPN_TLS_VERSION_MIN"
+	version, err = r.env.Get(key)
+	if err != nil || len(version) == 0 {
+		return "", err
+	}
+	choices := []string{"1.2", "1.3"}
+	if !isInside(version, choices) {
+		return "", &InvalidValueError{Key: key, Value: version, Accepted: choices}
 	}
-	return uint16(n), nil
+	return version, nil
 }
@@ -0,0 +1,58 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseReconnectJitter(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		s      string
+		jitter float64
+		err    string
+	}{
+		"zero": {
+			s: "0",
+		},
+		"fraction": {
+			s:      "0.25",
+			jitter: 0.25,
+		},
+		"one": {
+			s:      "1",
+			jitter: 1,
+		},
+		"not a number": {
+			s:   "abc",
+			err: `environment variable OPENVPN_RECONNECT_JITTER value "abc" is not valid: it must be a number between 0 and 1`,
+		},
+		"NaN": {
+			s:   "NaN",
+			err: `environment variable OPENVPN_RECONNECT_JITTER value "NaN" is not valid: it must be a number between 0 and 1`,
+		},
+		"negative": {
+			s:   "-0.5",
+			err: `environment variable OPENVPN_RECONNECT_JITTER value "-0.5" is not valid: it must be a number between 0 and 1`,
+		},
+		"above one": {
+			s:   "1.5",
+			err: `environment variable OPENVPN_RECONNECT_JITTER value "1.5" is not valid: it must be a number between 0 and 1`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			jitter, err := parseReconnectJitter(testCase.s)
+			if len(testCase.err) > 0 {
+				assert.EqualError(t, err, testCase.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, testCase.jitter, jitter)
+		})
+	}
+}
@@ -22,6 +22,20 @@ type Reader interface {
 	GetDNSOverTLSVerbosity() (verbosityLevel uint8, err error)
 	GetDNSOverTLSVerbosityDetails() (verbosityDetailsLevel uint8, err error)
 	GetDNSOverTLSValidationLogLevel() (validationLogLevel uint8, err error)
+	GetDNSThreads() (threads int, err error)
+	GetDNSRateLimit() (rateLimit int, err error)
+	GetDNSMaxQueries() (maxQueries int, err error)
+	GetDNSOverTLSMinVersion() (version string, err error)
+	GetDNSAccessControl() (allowed []net.IPNet, err error)
+	GetDNSLogFile() (path string, err error)
+	GetDNSRootHints() (path string, err error)
+	GetDNSOverTLSCABundle() (path string, err error)
+	GetDNSPrefetch() (prefetch bool, err error)
+	GetDNSSocketBuffers() (rcvBuf, sndBuf uint64, err error)
+	GetDNSStats() (enabled bool, err error)
+	GetDNSServeTLS() (serve bool, err error)
+	GetDNSServeTLSAddress() (address string, err error)
+	GetDNSServeTLSCertificate() (certFile, keyFile string, err error)
 	GetDNSMaliciousBlocking() (blocking bool, err error)
 	GetDNSSurveillanceBlocking() (blocking bool, err error)
 	GetDNSAdsBlocking() (blocking bool, err error)
@@ -29,21 +43,36 @@ type Reader interface {
 	GetDNSOverTLSPrivateAddresses() (privateAddresses []string, err error)
 	GetDNSOverTLSIPv6() (ipv6 bool, err error)
 	GetDNSUpdatePeriod() (period time.Duration, err error)
+	GetDNSBlockListsMirrors() (mirrors []string, err error)
 	GetDNSPlaintext() (ip net.IP, err error)
 	GetDNSKeepNameserver() (on bool, err error)
+	GetDNSKeepNameserverInterface() (interfaceName string, err error)
 
 	// System
 	GetPUID() (puid int, err error)
 	GetPGID() (pgid int, err error)
 	GetTimezone() (timezone string, err error)
+	GetRandomizeHostname() (randomize bool, err error)
+	GetIPFamily() (family string, err error)
+	GetDisableIPv6() (disable bool, err error)
+	GetDataDir() (dir string, err error)
+	GetStorageLockTimeout() (timeout time.Duration, err error)
+	GetStartupWaitForNetwork() (timeout time.Duration, err error)
+	GetSystemdNotify() (notify bool, err error)
+	GetSystemdNotifySocket() (socket string, err error)
 	GetPublicIPFilepath() (filepath models.Filepath, err error)
 
 	// Firewall getters
 	GetFirewall() (enabled bool, err error)
 	GetVPNInputPorts() (ports []uint16, err error)
 	GetInputPorts() (ports []uint16, err error)
+	GetInputSources() (sources []net.IPNet, err error)
 	GetOutboundSubnets() (outboundSubnets []net.IPNet, err error)
 	GetFirewallDebug() (debug bool, err error)
+	GetFirewallBackend() (backend string, err error)
+	GetFirewallRuleComment() (comment string, err error)
+	GetUplinkInterface() (name string, err error)
+	GetFirewallStartupGrace() (grace time.Duration, warning string, err error)
 
 	// VPN getters
 	GetUser() (s string, err error)
@@ -52,14 +81,55 @@ type Reader interface {
 	GetOpenVPNVerbosity() (verbosity int, err error)
 	GetOpenVPNRoot() (root bool, err error)
 	GetTargetIP() (ip net.IP, err error)
+	GetServerSelectionSeed() (seed *int64, err error)
+	GetServerConnectOrder() (order string, err error)
+	GetContinents() (continents []string, err error)
+	GetRegionsExclusion(choices []string) (regions []string, err error)
+	GetHostnamesExclusion(choices []string) (hostnames []string, err error)
 	GetOpenVPNCipher() (cipher string, err error)
 	GetOpenVPNAuth() (auth string, err error)
 	GetOpenVPNIPv6() (tunnel bool, err error)
-	GetOpenVPNMSSFix() (mssFix uint16, err error)
+	GetOpenVPNMSSFix() (mssFix *uint16, err error)
+	GetVPNMTUProbe() (probe bool, err error)
+	GetTUNDevicePath() (path models.Filepath, err error)
+	GetOpenVPNReconnectJitter() (jitter float64, err error)
+	GetOpenVPNConfigTemplate() (configTemplate string, err error)
+	GetOpenVPNConfigDir() (lines []string, err error)
+	GetOpenVPNCompression() (compression string, err error)
+	GetVPNExtraRoutes() (routes []net.IPNet, err error)
+	GetOpenVPNConnectTimeout() (timeout time.Duration, err error)
+	GetOpenVPNAuthNocache() (nocache bool, err error)
+	GetOpenVPNAuthRetry() (retry string, err error)
+	GetOpenVPNExplicitExitNotify() (notify *bool, err error)
+	GetOpenVPNFastIO() (fastIO bool, err error)
+	GetPreConnectProxy() (proxy models.PreConnectProxy, err error)
+	GetOpenVPNSndBuf() (size *uint64, err error)
+	GetOpenVPNRcvBuf() (size *uint64, err error)
+	GetOpenVPNPullFilters() (filters []models.PullFilter, err error)
+	GetOpenVPNIgnoreDNSPush() (ignore bool, err error)
+	GetOpenVPNReachabilityCheck() (count int, err error)
+	GetOpenVPNTLSVersionMin() (version string, err error)
+	GetOpenVPNManagement() (enabled bool, err error)
+	GetOpenVPNRouteNoPull() (noPull bool, err error)
+	GetOpenVPNResolvRetry() (retry string, err error)
+	GetOpenVPNPersistTun() (persist bool, err error)
+	GetOpenVPNPersistKey() (persist bool, err error)
+	GetOpenVPNRenegSec() (seconds *int, err error)
+	GetOpenVPNConnectRetry() (seconds *int, err error)
+	GetOpenVPNConnectRetryMax() (retries *int, err error)
+	GetOpenVPNRouteDelay() (seconds *int, err error)
+	GetOpenVPNTunMTUExtra() (bytes *int, err error)
+	GetOpenVPNLogScrub() (scrub bool, err error)
+	GetOpenVPNScramble() (scramble string, err error)
+	GetOpenVPNKeepalive() (keepalive string, err error)
+	GetConnectionLogFile() (path string, err error)
+	GetConnectionLogMaxSize() (size uint64, err error)
 
 	// PIA getters
 	GetPortForwarding() (activated bool, err error)
+	GetPortForwardingOnly() (only bool, err error)
 	GetPortForwardingStatusFilepath() (filepath models.Filepath, err error)
+	GetPortForwardingRenewPeriod() (period time.Duration, err error)
 	GetPIAEncryptionPreset() (preset string, err error)
 	GetPIARegions() (regions []string, err error)
 
@@ -95,6 +165,9 @@ type Reader interface {
 	// Privado getters
 	GetPrivadoHostnames() (hostnames []string, err error)
 
+	// Custom provider getters
+	GetOpenVPNCustomRemotes() (remotes []models.OpenVPNConnection, err error)
+
 	// PureVPN getters
 	GetPurevpnRegions() (regions []string, err error)
 	GetPurevpnCountries() (countries []string, err error)
@@ -106,25 +179,36 @@ type Reader interface {
 	GetShadowSocksPort() (port uint16, warning string, err error)
 	GetShadowSocksPassword() (password string, err error)
 	GetShadowSocksMethod() (method string, err error)
+	GetShadowSocksUsers() (users []models.ShadowSocksUser, err error)
 
 	// HTTP proxy getters
 	GetHTTPProxy() (activated bool, err error)
 	GetHTTPProxyLog() (log bool, err error)
-	GetHTTPProxyPort() (port uint16, warning string, err error)
+	GetHTTPProxyPort() (start, end uint16, warning string, err error)
 	GetHTTPProxyUser() (user string, err error)
 	GetHTTPProxyPassword() (password string, err error)
 	GetHTTPProxyStealth() (stealth bool, err error)
+	GetHTTPProxySticky() (sticky bool, err error)
 
 	// Public IP getters
 	GetPublicIPPeriod() (period time.Duration, err error)
+	GetPublicIPTimeout() (timeout time.Duration, err error)
+	GetReconnectOnIPChange() (reconnect bool, err error)
 
 	// Control server
 	GetControlServerPort() (port uint16, warning string, err error)
 	GetControlServerLog() (enabled bool, err error)
 
 	GetVersionInformation() (enabled bool, err error)
+	GetUnknownEnvWarnings() (warnings []string, err error)
+
+	GetServersUpdatePeriod() (period time.Duration, err error)
+	GetUpdaterMirror() (mirror string, err error)
 
-	GetUpdaterPeriod() (period time.Duration, err error)
+	// Health getters
+	GetHealthIncludeDNS() (include bool, err error)
+	GetHealthTargets() (targets []string, err error)
+	GetHealthQuorum() (quorum int, err error)
 }
 
 type reader struct {
@@ -152,7 +236,7 @@ func (r *reader) GetVPNSP() (vpnServiceProvider models.VPNProvider, err error) {
 		[]string{
 			"pia", "private internet access",
 			"mullvad", "windscribe", "surfshark", "cyberghost",
-			"vyprvpn", "nordvpn", "purevpn", "privado",
+			"vyprvpn", "nordvpn", "purevpn", "privado", "custom",
 		}, libparams.Default("private internet access"))
 	if s == "pia" {
 		s = "private internet access"
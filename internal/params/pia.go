@@ -2,6 +2,7 @@ package params
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/models"
@@ -25,16 +26,39 @@ func (r *reader) GetPortForwarding() (activated bool, err error) {
 	return false, fmt.Errorf("PORT_FORWARDING can only be \"on\" or \"off\"")
 }
 
+// GetPortForwardingOnly obtains if only servers supporting port forwarding
+// should be selected, from the environment variable SERVER_PORT_FORWARDING_ONLY.
+func (r *reader) GetPortForwardingOnly() (only bool, err error) {
+	return r.env.OnOff("SERVER_PORT_FORWARDING_ONLY", libparams.Default("off"))
+}
+
 // GetPortForwardingStatusFilepath obtains the port forwarding status file path
 // from the environment variable PORT_FORWARDING_STATUS_FILE.
 func (r *reader) GetPortForwardingStatusFilepath() (filepath models.Filepath, err error) {
+	defaultFilepath, err := r.getStatusFilepathDefault("forwarded_port")
+	if err != nil {
+		return "", err
+	}
 	filepathStr, err := r.env.Path(
 		"PORT_FORWARDING_STATUS_FILE",
-		libparams.Default("/tmp/gluetun/forwarded_port"),
+		libparams.Default(defaultFilepath),
 		libparams.CaseSensitiveValue())
 	return models.Filepath(filepathStr), err
 }
 
+// GetPortForwardingRenewPeriod obtains the period to renew the forwarded port
+// binding at, from the environment variable PORT_FORWARDING_RENEW.
+func (r *reader) GetPortForwardingRenewPeriod() (period time.Duration, err error) {
+	period, err = r.env.Duration("PORT_FORWARDING_RENEW", libparams.Default("15m"))
+	if err != nil {
+		return 0, err
+	} else if period < time.Minute {
+		return 0, &InvalidValueError{Key: "PORT_FORWARDING_RENEW", Value: period.String(),
+			Reason: "it must be at least 1m"}
+	}
+	return period, nil
+}
+
 // GetPIAEncryptionPreset obtains the encryption level for the PIA connection
 // from the environment variable PIA_ENCRYPTION, and using ENCRYPTION for
 // retro compatibility.
@@ -61,5 +85,5 @@ func (r *reader) GetPIAEncryptionPreset() (preset string, err error) {
 // GetPIARegions obtains the regions for the PIA servers from the
 // environment variable REGION.
 func (r *reader) GetPIARegions() (regions []string, err error) {
-	return r.env.CSVInside("REGION", constants.PIAGeoChoices())
+	return r.getRegions(constants.PIAGeoChoices())
 }
@@ -0,0 +1,55 @@
+package params
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+	libparams "github.com/qdm12/golibs/params"
+)
+
+// GetPreConnectProxy obtains the proxy to use to reach the VPN server
+// from the environment variables PRECONNECT_PROXY_TYPE, which can be
+// socks or http and disables the proxy if empty, PRECONNECT_PROXY_ADDRESS
+// in the form host:port, and optionally PRECONNECT_PROXY_USER and
+// PRECONNECT_PROXY_PASSWORD. An SSH bastion can be used by running
+// a SOCKS proxy on it, for example with ssh -D.
+func (r *reader) GetPreConnectProxy() (proxy models.PreConnectProxy, err error) {
+	const typeKey = "PRECONNECT_PROXY_TYPE"
+	proxy.Type, err = r.env.Get(typeKey)
+	if err != nil || len(proxy.Type) == 0 {
+		return models.PreConnectProxy{}, err
+	}
+	typeChoices := []string{constants.SOCKSProxy, constants.HTTPProxy}
+	if !isInside(proxy.Type, typeChoices) {
+		return proxy, &InvalidValueError{Key: typeKey, Value: proxy.Type, Accepted: typeChoices}
+	}
+
+	const addressKey = "PRECONNECT_PROXY_ADDRESS"
+	address, err := r.env.Get(addressKey, libparams.Compulsory(), libparams.CaseSensitiveValue())
+	if err != nil {
+		return proxy, err
+	}
+	host, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return proxy, &InvalidValueError{Key: addressKey, Value: address, Reason: err.Error()}
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16) //nolint:gomnd
+	if err != nil || port == 0 || len(host) == 0 {
+		return proxy, &InvalidValueError{Key: addressKey, Value: address,
+			Reason: "it must be in the form host:port"}
+	}
+	proxy.Host, proxy.Port = host, uint16(port)
+
+	const compulsory = false
+	proxy.User, err = r.getFromEnvOrSecretFile("PRECONNECT_PROXY_USER", compulsory, nil)
+	if err != nil {
+		return proxy, err
+	}
+	proxy.Password, err = r.getFromEnvOrSecretFile("PRECONNECT_PROXY_PASSWORD", compulsory, nil)
+	if err != nil {
+		return proxy, err
+	}
+	return proxy, nil
+}
@@ -0,0 +1,64 @@
+package params
+
+import (
+	"strings"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+)
+
+// providerPrefixedEnvKeys are the environment variable keys which can be
+// prefixed with the VPN provider, such as MULLVAD_OPENVPN_USER.
+//
+//nolint:gochecknoglobals
+var providerPrefixedEnvKeys = []string{"OPENVPN_USER", "OPENVPN_PASSWORD"}
+
+// providerEnvPrefix returns the prefix of the environment variables
+// specific to the VPN provider given, such as PIA or MULLVAD.
+func providerEnvPrefix(provider models.VPNProvider) (prefix string) {
+	if provider == constants.PrivateInternetAccess {
+		return "PIA"
+	}
+	return strings.ToUpper(string(provider))
+}
+
+// getFromProviderEnvOrSecretFile obtains the value for the environment
+// variable key prefixed with the current VPN provider, such as
+// MULLVAD_OPENVPN_USER, or from its secret file. If it is not set,
+// it falls back on the unprefixed key.
+func (r *reader) getFromProviderEnvOrSecretFile(envKey string, compulsory bool,
+	retroKeys []string) (value string, err error) {
+	provider, err := r.GetVPNSP()
+	if err != nil {
+		return "", err
+	}
+	const prefixedCompulsory = false
+	prefixedKey := providerEnvPrefix(provider) + "_" + envKey
+	value, err = r.getFromEnvOrSecretFile(prefixedKey, prefixedCompulsory, nil)
+	if err != nil || len(value) > 0 {
+		return value, err
+	}
+	return r.getFromEnvOrSecretFile(envKey, compulsory, retroKeys)
+}
+
+// isProviderPrefixedEnvKey returns true if the key is one of the
+// provider prefixable keys prefixed with a VPN provider.
+func isProviderPrefixedEnvKey(key string) bool {
+	providers := []models.VPNProvider{
+		constants.Cyberghost, constants.Mullvad, constants.Nordvpn,
+		constants.PrivateInternetAccess, constants.Privado, constants.Purevpn,
+		constants.Surfshark, constants.Vyprvpn, constants.Windscribe,
+	}
+	for _, provider := range providers {
+		prefix := providerEnvPrefix(provider) + "_"
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		for _, prefixableKey := range providerPrefixedEnvKeys {
+			if strings.TrimPrefix(key, prefix) == prefixableKey {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -17,12 +17,37 @@ func (r *reader) GetPublicIPPeriod() (period time.Duration, err error) {
 	return time.ParseDuration(s)
 }
 
+// GetPublicIPTimeout obtains the timeout for the HTTP request to obtain the
+// public IP address, from the environment variable PUBLICIP_TIMEOUT.
+// It defaults to 10 seconds and must be positive.
+func (r *reader) GetPublicIPTimeout() (timeout time.Duration, err error) {
+	timeout, err = r.env.Duration("PUBLICIP_TIMEOUT", libparams.Default("10s"))
+	if err != nil {
+		return 0, err
+	} else if timeout <= 0 {
+		return 0, &InvalidValueError{Key: "PUBLICIP_TIMEOUT", Value: timeout.String(),
+			Reason: "it must be a positive duration"}
+	}
+	return timeout, nil
+}
+
+// GetReconnectOnIPChange obtains if the VPN should be reconnected when the
+// public IP address changes without an intentional reconnect, from the
+// environment variable RECONNECT_ON_IP_CHANGE. It is off by default.
+func (r *reader) GetReconnectOnIPChange() (reconnect bool, err error) {
+	return r.env.OnOff("RECONNECT_ON_IP_CHANGE", libparams.Default("off"))
+}
+
 // GetPublicIPFilepath obtains the public IP filepath
 // from the environment variable PUBLICIP_FILE with retro-compatible
 // environment variable IP_STATUS_FILE.
 func (r *reader) GetPublicIPFilepath() (filepath models.Filepath, err error) {
+	defaultFilepath, err := r.getStatusFilepathDefault("ip")
+	if err != nil {
+		return "", err
+	}
 	filepathStr, err := r.env.Path("PUBLICIP_FILE",
 		libparams.RetroKeys([]string{"IP_STATUS_FILE"}, r.onRetroActive),
-		libparams.Default("/tmp/gluetun/ip"), libparams.CaseSensitiveValue())
+		libparams.Default(defaultFilepath), libparams.CaseSensitiveValue())
 	return models.Filepath(filepathStr), err
 }
@@ -0,0 +1,49 @@
+package params
+
+import (
+	"strings"
+
+	"github.com/qdm12/gluetun/internal/models"
+	libparams "github.com/qdm12/golibs/params"
+)
+
+// GetOpenVPNPullFilters obtains the OpenVPN pull filter rules from the
+// environment variable OPENVPN_PULL_FILTER, as a comma separated list of
+// rules such as `ignore redirect-gateway`.
+func (r *reader) GetOpenVPNPullFilters() (filters []models.PullFilter, err error) {
+	s, err := r.env.Get("OPENVPN_PULL_FILTER", libparams.CaseSensitiveValue())
+	if err != nil {
+		return nil, err
+	} else if len(s) == 0 {
+		return nil, nil
+	}
+	for _, rule := range strings.Split(s, ",") {
+		filter, err := parsePullFilter(rule)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	return filters, nil
+}
+
+func parsePullFilter(rule string) (filter models.PullFilter, err error) {
+	const key = "OPENVPN_PULL_FILTER"
+	fields := strings.Fields(rule)
+	const minFields = 2
+	if len(fields) < minFields {
+		return filter, &InvalidValueError{Key: key, Value: rule,
+			Reason: "it must be an action followed by the option text"}
+	}
+	filter.Action = strings.ToLower(fields[0])
+	actions := []string{"accept", "ignore", "reject"}
+	if !isInside(filter.Action, actions) {
+		return filter, &InvalidValueError{Key: key, Value: fields[0], Accepted: actions}
+	}
+	filter.Text = strings.Join(fields[1:], " ")
+	if strings.Contains(filter.Text, `"`) {
+		return filter, &InvalidValueError{Key: key, Value: rule,
+			Reason: "the option text must not contain double quotes"}
+	}
+	return filter, nil
+}
@@ -0,0 +1,52 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parsePullFilter(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		rule   string
+		filter models.PullFilter
+		err    string
+	}{
+		"ignore rule": {
+			rule:   "ignore redirect-gateway",
+			filter: models.PullFilter{Action: "ignore", Text: "redirect-gateway"},
+		},
+		"accept rule with spaces": {
+			rule:   " Accept route 10.0.0.0 ",
+			filter: models.PullFilter{Action: "accept", Text: "route 10.0.0.0"},
+		},
+		"missing text": {
+			rule: "reject",
+			err:  `environment variable OPENVPN_PULL_FILTER value "reject" is not valid: it must be an action followed by the option text`, //nolint:lll
+		},
+		"invalid action": {
+			rule: "drop route",
+			err:  `environment variable OPENVPN_PULL_FILTER value "drop" is not valid: it can only be one of: accept, ignore, reject`, //nolint:lll
+		},
+		"double quotes": {
+			rule: `ignore "route"`,
+			err:  `environment variable OPENVPN_PULL_FILTER value "ignore \"route\"" is not valid: the option text must not contain double quotes`, //nolint:lll
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			filter, err := parsePullFilter(testCase.rule)
+			if len(testCase.err) > 0 {
+				assert.EqualError(t, err, testCase.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.filter, filter)
+		})
+	}
+}
@@ -7,7 +7,7 @@ import (
 // GetPurevpnRegions obtains the regions (continents) for the PureVPN servers from the
 // environment variable REGION.
 func (r *reader) GetPurevpnRegions() (regions []string, err error) {
-	return r.env.CSVInside("REGION", constants.PurevpnRegionChoices())
+	return r.getRegions(constants.PurevpnRegionChoices())
 }
 
 // GetPurevpnCountries obtains the countries for the PureVPN servers from the
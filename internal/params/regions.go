@@ -0,0 +1,68 @@
+package params
+
+import (
+	"fmt"
+	"strings"
+
+	libparams "github.com/qdm12/golibs/params"
+)
+
+// getRegions obtains the regions from the comma separated list of the
+// environment variable REGION merged with the regions listed in the file
+// at the path given by the environment variable REGION_FILE, if set.
+// Each region is validated against the choices given, and duplicates
+// are removed.
+func (r *reader) getRegions(choices []string) (regions []string, err error) {
+	regions, err = r.csvInside("REGION", choices)
+	if err != nil {
+		return nil, err
+	}
+	filepath, err := r.env.Get("REGION_FILE", libparams.CaseSensitiveValue())
+	if err != nil {
+		return nil, err
+	} else if len(filepath) == 0 {
+		return regions, nil
+	}
+	b, err := readFromFile(r.os.OpenFile, filepath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrReadNonSecretFile, err)
+	}
+	for _, region := range parseRegionsFile(string(b)) {
+		if !isInside(region, choices) {
+			return nil, &InvalidValueError{Key: "REGION_FILE", Value: region, Accepted: choices}
+		}
+		regions = append(regions, region)
+	}
+	return dedupe(regions), nil
+}
+
+// parseRegionsFile returns the regions listed one per line in the content given,
+// ignoring blank lines and lines starting with #.
+func parseRegionsFile(content string) (regions []string) {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		regions = append(regions, line)
+	}
+	return regions
+}
+
+func isInside(value string, choices []string) bool {
+	for _, choice := range choices {
+		if strings.EqualFold(value, choice) {
+			return true
+		}
+	}
+	return false
+}
+
+func dedupe(values []string) (deduped []string) {
+	for _, value := range values {
+		if !isInside(value, deduped) {
+			deduped = append(deduped, value)
+		}
+	}
+	return deduped
+}
@@ -0,0 +1,36 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseRegionsFile(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		content string
+		regions []string
+	}{
+		"empty content": {},
+		"blank lines and comments": {
+			content: "# my regions\nGermany\n\n  France  \n#Spain\n",
+			regions: []string{"Germany", "France"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			regions := parseRegionsFile(testCase.content)
+			assert.Equal(t, testCase.regions, regions)
+		})
+	}
+}
+
+func Test_dedupe(t *testing.T) {
+	t.Parallel()
+	deduped := dedupe([]string{"germany", "France", "Germany", "france"})
+	assert.Equal(t, []string{"germany", "France"}, deduped)
+}
@@ -35,3 +35,27 @@ func (r *reader) GetOutboundSubnets() (outboundSubnets []net.IPNet, err error) {
 	}
 	return outboundSubnets, nil
 }
+
+// GetVPNExtraRoutes obtains the subnets to route through the VPN tunnel
+// once it is up from the comma separated CIDRs of the environment
+// variable VPN_ROUTES.
+func (r *reader) GetVPNExtraRoutes() (routes []net.IPNet, err error) {
+	return r.getCIDRs("VPN_ROUTES")
+}
+
+func (r *reader) getCIDRs(key string) (cidrs []net.IPNet, err error) {
+	s, err := r.env.Get(key)
+	if err != nil {
+		return nil, err
+	} else if s == "" {
+		return nil, nil
+	}
+	for _, subnet := range strings.Split(s, ",") {
+		_, cidr, err := net.ParseCIDR(strings.TrimSpace(subnet))
+		if err != nil {
+			return nil, &InvalidValueError{Key: key, Value: subnet, Reason: err.Error()}
+		}
+		cidrs = append(cidrs, *cidr)
+	}
+	return cidrs, nil
+}
@@ -0,0 +1,32 @@
+package params
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrScrambleMethod    = errors.New("scramble method is not valid")
+	ErrScrambleArguments = errors.New("scramble method has a wrong number of arguments")
+)
+
+func parseScramble(s string) (scramble string, err error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("%w: it is empty", ErrScrambleMethod)
+	}
+	var arguments int
+	switch fields[0] {
+	case "xorptrpos", "reverse":
+	case "xormask", "obfuscate":
+		arguments = 1
+	default:
+		return "", fmt.Errorf("%w: %s must be one of xormask, xorptrpos, reverse or obfuscate",
+			ErrScrambleMethod, fields[0])
+	}
+	if len(fields)-1 != arguments {
+		return "", fmt.Errorf("%w: %s requires %d argument(s)", ErrScrambleArguments, fields[0], arguments)
+	}
+	return strings.Join(fields, " "), nil
+}
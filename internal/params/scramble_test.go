@@ -0,0 +1,52 @@
+package params
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseScramble(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		s        string
+		scramble string
+		err      error
+	}{
+		"xormask": {
+			s:        "xormask  a",
+			scramble: "xormask a",
+		},
+		"reverse": {
+			s:        "reverse",
+			scramble: "reverse",
+		},
+		"only whitespace": {
+			s:   "  ",
+			err: ErrScrambleMethod,
+		},
+		"unknown method": {
+			s:   "rot13",
+			err: ErrScrambleMethod,
+		},
+		"missing mask": {
+			s:   "xormask",
+			err: ErrScrambleArguments,
+		},
+		"extra argument": {
+			s:   "xorptrpos 1",
+			err: ErrScrambleArguments,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			scramble, err := parseScramble(testCase.s)
+			assert.True(t, errors.Is(err, testCase.err))
+			assert.Equal(t, testCase.scramble, scramble)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	nativeos "os"
 	"strings"
 
 	"github.com/qdm12/golibs/os"
@@ -16,6 +17,7 @@ var (
 	ErrSecretFileIsEmpty = errors.New("secret file is empty")
 	ErrReadNonSecretFile = errors.New("cannot read non secret file")
 	ErrFilesDoNotExist   = errors.New("files do not exist")
+	ErrSecretFileExposed = errors.New("secret file is readable by group or others")
 )
 
 func (r *reader) getFromEnvOrSecretFile(envKey string, compulsory bool, retroKeys []string) (value string, err error) {
@@ -48,6 +50,10 @@ func (r *reader) getFromEnvOrSecretFile(envKey string, compulsory bool, retroKey
 	} else if fileErr != nil {
 		return "", fmt.Errorf("%w: %s", ErrReadSecretFile, fileErr)
 	}
+	if err := r.checkSecretFilePermissions(filepath); err != nil {
+		_ = file.Close()
+		return "", err
+	}
 
 	b, err := ioutil.ReadAll(file)
 	if err != nil {
@@ -79,6 +85,9 @@ func (r *reader) getFromFileOrSecretFile(secretName, filepath string) (
 	if err != nil && !os.IsNotExist(err) {
 		return b, fmt.Errorf("%w: %s", ErrReadSecretFile, err)
 	} else if err == nil {
+		if err := r.checkSecretFilePermissions(secretFilepath); err != nil {
+			return nil, err
+		}
 		return b, nil
 	}
 
@@ -107,3 +116,33 @@ func readFromFile(openFile os.OpenFileFunc, filepath string) (b []byte, err erro
 	}
 	return b, nil
 }
+
+// checkSecretFilePermissions logs a warning if the secret file is readable
+// by its group or by others, or returns an error if the environment variable
+// SECRETS_STRICT_PERMS is on.
+func (r *reader) checkSecretFilePermissions(filepath string) (err error) {
+	info, err := r.os.Stat(filepath)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrReadSecretFile, err)
+	}
+	if !isExposedFileMode(info.Mode()) {
+		return nil
+	}
+	strict, err := r.env.OnOff("SECRETS_STRICT_PERMS", libparams.Default("off"))
+	if err != nil {
+		return err
+	}
+	if strict {
+		return fmt.Errorf("%w: %s has permissions %s", ErrSecretFileExposed, filepath, info.Mode().Perm())
+	}
+	r.logger.Warn("secret file %s has permissions %s: it should not be readable by group or others",
+		filepath, info.Mode().Perm())
+	return nil
+}
+
+// isExposedFileMode returns true if the file mode given
+// allows the group or others to read the file.
+func isExposedFileMode(mode nativeos.FileMode) bool {
+	const groupOrOthersRead = 0044
+	return mode.Perm()&groupOrOthersRead != 0
+}
@@ -0,0 +1,30 @@
+package params
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_isExposedFileMode(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		mode    os.FileMode
+		exposed bool
+	}{
+		"owner only":         {mode: 0600},
+		"group readable":     {mode: 0640, exposed: true},
+		"world readable":     {mode: 0604, exposed: true},
+		"owner and executes": {mode: 0711},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			exposed := isExposedFileMode(testCase.mode)
+			assert.Equal(t, testCase.exposed, exposed)
+		})
+	}
+}
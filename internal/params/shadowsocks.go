@@ -1,6 +1,10 @@
 package params
 
 import (
+	"strconv"
+	"strings"
+
+	"github.com/qdm12/gluetun/internal/models"
 	libparams "github.com/qdm12/golibs/params"
 )
 
@@ -35,3 +39,46 @@ func (r *reader) GetShadowSocksPassword() (password string, err error) {
 func (r *reader) GetShadowSocksMethod() (method string, err error) {
 	return r.env.Get("SHADOWSOCKS_METHOD", libparams.Default("chacha20-ietf-poly1305"))
 }
+
+// GetShadowSocksUsers obtains the Shadowsocks users from the environment
+// variable SHADOWSOCKS_USERS, as a comma separated list of port:password
+// pairs. If set, each user is served on its own port and SHADOWSOCKS_PORT
+// and SHADOWSOCKS_PASSWORD are ignored.
+func (r *reader) GetShadowSocksUsers() (users []models.ShadowSocksUser, err error) {
+	s, err := r.env.Get("SHADOWSOCKS_USERS", libparams.CaseSensitiveValue())
+	if err != nil {
+		return nil, err
+	} else if len(s) == 0 {
+		return nil, nil
+	}
+	return parseShadowSocksUsers(s)
+}
+
+func parseShadowSocksUsers(s string) (users []models.ShadowSocksUser, err error) {
+	const key = "SHADOWSOCKS_USERS"
+	ports := make(map[uint16]struct{})
+	for _, value := range strings.Split(s, ",") {
+		const fields = 2
+		parts := strings.SplitN(value, ":", fields)
+		if len(parts) != fields {
+			return nil, &InvalidValueError{Key: key, Value: value,
+				Reason: "it must be in the format port:password"}
+		}
+		port, err := strconv.Atoi(parts[0])
+		if err != nil || port < 1 || port > 65535 {
+			return nil, &InvalidValueError{Key: key, Value: parts[0],
+				Reason: "the port must be between 1 and 65535"}
+		} else if len(parts[1]) == 0 {
+			return nil, &InvalidValueError{Key: key, Value: parts[0],
+				Reason: "the password for this port cannot be empty"}
+		}
+		user := models.ShadowSocksUser{Port: uint16(port), Password: parts[1]}
+		if _, ok := ports[user.Port]; ok {
+			return nil, &InvalidValueError{Key: key, Value: parts[0],
+				Reason: "the port is used by more than one user"}
+		}
+		ports[user.Port] = struct{}{}
+		users = append(users, user)
+	}
+	return users, nil
+}
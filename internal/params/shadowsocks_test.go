@@ -0,0 +1,59 @@
+package params
+
+import (
+	"testing"
+
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseShadowSocksUsers(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		s     string
+		users []models.ShadowSocksUser
+		err   string
+	}{
+		"single user": {
+			s:     "8388:secret",
+			users: []models.ShadowSocksUser{{Port: 8388, Password: "secret"}},
+		},
+		"multiple users with colon in password": {
+			s: "8388:a,8389:b:c",
+			users: []models.ShadowSocksUser{
+				{Port: 8388, Password: "a"},
+				{Port: 8389, Password: "b:c"},
+			},
+		},
+		"missing password": {
+			s:   "8388",
+			err: `environment variable SHADOWSOCKS_USERS value "8388" is not valid: it must be in the format port:password`, //nolint:lll
+		},
+		"empty password": {
+			s:   "8388:",
+			err: `environment variable SHADOWSOCKS_USERS value "8388" is not valid: the password for this port cannot be empty`, //nolint:lll
+		},
+		"invalid port": {
+			s:   "70000:secret",
+			err: `environment variable SHADOWSOCKS_USERS value "70000" is not valid: the port must be between 1 and 65535`, //nolint:lll
+		},
+		"duplicate port": {
+			s:   "8388:a,8388:b",
+			err: `environment variable SHADOWSOCKS_USERS value "8388" is not valid: the port is used by more than one user`, //nolint:lll
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			users, err := parseShadowSocksUsers(testCase.s)
+			if len(testCase.err) > 0 {
+				assert.EqualError(t, err, testCase.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.users, users)
+		})
+	}
+}
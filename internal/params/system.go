@@ -1,6 +1,11 @@
 package params
 
 import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/qdm12/gluetun/internal/constants"
 	libparams "github.com/qdm12/golibs/params"
 )
 
@@ -20,7 +25,144 @@ func (r *reader) GetPGID() (pgid int, err error) {
 		libparams.RetroKeys([]string{"GID"}, r.onRetroActive))
 }
 
-// GetTZ obtains the timezone from the environment variable TZ.
+// GetTimezone obtains the timezone from the environment variable TZ.
+// It returns an error if the timezone is unknown.
 func (r *reader) GetTimezone() (timezone string, err error) {
-	return r.env.Get("TZ")
+	timezone, err = r.env.Get("TZ", libparams.CaseSensitiveValue())
+	if err != nil || len(timezone) == 0 {
+		return "", err
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return "", &InvalidValueError{Key: "TZ", Value: timezone, Reason: err.Error()}
+	}
+	return timezone, nil
+}
+
+// GetRandomizeHostname obtains if the hostname should be set to a random
+// value at startup, from the environment variable RANDOMIZE_HOSTNAME.
+func (r *reader) GetRandomizeHostname() (randomize bool, err error) {
+	return r.env.OnOff("RANDOMIZE_HOSTNAME", libparams.Default("off"))
+}
+
+// GetIPFamily obtains the IP family to use through the tunnel, from the
+// environment variable IP_FAMILY, which can be ipv4 (default), ipv6 or dual.
+// It drives the firewall and tunnel routes and sets the defaults of
+// OPENVPN_IPV6 and DOT_IPV6.
+func (r *reader) GetIPFamily() (family string, err error) {
+	return r.env.Inside("IP_FAMILY", []string{
+		constants.IPv4Family, constants.IPv6Family, constants.DualStackFamily},
+		libparams.Default(constants.IPv4Family))
+}
+
+// familyUsesIPv6 returns true if the IP family of IP_FAMILY uses IPv6.
+func (r *reader) familyUsesIPv6() (ipv6 bool, err error) {
+	family, err := r.GetIPFamily()
+	if err != nil {
+		return false, err
+	}
+	return family != constants.IPv4Family, nil
+}
+
+func onOff(on bool) string {
+	if on {
+		return "on"
+	}
+	return "off"
+}
+
+// GetDisableIPv6 obtains if IPv6 should be disabled on the network
+// interfaces at startup, from the environment variable DISABLE_IPV6.
+// It defaults to off and cannot be on if IP_FAMILY uses IPv6.
+func (r *reader) GetDisableIPv6() (disable bool, err error) {
+	const key = "DISABLE_IPV6"
+	disable, err = r.env.OnOff(key, libparams.Default("off"))
+	if err != nil || !disable {
+		return disable, err
+	}
+	ipv6, err := r.familyUsesIPv6()
+	if err != nil {
+		return false, err
+	} else if ipv6 {
+		return false, &InvalidValueError{Key: key, Value: "on",
+			Reason: "it cannot be on with IP_FAMILY ipv6 or dual"}
+	}
+	return true, nil
+}
+
+// GetDataDir obtains the directory path to store data and generated files in,
+// such as the servers data file, from the environment variable DATA_DIR.
+func (r *reader) GetDataDir() (dir string, err error) {
+	return r.env.Path("DATA_DIR", libparams.Default(constants.DefaultDataDir),
+		libparams.CaseSensitiveValue())
+}
+
+// GetStorageLockTimeout obtains how long to wait for another process to
+// release the servers data file, from the environment variable
+// STORAGE_LOCK_TIMEOUT. It defaults to 10 seconds and must be positive.
+func (r *reader) GetStorageLockTimeout() (timeout time.Duration, err error) {
+	const key = "STORAGE_LOCK_TIMEOUT"
+	timeout, err = r.env.Duration(key, libparams.Default("10s"))
+	if err != nil {
+		return 0, err
+	} else if timeout <= 0 {
+		return 0, &InvalidValueError{Key: key, Value: timeout.String(),
+			Reason: "it must be a positive duration"}
+	}
+	return timeout, nil
+}
+
+// getStatusFilepathDefault returns the default file path of the status file
+// name given. It is in the data directory if DATA_DIR is set, and in
+// /tmp/gluetun otherwise for retro-compatibility.
+func (r *reader) getStatusFilepathDefault(name string) (path string, err error) {
+	dir, err := r.env.Get("DATA_DIR")
+	if err != nil {
+		return "", err
+	}
+	if len(dir) == 0 {
+		return filepath.Join(constants.DefaultStatusDir, name), nil
+	}
+	dir, err = r.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name), nil
+}
+
+// GetSystemdNotify obtains if systemd should be notified once the VPN is
+// connected, from the environment variable SYSTEMD_NOTIFY. It is off by default.
+func (r *reader) GetSystemdNotify() (notify bool, err error) {
+	return r.env.OnOff("SYSTEMD_NOTIFY", libparams.Default("off"))
+}
+
+// GetSystemdNotifySocket obtains the systemd notification socket from the
+// environment variable NOTIFY_SOCKET set by systemd. It returns an empty
+// string if unset, for example when not running as a systemd service.
+func (r *reader) GetSystemdNotifySocket() (socket string, err error) {
+	const key = "NOTIFY_SOCKET"
+	socket, err = r.env.Get(key, libparams.CaseSensitiveValue())
+	if err != nil || len(socket) == 0 {
+		return "", err
+	}
+	if !filepath.IsAbs(socket) && !strings.HasPrefix(socket, "@") {
+		return "", &InvalidValueError{Key: key, Value: socket,
+			Reason: "it must be an absolute path or an abstract socket name starting with @"}
+	}
+	return socket, nil
+}
+
+// GetStartupWaitForNetwork obtains the maximum duration to wait at startup
+// for a default route to be available, for hosts with a network slow to be
+// ready, from the environment variable STARTUP_WAIT_NETWORK. It defaults
+// to 0 to not wait and fail immediately if there is no default route, and
+// cannot be negative. Name resolution is not awaited.
+func (r *reader) GetStartupWaitForNetwork() (timeout time.Duration, err error) {
+	timeout, err = r.env.Duration("STARTUP_WAIT_NETWORK", libparams.Default("0"))
+	if err != nil {
+		return 0, err
+	} else if timeout < 0 {
+		return 0, &InvalidValueError{Key: "STARTUP_WAIT_NETWORK", Value: timeout.String(),
+			Reason: "it cannot be negative"}
+	}
+	return timeout, nil
 }
@@ -0,0 +1,292 @@
+package params
+
+import (
+	"fmt"
+	nativeos "os"
+	"sort"
+	"strings"
+
+	libparams "github.com/qdm12/golibs/params"
+)
+
+// knownEnvKeys contains all the environment variable keys read by the program,
+// including retro-compatible keys.
+//
+//nolint:gochecknoglobals
+var knownEnvKeys = map[string]struct{}{
+	"BLOCK_ADS":                     {},
+	"BLOCK_MALICIOUS":               {},
+	"BLOCK_NSA":                     {},
+	"BLOCK_SURVEILLANCE":            {},
+	"CITY":                          {},
+	"CONNECTION_LOG_FILE":           {},
+	"CONNECTION_LOG_MAX_SIZE":       {},
+	"CONTINENT":                     {},
+	"COUNTRY":                       {},
+	"CYBERGHOST_GROUP":              {},
+	"DATA_DIR":                      {},
+	"DISABLE_IPV6":                  {},
+	"DNS_BLOCK_LISTS_MIRRORS":       {},
+	"DNS_KEEP_NAMESERVER":           {},
+	"DNS_KEEP_NAMESERVER_INTERFACE": {},
+	"DNS_PLAINTEXT_ADDRESS":         {},
+	"DNS_UPDATE_PERIOD":             {},
+	"DOT":                           {},
+	"DOT_ACCESS_CONTROL":            {},
+	"DOT_CACHING":                   {},
+	"DOT_CA_BUNDLE":                 {},
+	"DOT_IPV6":                      {},
+	"DOT_LOG_FILE":                  {},
+	"DOT_MAX_QUERIES":               {},
+	"DOT_PREFETCH":                  {},
+	"DOT_PRIVATE_ADDRESS":           {},
+	"DOT_PROVIDERS":                 {},
+	"DOT_RATE_LIMIT":                {},
+	"DOT_ROOT_HINTS":                {},
+	"DOT_SERVE_TLS":                 {},
+	"DOT_SERVE_TLS_ADDRESS":         {},
+	"DOT_SERVE_TLS_CERTFILE":        {},
+	"DOT_SERVE_TLS_KEYFILE":         {},
+	"DOT_SO_RCVBUF":                 {},
+	"DOT_SO_SNDBUF":                 {},
+	"DOT_STATS":                     {},
+	"DOT_THREADS":                   {},
+	"DOT_TLS_MIN_VERSION":           {},
+	"DOT_VALIDATION_LOGLEVEL":       {},
+	"DOT_VERBOSITY":                 {},
+	"DOT_VERBOSITY_DETAILS":         {},
+	"ENCRYPTION":                    {},
+	"EXTRA_SUBNETS":                 {},
+	"FIREWALL":                      {},
+	"FIREWALL_BACKEND":              {},
+	"FIREWALL_DEBUG":                {},
+	"FIREWALL_INPUT_PORTS":          {},
+	"FIREWALL_INPUT_SOURCES":        {},
+	"FIREWALL_OUTBOUND_SUBNETS":     {},
+	"FIREWALL_RULE_COMMENT":         {},
+	"FIREWALL_STARTUP_GRACE":        {},
+	"FIREWALL_VPN_INPUT_PORTS":      {},
+	"GID":                           {},
+	"HEALTH_INCLUDE_DNS":            {},
+	"HEALTH_QUORUM":                 {},
+	"HEALTH_TARGETS":                {},
+	"HOSTNAME":                      {},
+	"HTTPPROXY":                     {},
+	"HTTPPROXY_LOG":                 {},
+	"HTTPPROXY_PASSWORD":            {},
+	"HTTPPROXY_PORT":                {},
+	"HTTPPROXY_STEALTH":             {},
+	"HTTPPROXY_USER":                {},
+	"HTTP_CONTROL_SERVER_LOG":       {},
+	"HTTP_CONTROL_SERVER_PORT":      {},
+	"IP_FAMILY":                     {},
+	"IP_STATUS_FILE":                {},
+	"ISP":                           {},
+	"NOTIFY_SOCKET":                 {},
+	"OPENVPN_AUTH":                  {},
+	"OPENVPN_AUTH_NOCACHE":          {},
+	"OPENVPN_AUTH_RETRY":            {},
+	"OPENVPN_CIPHER":                {},
+	"OPENVPN_CLIENTCRT":             {},
+	"OPENVPN_CLIENTCRT_FILES":       {},
+	"OPENVPN_CLIENTKEY":             {},
+	"OPENVPN_COMPRESSION":           {},
+	"OPENVPN_CONFIG_DIR":            {},
+	"OPENVPN_CONFIG_TEMPLATE":       {},
+	"OPENVPN_CONNECT_RETRY":         {},
+	"OPENVPN_CONNECT_RETRY_MAX":     {},
+	"OPENVPN_CONNECT_TIMEOUT":       {},
+	"OPENVPN_CUSTOM_REMOTES":        {},
+	"OPENVPN_EXPLICIT_EXIT_NOTIFY":  {},
+	"OPENVPN_FAST_IO":               {},
+	"OPENVPN_IGNORE_DNS_PUSH":       {},
+	"OPENVPN_IPV6":                  {},
+	"OPENVPN_KEEPALIVE":             {},
+	"OPENVPN_LOG_SCRUB":             {},
+	"OPENVPN_MANAGEMENT":            {},
+	"OPENVPN_MSSFIX":                {},
+	"OPENVPN_PASSWORD":              {},
+	"OPENVPN_PERSIST_KEY":           {},
+	"OPENVPN_PERSIST_TUN":           {},
+	"OPENVPN_PULL_FILTER":           {},
+	"OPENVPN_RCVBUF":                {},
+	"OPENVPN_REACHABILITY_CHECK":    {},
+	"OPENVPN_RECONNECT_JITTER":      {},
+	"OPENVPN_RENEG_SEC":             {},
+	"OPENVPN_RESOLV_RETRY":          {},
+	"OPENVPN_ROOT":                  {},
+	"OPENVPN_ROUTE_DELAY":           {},
+	"OPENVPN_ROUTE_NOPULL":          {},
+	"OPENVPN_SCRAMBLE":              {},
+	"OPENVPN_SNDBUF":                {},
+	"OPENVPN_TARGET_IP":             {},
+	"OPENVPN_TLS_VERSION_MIN":       {},
+	"OPENVPN_TUN_MTU_EXTRA":         {},
+	"OPENVPN_USER":                  {},
+	"OPENVPN_VERBOSITY":             {},
+	"OWNED":                         {},
+	"PASSWORD":                      {},
+	"PGID":                          {},
+	"PIA_ENCRYPTION":                {},
+	"PORT":                          {},
+	"PORT_FORWARDING":               {},
+	"PORT_FORWARDING_RENEW":         {},
+	"PORT_FORWARDING_STATUS_FILE":   {},
+	"PRECONNECT_PROXY_ADDRESS":      {},
+	"PRECONNECT_PROXY_PASSWORD":     {},
+	"PRECONNECT_PROXY_TYPE":         {},
+	"PRECONNECT_PROXY_USER":         {},
+	"PROTOCOL":                      {},
+	"PROXY":                         {},
+	"PROXY_LOG_LEVEL":               {},
+	"PROXY_PASSWORD":                {},
+	"PROXY_PORT":                    {},
+	"PROXY_STICKY":                  {},
+	"PROXY_USER":                    {},
+	"PUBLICIP_FILE":                 {},
+	"PUBLICIP_PERIOD":               {},
+	"PUBLICIP_TIMEOUT":              {},
+	"PUID":                          {},
+	"RANDOMIZE_HOSTNAME":            {},
+	"RECONNECT_ON_IP_CHANGE":        {},
+	"REGION":                        {},
+	"REGION_EXCLUDE":                {},
+	"REGION_FILE":                   {},
+	"SECRETS_STRICT_PERMS":          {},
+	"SERVER_CONNECT_ORDER":          {},
+	"SERVER_HOSTNAME":               {},
+	"SERVER_HOSTNAME_EXCLUDE":       {},
+	"SERVER_NUMBER":                 {},
+	"SERVER_PORT_FORWARDING_ONLY":   {},
+	"SERVER_SELECTION_SEED":         {},
+	"SHADOWSOCKS":                   {},
+	"SHADOWSOCKS_LOG":               {},
+	"SHADOWSOCKS_METHOD":            {},
+	"SHADOWSOCKS_PASSWORD":          {},
+	"SHADOWSOCKS_PORT":              {},
+	"SHADOWSOCKS_USERS":             {},
+	"STARTUP_WAIT_NETWORK":          {},
+	"STORAGE_LOCK_TIMEOUT":          {},
+	"SYSTEMD_NOTIFY":                {},
+	"TINYPROXY":                     {},
+	"TINYPROXY_LOG":                 {},
+	"TINYPROXY_PASSWORD":            {},
+	"TINYPROXY_PORT":                {},
+	"TINYPROXY_USER":                {},
+	"TUN_DEVICE":                    {},
+	"TZ":                            {},
+	"UID":                           {},
+	"UNBLOCK":                       {},
+	"UPDATER_MIRROR":                {},
+	"UPDATER_PERIOD":                {},
+	"UPLINK_INTERFACE":              {},
+	"USER":                          {},
+	"VERSION_INFORMATION":           {},
+	"VPNSP":                         {},
+	"VPN_MTU_PROBE":                 {},
+	"VPN_ROUTES":                    {},
+	"WARN_UNKNOWN_ENV":              {},
+}
+
+// GetUnknownEnvWarnings obtains warnings for environment variables which look
+// like they are meant for the program but are not known to it, such as
+// typos like REGIN. It can be disabled with the environment variable
+// WARN_UNKNOWN_ENV.
+func (r *reader) GetUnknownEnvWarnings() (warnings []string, err error) {
+	warn, err := r.env.OnOff("WARN_UNKNOWN_ENV", libparams.Default("on"))
+	if err != nil || !warn {
+		return nil, err
+	}
+	return unknownEnvWarnings(nativeos.Environ()), nil
+}
+
+func unknownEnvWarnings(environ []string) (warnings []string) {
+	for _, keyValue := range environ {
+		key := strings.SplitN(keyValue, "=", 2)[0] //nolint:gomnd
+		if isKnownEnvKey(key) {
+			continue
+		}
+		if suggestion := closestKnownEnvKey(key); len(suggestion) > 0 {
+			warnings = append(warnings, fmt.Sprintf(
+				"unknown environment variable %s, did you mean %s?", key, suggestion))
+		} else if hasKnownEnvPrefix(key) {
+			warnings = append(warnings, "unknown environment variable "+key)
+		}
+	}
+	sort.Strings(warnings)
+	return warnings
+}
+
+func isKnownEnvKey(key string) bool {
+	key = strings.TrimSuffix(key, "_SECRETFILE")
+	_, ok := knownEnvKeys[key]
+	return ok || isProviderPrefixedEnvKey(key)
+}
+
+// hasKnownEnvPrefix returns true if the key starts with the same word
+// as a known key made of several words, such as OPENVPN_ or DOT_.
+func hasKnownEnvPrefix(key string) bool {
+	i := strings.Index(key, "_")
+	if i <= 0 {
+		return false
+	}
+	prefix := key[:i+1]
+	for knownKey := range knownEnvKeys {
+		if strings.HasPrefix(knownKey, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// closestKnownEnvKey returns the known key closest to the key given,
+// or the empty string if no known key is close enough.
+func closestKnownEnvKey(key string) (closest string) {
+	maxDistance := 1
+	const longKeyLength = 6
+	if len(key) >= longKeyLength {
+		maxDistance = 2
+	}
+	bestDistance := maxDistance + 1
+	for knownKey := range knownEnvKeys {
+		distance := levenshtein(key, knownKey)
+		if distance < bestDistance || (distance == bestDistance && knownKey < closest) {
+			bestDistance = distance
+			closest = knownKey
+		}
+	}
+	if bestDistance > maxDistance {
+		return ""
+	}
+	return closest
+}
+
+func levenshtein(a, b string) (distance int) {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minInt(values ...int) (minimum int) {
+	minimum = values[0]
+	for _, value := range values[1:] {
+		if value < minimum {
+			minimum = value
+		}
+	}
+	return minimum
+}
@@ -0,0 +1,65 @@
+package params
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_unknownEnvWarnings(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		environ  []string
+		warnings []string
+	}{
+		"no environment": {},
+		"known and unrelated keys": {
+			environ: []string{"PATH=/bin", "HOME=/root", "REGION=x", "USER_SECRETFILE=/f",
+				"MULLVAD_OPENVPN_USER=x", "PIA_OPENVPN_PASSWORD_SECRETFILE=/f"},
+		},
+		"typo": {
+			environ:  []string{"REGIN=US"},
+			warnings: []string{"unknown environment variable REGIN, did you mean REGION?"},
+		},
+		"unknown key with known prefix": {
+			environ:  []string{"OPENVPN_SOMETHING=1"},
+			warnings: []string{"unknown environment variable OPENVPN_SOMETHING"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			warnings := unknownEnvWarnings(testCase.environ)
+			assert.Equal(t, testCase.warnings, warnings)
+		})
+	}
+}
+
+func Test_knownEnvKeys(t *testing.T) {
+	t.Parallel()
+	paths, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+	keyRegex := regexp.MustCompile(`"([A-Z][A-Z0-9_]+)"`)
+	notKeys := map[string]struct{}{"PIA": {}}
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		content, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		for _, match := range keyRegex.FindAllStringSubmatch(string(content), -1) {
+			if _, ok := notKeys[match[1]]; ok {
+				continue
+			}
+			_, ok := knownEnvKeys[match[1]]
+			assert.True(t, ok, "key %s from %s is not registered", match[1], path)
+		}
+	}
+}
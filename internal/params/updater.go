@@ -1,17 +1,34 @@
 package params
 
 import (
+	"net/url"
+	"strings"
 	"time"
 
 	libparams "github.com/qdm12/golibs/params"
 )
 
-// GetUpdaterPeriod obtains the period to fetch the servers information when the tunnel is up.
-// Set to 0 to disable.
-func (r *reader) GetUpdaterPeriod() (period time.Duration, err error) {
-	s, err := r.env.Get("UPDATER_PERIOD", libparams.Default("0"))
-	if err != nil {
-		return 0, err
+// GetServersUpdatePeriod obtains the period to fetch the servers information
+// when the tunnel is up, from the environment variable UPDATER_PERIOD.
+// It defaults to 0 which disables the periodic update.
+func (r *reader) GetServersUpdatePeriod() (period time.Duration, err error) {
+	return r.env.Duration("UPDATER_PERIOD", libparams.Default("0"))
+}
+
+// GetUpdaterMirror obtains the base URL of a mirror to fetch the servers
+// information from instead of the VPN providers, from the environment
+// variable UPDATER_MIRROR. Each URL is fetched from the mirror at the path
+// made of its host and path, such as <mirror>/nordvpn.com/api/server.
+// It returns an empty string if unset, to fetch from the providers directly.
+func (r *reader) GetUpdaterMirror() (mirror string, err error) {
+	const key = "UPDATER_MIRROR"
+	mirror, err = r.env.Get(key, libparams.CaseSensitiveValue())
+	if err != nil || len(mirror) == 0 {
+		return "", err
+	}
+	u, err := url.Parse(mirror)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return "", &InvalidValueError{Key: key, Value: mirror, Reason: "it must be an http or https URL"}
 	}
-	return time.ParseDuration(s)
+	return strings.TrimSuffix(mirror, "/") + "/", nil
 }
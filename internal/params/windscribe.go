@@ -11,7 +11,7 @@ import (
 // GetWindscribeRegions obtains the regions for the Windscribe servers from the
 // environment variable REGION.
 func (r *reader) GetWindscribeRegions() (regions []string, err error) {
-	return r.env.CSVInside("REGION", constants.WindscribeRegionChoices())
+	return r.getRegions(constants.WindscribeRegionChoices())
 }
 
 // GetWindscribeCities obtains the cities for the Windscribe servers from the
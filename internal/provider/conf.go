@@ -0,0 +1,233 @@
+package provider
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/qdm12/gluetun/internal/settings"
+)
+
+// customizeConf modifies the OpenVPN configuration lines built by a provider
+// according to the user OpenVPN settings common to all providers.
+func customizeConf(lines []string, settings settings.OpenVPN) []string {
+	if len(settings.TUNDevice) > 0 && settings.TUNDevice != constants.TunnelDevice {
+		lines = setDirective(lines, "dev-node "+string(settings.TUNDevice))
+	}
+	switch settings.Compression {
+	case constants.NoCompression:
+		// keep the compression framing the server expects without compressing
+		if hasDirective(lines, "comp-lzo") {
+			lines = setDirective(lines, "comp-lzo no")
+		}
+		if hasDirective(lines, "compress") {
+			lines = setDirective(lines, "compress")
+		}
+	case constants.LZO:
+		lines = removeDirective(lines, "compress")
+		lines = setDirective(lines, "comp-lzo yes")
+	case constants.LZ4:
+		lines = removeDirective(lines, "comp-lzo")
+		lines = setDirective(lines, "compress lz4")
+	}
+	if settings.AuthNoCache {
+		lines = setDirective(lines, "auth-nocache")
+	} else {
+		lines = removeDirective(lines, "auth-nocache")
+	}
+	if len(settings.AuthRetry) > 0 {
+		lines = setDirective(lines, "auth-retry "+settings.AuthRetry)
+	}
+	if settings.PersistTun {
+		lines = setDirective(lines, "persist-tun")
+	} else {
+		lines = removeDirective(lines, "persist-tun")
+	}
+	if settings.PersistKey {
+		lines = setDirective(lines, "persist-key")
+	} else {
+		lines = removeDirective(lines, "persist-key")
+	}
+	if line := preConnectProxyLine(settings.PreConnectProxy); len(line) > 0 {
+		lines = setDirective(lines, line)
+	}
+	if !settings.ExplicitExitNotify {
+		lines = removeDirective(lines, "explicit-exit-notify")
+	} else if !hasDirective(lines, "explicit-exit-notify") {
+		lines = setDirective(lines, "explicit-exit-notify")
+	}
+	if len(settings.TLSVersionMin) > 0 {
+		lines = setDirective(lines, "tls-version-min "+settings.TLSVersionMin)
+	}
+	if len(settings.ResolvRetry) > 0 {
+		lines = setDirective(lines, "resolv-retry "+settings.ResolvRetry)
+	}
+	if settings.RenegSec != nil {
+		lines = setDirective(lines, "reneg-sec "+strconv.Itoa(*settings.RenegSec))
+	}
+	if settings.ConnectRetry != nil {
+		lines = setDirective(lines, "connect-retry "+strconv.Itoa(*settings.ConnectRetry))
+	}
+	if settings.ConnectRetryMax != nil {
+		lines = setDirective(lines, "connect-retry-max "+strconv.Itoa(*settings.ConnectRetryMax))
+	}
+	if settings.RouteDelay != nil {
+		lines = setDirective(lines, "route-delay "+strconv.Itoa(*settings.RouteDelay))
+	}
+	if settings.TunMTUExtra != nil {
+		lines = setDirective(lines, "tun-mtu-extra "+strconv.Itoa(*settings.TunMTUExtra))
+	}
+	if settings.MSSFix != nil {
+		lines = setDirective(lines, "mssfix "+strconv.Itoa(int(*settings.MSSFix)))
+	}
+	if len(settings.Scramble) > 0 {
+		lines = setDirective(lines, "scramble "+settings.Scramble)
+	}
+	if len(settings.Keepalive) > 0 {
+		lines = removeDirective(lines, "ping")
+		lines = removeDirective(lines, "ping-restart")
+		lines = removeDirective(lines, "ping-exit")
+		lines = setDirective(lines, "keepalive "+settings.Keepalive)
+	}
+	if settings.RouteNoPull {
+		lines = setDirective(lines, "route-nopull")
+	}
+	if settings.Management {
+		lines = setDirective(lines, "management "+string(constants.OpenVPNManagementSocket)+" unix")
+	}
+	if settings.MTUProbe {
+		lines = setDirective(lines, "mtu-test")
+	}
+	if settings.FastIO {
+		lines = setDirective(lines, "fast-io")
+	}
+	if settings.SndBuf != nil {
+		lines = setDirective(lines, "sndbuf "+strconv.FormatUint(*settings.SndBuf, 10))
+	}
+	if settings.RcvBuf != nil {
+		lines = setDirective(lines, "rcvbuf "+strconv.FormatUint(*settings.RcvBuf, 10))
+	}
+	for _, filter := range settings.PullFilters {
+		lines = insertLines(lines, "pull-filter "+filter.String())
+	}
+	if settings.IgnoreDNSPush {
+		// after the user pull filters since the first matching filter applies
+		filter := models.PullFilter{Action: "ignore", Text: "dhcp-option DNS"}
+		lines = insertLines(lines, "pull-filter "+filter.String())
+	}
+	switch settings.IPFamily {
+	case constants.IPv4Family:
+		if !settings.Provider.ExtraConfigOptions.OpenVPNIPv6 {
+			lines = insertMissingLines(lines, `pull-filter ignore "route-ipv6"`, `pull-filter ignore "ifconfig-ipv6"`)
+		}
+	case constants.IPv6Family:
+		// route only IPv6 traffic through the tunnel
+		lines = insertMissingLines(lines, `pull-filter ignore "redirect-gateway"`)
+		lines = setDirective(lines, "redirect-gateway ipv6 !ipv4")
+	}
+	for _, route := range settings.ExtraRoutes {
+		lines = insertLines(lines, routeLine(route))
+	}
+	return lines
+}
+
+func preConnectProxyLine(proxy models.PreConnectProxy) (line string) {
+	switch proxy.Type {
+	case constants.SOCKSProxy:
+		line = "socks-proxy " + proxy.Host + " " + strconv.Itoa(int(proxy.Port))
+		if len(proxy.User) > 0 {
+			line += " " + string(constants.OpenVPNProxyAuthConf)
+		}
+	case constants.HTTPProxy:
+		line = "http-proxy " + proxy.Host + " " + strconv.Itoa(int(proxy.Port))
+		if len(proxy.User) > 0 {
+			line += " " + string(constants.OpenVPNProxyAuthConf) + " basic"
+		}
+	}
+	return line
+}
+
+func routeLine(subnet net.IPNet) string {
+	if subnet.IP.To4() == nil {
+		return "route-ipv6 " + subnet.String()
+	}
+	return "route " + subnet.IP.String() + " " + net.IP(subnet.Mask).String()
+}
+
+// setDirective replaces the first line having the same directive as the line
+// given, or inserts the line before the first inline block if no line uses
+// this directive.
+func setDirective(lines []string, line string) []string {
+	directive := directiveOf(line)
+	for i := range lines {
+		if directiveOf(lines[i]) == directive {
+			lines[i] = line
+			return lines
+		}
+	}
+	return insertLines(lines, line)
+}
+
+func hasDirective(lines []string, directive string) bool {
+	for _, line := range lines {
+		if directiveOf(line) == directive {
+			return true
+		}
+	}
+	return false
+}
+
+// removeDirective removes all the lines using the directive given.
+func removeDirective(lines []string, directive string) []string {
+	filtered := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if directiveOf(line) == directive {
+			continue
+		}
+		filtered = append(filtered, line)
+	}
+	return filtered
+}
+
+// insertLines inserts the lines given before the first inline block
+// such as <ca>, or at the end if there is no inline block.
+func insertLines(lines []string, newLines ...string) []string {
+	i := 0
+	for ; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], "<") {
+			break
+		}
+	}
+	result := make([]string, 0, len(lines)+len(newLines))
+	result = append(result, lines[:i]...)
+	result = append(result, newLines...)
+	result = append(result, lines[i:]...)
+	return result
+}
+
+// insertMissingLines inserts the lines given not already present.
+func insertMissingLines(lines []string, newLines ...string) []string {
+	for _, newLine := range newLines {
+		found := false
+		for _, line := range lines {
+			if line == newLine {
+				found = true
+				break
+			}
+		}
+		if !found {
+			lines = insertLines(lines, newLine)
+		}
+	}
+	return lines
+}
+
+func directiveOf(line string) (directive string) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
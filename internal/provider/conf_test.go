@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"net"
+	"testing"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/qdm12/gluetun/internal/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_setDirective(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		lines    []string
+		line     string
+		expected []string
+	}{
+		"empty lines": {
+			line:     "a 1",
+			expected: []string{"a 1"},
+		},
+		"replace existing directive": {
+			lines:    []string{"a 1", "b 2", "<ca>", "</ca>"},
+			line:     "b 3",
+			expected: []string{"a 1", "b 3", "<ca>", "</ca>"},
+		},
+		"insert before inline block": {
+			lines:    []string{"a 1", "<ca>", "</ca>"},
+			line:     "c",
+			expected: []string{"a 1", "c", "<ca>", "</ca>"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			lines := setDirective(testCase.lines, testCase.line)
+			assert.Equal(t, testCase.expected, lines)
+		})
+	}
+}
+
+func Test_removeDirective(t *testing.T) {
+	t.Parallel()
+	lines := []string{"a 1", "b", "a 2", "<ca>", "</ca>"}
+	lines = removeDirective(lines, "a")
+	assert.Equal(t, []string{"b", "<ca>", "</ca>"}, lines)
+}
+
+func Test_customizeConf(t *testing.T) {
+	t.Parallel()
+	renegSec, connectRetry, connectRetryMax, routeDelay, tunMTUExtra := 3600, 2, 3, 5, 64
+	var zeroBuf, sndBuf, rcvBuf uint64 = 0, 524288, 1048576
+	var zeroMSSFix, mssFix uint16 = 0, 1400
+	testCases := map[string]struct {
+		lines    []string
+		settings settings.OpenVPN
+		expected []string
+	}{
+		"no customization": {
+			lines:    []string{"client", "comp-lzo", "<ca>", "</ca>"},
+			expected: []string{"client", "comp-lzo", "<ca>", "</ca>"},
+		},
+		"TUN device": {
+			lines:    []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{TUNDevice: "/dev/tun"},
+			expected: []string{"client", "dev-node /dev/tun", "<ca>", "</ca>"},
+		},
+		"no compression": {
+			lines:    []string{"client", "comp-lzo", "compress lz4", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{Compression: constants.NoCompression},
+			expected: []string{"client", "comp-lzo no", "compress", "<ca>", "</ca>"},
+		},
+		"no compression without framing": {
+			lines:    []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{Compression: constants.NoCompression},
+			expected: []string{"client", "<ca>", "</ca>"},
+		},
+		"no compression keeps comp-lzo no": {
+			lines:    []string{"client", "comp-lzo no", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{Compression: constants.NoCompression},
+			expected: []string{"client", "comp-lzo no", "<ca>", "</ca>"},
+		},
+		"extra routes": {
+			lines: []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{ExtraRoutes: []net.IPNet{
+				{IP: net.IP{10, 0, 0, 0}, Mask: net.IPMask{255, 255, 0, 0}},
+				{IP: net.ParseIP("fd00::"), Mask: net.CIDRMask(64, 128)},
+			}},
+			expected: []string{"client", "route 10.0.0.0 255.255.0.0", "route-ipv6 fd00::/64", "<ca>", "</ca>"},
+		},
+		"IPv4 family": {
+			lines:    []string{"client", `pull-filter ignore "ifconfig-ipv6"`, "<ca>", "</ca>"},
+			settings: settings.OpenVPN{IPFamily: constants.IPv4Family},
+			expected: []string{"client", `pull-filter ignore "ifconfig-ipv6"`,
+				`pull-filter ignore "route-ipv6"`, "<ca>", "</ca>"},
+		},
+		"IPv6 family": {
+			lines:    []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{IPFamily: constants.IPv6Family},
+			expected: []string{"client", `pull-filter ignore "redirect-gateway"`,
+				"redirect-gateway ipv6 !ipv4", "<ca>", "</ca>"},
+		},
+		"auth nocache": {
+			lines:    []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{AuthNoCache: true},
+			expected: []string{"client", "auth-nocache", "<ca>", "</ca>"},
+		},
+		"auth cache": {
+			lines:    []string{"client", "auth-nocache", "<ca>", "</ca>"},
+			expected: []string{"client", "<ca>", "</ca>"},
+		},
+		"auth retry": {
+			lines:    []string{"client", "auth-retry none", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{AuthRetry: constants.AuthRetryNoInteract},
+			expected: []string{"client", "auth-retry nointeract", "<ca>", "</ca>"},
+		},
+		"fast io and buffers": {
+			lines:    []string{"client", "sndbuf 0", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{FastIO: true, SndBuf: &sndBuf, RcvBuf: &rcvBuf},
+			expected: []string{"client", "sndbuf 524288", "fast-io", "rcvbuf 1048576", "<ca>", "</ca>"},
+		},
+		"operating system default buffers": {
+			lines:    []string{"client", "sndbuf 393216", "rcvbuf 393216", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{SndBuf: &zeroBuf, RcvBuf: &zeroBuf},
+			expected: []string{"client", "sndbuf 0", "rcvbuf 0", "<ca>", "</ca>"},
+		},
+		"provider buffers kept": {
+			lines:    []string{"client", "sndbuf 393216", "rcvbuf 393216", "<ca>", "</ca>"},
+			expected: []string{"client", "sndbuf 393216", "rcvbuf 393216", "<ca>", "</ca>"},
+		},
+		"MTU probe": {
+			lines:    []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{MTUProbe: true},
+			expected: []string{"client", "mtu-test", "<ca>", "</ca>"},
+		},
+		"socks pre-connect proxy": {
+			lines: []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{PreConnectProxy: models.PreConnectProxy{
+				Type: constants.SOCKSProxy, Host: "10.0.0.1", Port: 1080,
+			}},
+			expected: []string{"client", "socks-proxy 10.0.0.1 1080", "<ca>", "</ca>"},
+		},
+		"http pre-connect proxy with credentials": {
+			lines: []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{PreConnectProxy: models.PreConnectProxy{
+				Type: constants.HTTPProxy, Host: "proxy", Port: 3128, User: "u", Password: "p",
+			}},
+			expected: []string{"client", "http-proxy proxy 3128 /etc/openvpn/proxy-auth.conf basic", "<ca>", "</ca>"},
+		},
+		"pull filters": {
+			lines: []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{PullFilters: []models.PullFilter{
+				{Action: "ignore", Text: "redirect-gateway"},
+				{Action: "accept", Text: "route 10.0.0.0"},
+			}},
+			expected: []string{"client", `pull-filter ignore "redirect-gateway"`,
+				`pull-filter accept "route 10.0.0.0"`, "<ca>", "</ca>"},
+		},
+		"ignore DNS push after pull filters": {
+			lines: []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{
+				PullFilters:   []models.PullFilter{{Action: "accept", Text: "dhcp-option DNS 10.0.0.1"}},
+				IgnoreDNSPush: true,
+			},
+			expected: []string{"client", `pull-filter accept "dhcp-option DNS 10.0.0.1"`,
+				`pull-filter ignore "dhcp-option DNS"`, "<ca>", "</ca>"},
+		},
+		"explicit exit notify": {
+			lines:    []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{ExplicitExitNotify: true},
+			expected: []string{"client", "explicit-exit-notify", "<ca>", "</ca>"},
+		},
+		"explicit exit notify kept from provider": {
+			lines:    []string{"client", "explicit-exit-notify 2", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{ExplicitExitNotify: true},
+			expected: []string{"client", "explicit-exit-notify 2", "<ca>", "</ca>"},
+		},
+		"explicit exit notify removed": {
+			lines:    []string{"client", "explicit-exit-notify 2", "<ca>", "</ca>"},
+			expected: []string{"client", "<ca>", "</ca>"},
+		},
+		"minimum TLS version": {
+			lines:    []string{"client", "tls-version-min 1.0", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{TLSVersionMin: "1.3"},
+			expected: []string{"client", "tls-version-min 1.3", "<ca>", "</ca>"},
+		},
+		"resolv retry": {
+			lines:    []string{"client", "resolv-retry 5", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{ResolvRetry: "infinite"},
+			expected: []string{"client", "resolv-retry infinite", "<ca>", "</ca>"},
+		},
+		"persist tun and key": {
+			lines:    []string{"client", "persist-key", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{PersistTun: true, PersistKey: true},
+			expected: []string{"client", "persist-key", "persist-tun", "<ca>", "</ca>"},
+		},
+		"no persist key": {
+			lines:    []string{"client", "persist-key", "persist-tun", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{PersistTun: true},
+			expected: []string{"client", "persist-tun", "<ca>", "</ca>"},
+		},
+		"reneg sec": {
+			lines:    []string{"client", "reneg-sec 0", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{RenegSec: &renegSec},
+			expected: []string{"client", "reneg-sec 3600", "<ca>", "</ca>"},
+		},
+		"connect retry": {
+			lines:    []string{"client", "connect-retry 5", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{ConnectRetry: &connectRetry, ConnectRetryMax: &connectRetryMax},
+			expected: []string{"client", "connect-retry 2", "connect-retry-max 3", "<ca>", "</ca>"},
+		},
+		"route delay": {
+			lines:    []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{RouteDelay: &routeDelay},
+			expected: []string{"client", "route-delay 5", "<ca>", "</ca>"},
+		},
+		"tun mtu extra": {
+			lines:    []string{"client", "tun-mtu 1500", "tun-mtu-extra 32", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{TunMTUExtra: &tunMTUExtra},
+			expected: []string{"client", "tun-mtu 1500", "tun-mtu-extra 64", "<ca>", "</ca>"},
+		},
+		"mssfix": {
+			lines:    []string{"client", "mssfix 1450", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{MSSFix: &mssFix},
+			expected: []string{"client", "mssfix 1400", "<ca>", "</ca>"},
+		},
+		"mssfix disabled": {
+			lines:    []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{MSSFix: &zeroMSSFix},
+			expected: []string{"client", "mssfix 0", "<ca>", "</ca>"},
+		},
+		"scramble": {
+			lines:    []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{Scramble: "xormask a"},
+			expected: []string{"client", "scramble xormask a", "<ca>", "</ca>"},
+		},
+		"keepalive": {
+			lines:    []string{"client", "ping 10", "ping-exit 60", "ping-timer-rem", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{Keepalive: "15 120"},
+			expected: []string{"client", "ping-timer-rem", "keepalive 15 120", "<ca>", "</ca>"},
+		},
+		"route no pull": {
+			lines:    []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{RouteNoPull: true},
+			expected: []string{"client", "route-nopull", "<ca>", "</ca>"},
+		},
+		"management interface": {
+			lines:    []string{"client", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{Management: true},
+			expected: []string{"client", "management /etc/openvpn/management.sock unix", "<ca>", "</ca>"},
+		},
+		"lz4 compression": {
+			lines:    []string{"client", "comp-lzo", "<ca>", "</ca>"},
+			settings: settings.OpenVPN{Compression: constants.LZ4},
+			expected: []string{"client", "compress lz4", "<ca>", "</ca>"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			lines := customizeConf(testCase.lines, testCase.settings)
+			assert.Equal(t, testCase.expected, lines)
+		})
+	}
+}
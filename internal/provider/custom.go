@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/firewall"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/qdm12/gluetun/internal/settings"
+	"github.com/qdm12/golibs/logging"
+	"github.com/qdm12/golibs/os"
+)
+
+var ErrNoCustomRemote = errors.New("no custom OpenVPN remote specified")
+
+// custom is a provider connecting to the OpenVPN remotes given by the user,
+// without using the servers data. The certificate authority and any other
+// provider specific options should be added with a configuration template.
+type custom struct {
+	rotation
+	randSource rand.Source
+}
+
+func newCustom(timeNow timeNowFunc) *custom {
+	return &custom{
+		randSource: rand.NewSource(timeNow().UnixNano()),
+	}
+}
+
+func (c *custom) GetOpenVPNConnection(selection models.ServerSelection) (
+	connection models.OpenVPNConnection, err error) {
+	remotes := selection.CustomRemotes
+	if len(remotes) == 0 {
+		return connection, ErrNoCustomRemote
+	}
+
+	if selection.TargetIP != nil {
+		for _, remote := range remotes {
+			if remote.IP.Equal(selection.TargetIP) {
+				return remote, nil
+			}
+		}
+		return models.OpenVPNConnection{IP: selection.TargetIP, Port: remotes[0].Port, Protocol: remotes[0].Protocol}, nil
+	}
+
+	return pickConnection(remotes, selection, c.selectionSource(selection, c.randSource), c.failedAttempts), nil
+}
+
+// ExplainSelection returns the number of custom remotes, since
+// they are not filtered.
+func (c *custom) ExplainSelection(selection models.ServerSelection) (stages []SelectionStage) {
+	applyFilters(len(selection.CustomRemotes), nil,
+		func(stage SelectionStage) { stages = append(stages, stage) })
+	return stages
+}
+
+func (c *custom) BuildConf(connection models.OpenVPNConnection,
+	username string, settings settings.OpenVPN) (lines []string) {
+	lines = []string{
+		"client",
+		"dev tun",
+		"nobind",
+		"persist-key",
+		"remote-cert-tls server",
+		"ping 10",
+		"ping-exit 60",
+		"ping-timer-rem",
+		"tls-exit",
+
+		// Added constant values
+		"auth-nocache",
+		"mute-replay-warnings",
+		"pull-filter ignore \"auth-token\"", // prevent auth failed loops
+		"auth-retry nointeract",
+		"suppress-timestamps",
+
+		// Modified variables
+		fmt.Sprintf("verb %d", settings.Verbosity),
+		fmt.Sprintf("auth-user-pass %s", constants.OpenVPNAuthConf),
+		fmt.Sprintf("proto %s", connection.Protocol),
+	}
+	// OpenVPN tries the remotes in order, starting with the connection picked
+	lines = append(lines, customRemoteLine(connection))
+	for _, remote := range settings.Provider.ServerSelection.CustomRemotes {
+		if !remote.Equal(connection) {
+			lines = append(lines, customRemoteLine(remote))
+		}
+	}
+	if len(settings.Cipher) > 0 {
+		lines = append(lines, "cipher "+settings.Cipher)
+	}
+	if len(settings.Auth) > 0 {
+		lines = append(lines, "auth "+settings.Auth)
+	}
+	if !settings.Root {
+		lines = append(lines, "user "+username)
+	}
+	return customizeConf(lines, settings)
+}
+
+func customRemoteLine(remote models.OpenVPNConnection) string {
+	return fmt.Sprintf("remote %s %d", remote.IP, remote.Port)
+}
+
+func (c *custom) PortForward(ctx context.Context, client *http.Client,
+	openFile os.OpenFileFunc, pfLogger logging.Logger, gateway net.IP, fw firewall.Configurator,
+	syncState func(port uint16) (pfFilepath models.Filepath)) {
+	panic("port forwarding is not supported for the custom provider")
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"math/rand"
+	"net"
+	"testing"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_custom_GetOpenVPNConnection(t *testing.T) {
+	t.Parallel()
+	remotes := []models.OpenVPNConnection{
+		{IP: net.IPv4(1, 1, 1, 1), Port: 1194, Protocol: constants.UDP},
+		{IP: net.IPv4(2, 2, 2, 2), Port: 1195, Protocol: constants.UDP},
+	}
+	testCases := map[string]struct {
+		selection  models.ServerSelection
+		connection models.OpenVPNConnection
+		err        error
+	}{
+		"no remote": {
+			err: ErrNoCustomRemote,
+		},
+		"target IP of a remote": {
+			selection: models.ServerSelection{
+				CustomRemotes: remotes,
+				TargetIP:      net.IPv4(2, 2, 2, 2),
+			},
+			connection: remotes[1],
+		},
+		"target IP not in remotes": {
+			selection: models.ServerSelection{
+				CustomRemotes: remotes,
+				TargetIP:      net.IPv4(3, 3, 3, 3),
+			},
+			connection: models.OpenVPNConnection{
+				IP: net.IPv4(3, 3, 3, 3), Port: 1194, Protocol: constants.UDP,
+			},
+		},
+		"sequential order": {
+			selection: models.ServerSelection{
+				CustomRemotes: remotes,
+				ConnectOrder:  constants.SequentialOrder,
+			},
+			connection: remotes[0],
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			c := &custom{randSource: rand.NewSource(0)}
+			connection, err := c.GetOpenVPNConnection(testCase.selection)
+			if testCase.err != nil {
+				require.Error(t, err)
+				assert.Equal(t, testCase.err.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.connection, connection)
+		})
+	}
+}
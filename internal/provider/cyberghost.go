@@ -6,7 +6,6 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
-	"strconv"
 	"strings"
 
 	"github.com/qdm12/gluetun/internal/constants"
@@ -18,6 +17,7 @@ import (
 )
 
 type cyberghost struct {
+	rotation
 	servers    []models.CyberghostServer
 	randSource rand.Source
 }
@@ -29,18 +29,63 @@ func newCyberghost(servers []models.CyberghostServer, timeNow timeNowFunc) *cybe
 	}
 }
 
-func (c *cyberghost) filterServers(regions []string, group string) (servers []models.CyberghostServer) {
-	for _, server := range c.servers {
-		switch {
-		case len(group) > 0 && !strings.EqualFold(group, server.Group),
-			filterByPossibilities(server.Region, regions):
-		default:
-			servers = append(servers, server)
-		}
+func (c *cyberghost) filterServers(regions, excludeRegions []string, group string, stage stageFunc) (
+	servers []models.CyberghostServer) {
+	region := func(i int) string { return c.servers[i].Region }
+	filters := []serverFilter{
+		{name: "group", values: []string{group}, enabled: len(group) > 0,
+			filtered: func(i int) bool { return !strings.EqualFold(group, c.servers[i].Group) }},
+		possibilitiesFilter("regions", regions, region),
+		exclusionsFilter("excluded regions", excludeRegions, region),
+	}
+	for _, i := range applyFilters(len(c.servers), filters, stage) {
+		servers = append(servers, c.servers[i])
 	}
 	return servers
 }
 
+// ExplainSelection returns the number of servers remaining after each
+// server filter of the selection given.
+func (c *cyberghost) ExplainSelection(selection models.ServerSelection) (stages []SelectionStage) {
+	c.filterServers(selection.Regions, selection.ExcludeRegions, selection.Group,
+		func(stage SelectionStage) { stages = append(stages, stage) })
+	return stages
+}
+
+// serversOfRotatedRegion returns the servers of the region to try for the
+// attempt given, going through the regions in order and cycling back to
+// the first one. It only moves to the next region once as many attempts
+// as there are connections in the current region have failed, and returns
+// the attempt number within the region. Regions without servers are skipped.
+func serversOfRotatedRegion(servers []models.CyberghostServer, regions []string,
+	attempt int) (regionServers []models.CyberghostServer, regionAttempt int) {
+	serversByRegion := make([][]models.CyberghostServer, len(regions))
+	totalConnections := 0
+	for i, region := range regions {
+		for _, server := range servers {
+			if strings.EqualFold(server.Region, region) {
+				serversByRegion[i] = append(serversByRegion[i], server)
+				totalConnections += len(server.IPs)
+			}
+		}
+	}
+	if totalConnections == 0 {
+		return servers, attempt
+	}
+	regionAttempt = attempt % totalConnections
+	for _, regionServers := range serversByRegion {
+		connections := 0
+		for _, server := range regionServers {
+			connections += len(server.IPs)
+		}
+		if regionAttempt < connections {
+			return regionServers, regionAttempt
+		}
+		regionAttempt -= connections
+	}
+	return servers, attempt // never reached
+}
+
 func (c *cyberghost) GetOpenVPNConnection(selection models.ServerSelection) (
 	connection models.OpenVPNConnection, err error) {
 	const httpsPort = 443
@@ -48,11 +93,15 @@ func (c *cyberghost) GetOpenVPNConnection(selection models.ServerSelection) (
 		return models.OpenVPNConnection{IP: selection.TargetIP, Port: httpsPort, Protocol: selection.Protocol}, nil
 	}
 
-	servers := c.filterServers(selection.Regions, selection.Group)
+	servers := c.filterServers(selection.Regions, selection.ExcludeRegions, selection.Group, nil)
 	if len(servers) == 0 {
 		return connection,
 			fmt.Errorf("no server found for regions %s and group %q", commaJoin(selection.Regions), selection.Group)
 	}
+	attempt := c.failedAttempts
+	if len(selection.Regions) > 1 {
+		servers, attempt = serversOfRotatedRegion(servers, selection.Regions, attempt)
+	}
 
 	var connections []models.OpenVPNConnection
 	for _, server := range servers {
@@ -61,17 +110,17 @@ func (c *cyberghost) GetOpenVPNConnection(selection models.ServerSelection) (
 		}
 	}
 
-	return pickRandomConnection(connections, c.randSource), nil
+	return pickConnection(connections, selection, c.selectionSource(selection, c.randSource), attempt), nil
+}
+
+// DefaultCipherAuth returns the default cipher and auth algorithms.
+func (c *cyberghost) DefaultCipherAuth(settings.OpenVPN) (cipher, auth string) {
+	return aes256cbc, sha256
 }
 
 func (c *cyberghost) BuildConf(connection models.OpenVPNConnection,
 	username string, settings settings.OpenVPN) (lines []string) {
-	if len(settings.Cipher) == 0 {
-		settings.Cipher = aes256cbc
-	}
-	if len(settings.Auth) == 0 {
-		settings.Auth = sha256
-	}
+	setCipherAuthDefaults(&settings, c)
 	lines = []string{
 		"client",
 		"dev tun",
@@ -112,9 +161,6 @@ func (c *cyberghost) BuildConf(connection models.OpenVPNConnection,
 	if !settings.Root {
 		lines = append(lines, "user "+username)
 	}
-	if settings.MSSFix > 0 {
-		lines = append(lines, "mssfix "+strconv.Itoa(int(settings.MSSFix)))
-	}
 	lines = append(lines, []string{
 		"<ca>",
 		"-----BEGIN CERTIFICATE-----",
@@ -137,7 +183,7 @@ func (c *cyberghost) BuildConf(connection models.OpenVPNConnection,
 		"</key>",
 		"",
 	}...)
-	return lines
+	return customizeConf(lines, settings)
 }
 
 func (c *cyberghost) PortForward(ctx context.Context, client *http.Client,
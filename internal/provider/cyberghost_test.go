@@ -1,10 +1,13 @@
 package provider
 
 import (
+	"net"
 	"testing"
 
+	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_cyberghost_filterServers(t *testing.T) {
@@ -75,8 +78,79 @@ func Test_cyberghost_filterServers(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 			c := &cyberghost{servers: testCase.servers}
-			filteredServers := c.filterServers(testCase.regions, testCase.group)
+			filteredServers := c.filterServers(testCase.regions, nil, testCase.group, nil)
 			assert.Equal(t, testCase.filteredServers, filteredServers)
 		})
 	}
 }
+
+func Test_serversOfRotatedRegion(t *testing.T) {
+	t.Parallel()
+	servers := []models.CyberghostServer{
+		{Region: "a", Group: "1", IPs: []net.IP{{1, 1, 1, 1}, {1, 1, 1, 2}}},
+		{Region: "b", Group: "1", IPs: []net.IP{{2, 2, 2, 1}}},
+		{Region: "b", Group: "2", IPs: []net.IP{{2, 2, 2, 2}}},
+	}
+	testCases := map[string]struct {
+		regions       []string
+		attempt       int
+		regionServers []models.CyberghostServer
+		regionAttempt int
+	}{
+		"first attempt": {
+			regions:       []string{"a", "b"},
+			regionServers: servers[:1],
+		},
+		"second connection of first region": {
+			regions:       []string{"a", "b"},
+			attempt:       1,
+			regionServers: servers[:1],
+			regionAttempt: 1,
+		},
+		"first region exhausted": {
+			regions:       []string{"a", "b"},
+			attempt:       2,
+			regionServers: servers[1:],
+		},
+		"wraps around": {
+			regions:       []string{"a", "b"},
+			attempt:       4,
+			regionServers: servers[:1],
+		},
+		"skips region without servers": {
+			regions:       []string{"c", "b"},
+			attempt:       1,
+			regionServers: servers[1:],
+			regionAttempt: 1,
+		},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			regionServers, regionAttempt := serversOfRotatedRegion(servers, testCase.regions, testCase.attempt)
+			assert.Equal(t, testCase.regionServers, regionServers)
+			assert.Equal(t, testCase.regionAttempt, regionAttempt)
+		})
+	}
+}
+
+func Test_cyberghost_GetOpenVPNConnection_regionRotation(t *testing.T) {
+	t.Parallel()
+	c := &cyberghost{servers: []models.CyberghostServer{
+		{Region: "a", IPs: []net.IP{{1, 1, 1, 1}}},
+		{Region: "a", IPs: []net.IP{{1, 1, 1, 2}}},
+		{Region: "b", IPs: []net.IP{{2, 2, 2, 1}}},
+	}}
+	selection := models.ServerSelection{
+		Regions:      []string{"a", "b"},
+		ConnectOrder: constants.SequentialOrder,
+	}
+	expectedIPs := []net.IP{{1, 1, 1, 1}, {1, 1, 1, 2}, {2, 2, 2, 1}, {1, 1, 1, 1}}
+	for attempt, expectedIP := range expectedIPs {
+		c.SetFailedAttempts(attempt)
+		connection, err := c.GetOpenVPNConnection(selection)
+		require.NoError(t, err)
+		assert.Equal(t, expectedIP, connection.IP, "attempt %d", attempt)
+	}
+}
@@ -0,0 +1,23 @@
+package provider
+
+import "github.com/qdm12/gluetun/internal/settings"
+
+// CipherAuthDefaulter is implemented by providers using a default cipher
+// or auth algorithm if none is set in the OpenVPN settings.
+type CipherAuthDefaulter interface {
+	// DefaultCipherAuth returns the default cipher and auth algorithms,
+	// which are empty if the provider has no default for them.
+	DefaultCipherAuth(settings settings.OpenVPN) (cipher, auth string)
+}
+
+// setCipherAuthDefaults sets the cipher and auth algorithms of the settings
+// to the defaults of the provider given if they are not set.
+func setCipherAuthDefaults(settings *settings.OpenVPN, defaulter CipherAuthDefaulter) {
+	cipher, auth := defaulter.DefaultCipherAuth(*settings)
+	if len(settings.Cipher) == 0 {
+		settings.Cipher = cipher
+	}
+	if len(settings.Auth) == 0 {
+		settings.Auth = auth
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/qdm12/gluetun/internal/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_setCipherAuthDefaults(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		defaulter CipherAuthDefaulter
+		settings  settings.OpenVPN
+		cipher    string
+		auth      string
+	}{
+		"provider defaults": {
+			defaulter: &cyberghost{},
+			cipher:    aes256cbc,
+			auth:      sha256,
+		},
+		"settings kept": {
+			defaulter: &cyberghost{},
+			settings:  settings.OpenVPN{Cipher: "aes-128-gcm", Auth: "sha1"},
+			cipher:    "aes-128-gcm",
+			auth:      "sha1",
+		},
+		"no default auth": {
+			defaulter: &mullvad{},
+			cipher:    aes256cbc,
+		},
+		"defaults of the encryption preset": {
+			defaulter: &pia{},
+			settings: settings.OpenVPN{Provider: models.ProviderSettings{
+				ExtraConfigOptions: models.ExtraConfigOptions{
+					EncryptionPreset: constants.PIAEncryptionPresetNormal,
+				},
+			}},
+			cipher: "aes-128-cbc",
+			auth:   "sha1",
+		},
+	}
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			settings := testCase.settings
+			setCipherAuthDefaults(&settings, testCase.defaulter)
+			assert.Equal(t, testCase.cipher, settings.Cipher)
+			assert.Equal(t, testCase.auth, settings.Auth)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/qdm12/gluetun/internal/models"
+)
+
+// Explainer is implemented by providers able to explain their server
+// selection, stage by stage.
+type Explainer interface {
+	ExplainSelection(selection models.ServerSelection) (stages []SelectionStage)
+}
+
+// SelectionStage is a stage of the server filters pipeline, with the
+// number of candidate servers remaining once its filter is applied.
+type SelectionStage struct {
+	Filter     string
+	Values     []string
+	Candidates int
+}
+
+func (s SelectionStage) String() string {
+	if len(s.Values) == 0 {
+		return fmt.Sprintf("%s: %d candidates", s.Filter, s.Candidates)
+	}
+	return fmt.Sprintf("%s %s: %d candidates", s.Filter, strings.Join(s.Values, ", "), s.Candidates)
+}
+
+// stageFunc is called by the server filters of a provider with each
+// stage of the filtering, and is nil when only selecting servers.
+type stageFunc func(stage SelectionStage)
+
+// serverFilter is a server filter of a provider, which is only applied
+// if enabled. Filtered returns true if the server at the index given
+// should be filtered out.
+type serverFilter struct {
+	name     string
+	values   []string
+	enabled  bool
+	filtered func(index int) bool
+}
+
+func possibilitiesFilter(name string, possibilities []string, value func(index int) string) serverFilter {
+	return serverFilter{
+		name:    name,
+		values:  possibilities,
+		enabled: len(possibilities) > 0,
+		filtered: func(index int) bool {
+			return filterByPossibilities(value(index), possibilities)
+		},
+	}
+}
+
+func exclusionsFilter(name string, exclusions []string, value func(index int) string) serverFilter {
+	return serverFilter{
+		name:    name,
+		values:  exclusions,
+		enabled: len(exclusions) > 0,
+		filtered: func(index int) bool {
+			return filterByExclusions(value(index), exclusions)
+		},
+	}
+}
+
+// applyFilters returns the indexes of the servers out of count servers not
+// filtered out by the enabled filters, applied one after the other. If the
+// stage function is not nil, it is called with the total number of servers
+// and with the number of servers remaining after each enabled filter.
+func applyFilters(count int, filters []serverFilter, stage stageFunc) (indexes []int) {
+	indexes = make([]int, count)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	if stage != nil {
+		stage(SelectionStage{Filter: "all servers", Candidates: count})
+	}
+	for _, filter := range filters {
+		if !filter.enabled {
+			continue
+		}
+		remaining := indexes[:0]
+		for _, index := range indexes {
+			if !filter.filtered(index) {
+				remaining = append(remaining, index)
+			}
+		}
+		indexes = remaining
+		if stage != nil {
+			stage(SelectionStage{Filter: filter.name, Values: filter.values, Candidates: len(indexes)})
+		}
+	}
+	return indexes
+}
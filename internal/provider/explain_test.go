@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExplainSelection(t *testing.T) {
+	t.Parallel()
+	allServers := models.AllServers{
+		Nordvpn: models.NordvpnServers{Servers: []models.NordvpnServer{
+			{Region: "Canada", Number: 1, TCP: true, UDP: true},
+			{Region: "Canada", Number: 2, UDP: true},
+			{Region: "France", Number: 3, TCP: true},
+		}},
+		Cyberghost: models.CyberghostServers{Servers: []models.CyberghostServer{
+			{Region: "Canada", Group: "Premium UDP Europe"},
+			{Region: "Canada", Group: "Premium TCP Europe"},
+		}},
+		Surfshark: models.SurfsharkServers{Servers: []models.SurfsharkServer{
+			{Region: "Canada"},
+		}},
+	}
+	testCases := map[string]struct {
+		provider  models.VPNProvider
+		selection models.ServerSelection
+		stages    []SelectionStage
+	}{
+		"protocol only": {
+			provider:  constants.Nordvpn,
+			selection: models.ServerSelection{Protocol: constants.UDP},
+			stages: []SelectionStage{
+				{Filter: "all servers", Candidates: 3},
+				{Filter: "protocol", Values: []string{"udp"}, Candidates: 2},
+			},
+		},
+		"regions and numbers": {
+			provider: constants.Nordvpn,
+			selection: models.ServerSelection{Protocol: constants.TCP,
+				Regions: []string{"canada"}, Numbers: []uint16{2}},
+			stages: []SelectionStage{
+				{Filter: "all servers", Candidates: 3},
+				{Filter: "protocol", Values: []string{"tcp"}, Candidates: 2},
+				{Filter: "regions", Values: []string{"canada"}, Candidates: 1},
+				{Filter: "numbers", Values: []string{"2"}, Candidates: 0},
+			},
+		},
+		"group": {
+			provider: constants.Cyberghost,
+			selection: models.ServerSelection{Protocol: constants.UDP,
+				ExcludeRegions: []string{"France"}, Group: "Premium UDP Europe"},
+			stages: []SelectionStage{
+				{Filter: "all servers", Candidates: 2},
+				{Filter: "group", Values: []string{"Premium UDP Europe"}, Candidates: 1},
+				{Filter: "excluded regions", Values: []string{"France"}, Candidates: 1},
+			},
+		},
+		"filters not supported by the provider": {
+			provider: constants.Surfshark,
+			selection: models.ServerSelection{Protocol: constants.UDP,
+				Cities: []string{"Toronto"}, Hostnames: []string{"ca1"}},
+			stages: []SelectionStage{
+				{Filter: "all servers", Candidates: 1},
+			},
+		},
+		"custom remotes": {
+			provider: constants.Custom,
+			selection: models.ServerSelection{CustomRemotes: []models.OpenVPNConnection{
+				{IP: net.IP{1, 2, 3, 4}, Port: 1194, Protocol: constants.UDP},
+				{IP: net.IP{5, 6, 7, 8}, Port: 1194, Protocol: constants.UDP},
+			}},
+			stages: []SelectionStage{
+				{Filter: "all servers", Candidates: 2},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			explainer, ok := New(testCase.provider, allServers, time.Now).(Explainer)
+			require.True(t, ok)
+			stages := explainer.ExplainSelection(testCase.selection)
+			assert.Equal(t, testCase.stages, stages)
+		})
+	}
+}
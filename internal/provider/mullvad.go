@@ -6,7 +6,6 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
-	"strconv"
 
 	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/firewall"
@@ -17,6 +16,7 @@ import (
 )
 
 type mullvad struct {
+	rotation
 	servers    []models.MullvadServer
 	randSource rand.Source
 }
@@ -28,21 +28,29 @@ func newMullvad(servers []models.MullvadServer, timeNow timeNowFunc) *mullvad {
 	}
 }
 
-func (m *mullvad) filterServers(countries, cities, isps []string, owned bool) (servers []models.MullvadServer) {
-	for _, server := range m.servers {
-		switch {
-		case
-			filterByPossibilities(server.Country, countries),
-			filterByPossibilities(server.City, cities),
-			filterByPossibilities(server.ISP, isps),
-			owned && !server.Owned:
-		default:
-			servers = append(servers, server)
-		}
+func (m *mullvad) filterServers(countries, cities, isps []string, owned bool, stage stageFunc) (
+	servers []models.MullvadServer) {
+	filters := []serverFilter{
+		possibilitiesFilter("countries", countries, func(i int) string { return m.servers[i].Country }),
+		possibilitiesFilter("cities", cities, func(i int) string { return m.servers[i].City }),
+		possibilitiesFilter("ISPs", isps, func(i int) string { return m.servers[i].ISP }),
+		{name: "owned servers only", enabled: owned,
+			filtered: func(i int) bool { return !m.servers[i].Owned }},
+	}
+	for _, i := range applyFilters(len(m.servers), filters, stage) {
+		servers = append(servers, m.servers[i])
 	}
 	return servers
 }
 
+// ExplainSelection returns the number of servers remaining after each
+// server filter of the selection given.
+func (m *mullvad) ExplainSelection(selection models.ServerSelection) (stages []SelectionStage) {
+	m.filterServers(selection.Countries, selection.Cities, selection.ISPs, selection.Owned,
+		func(stage SelectionStage) { stages = append(stages, stage) })
+	return stages
+}
+
 func (m *mullvad) GetOpenVPNConnection(selection models.ServerSelection) (
 	connection models.OpenVPNConnection, err error) {
 	var defaultPort uint16 = 1194
@@ -58,7 +66,7 @@ func (m *mullvad) GetOpenVPNConnection(selection models.ServerSelection) (
 		return models.OpenVPNConnection{IP: selection.TargetIP, Port: port, Protocol: selection.Protocol}, nil
 	}
 
-	servers := m.filterServers(selection.Countries, selection.Cities, selection.ISPs, selection.Owned)
+	servers := m.filterServers(selection.Countries, selection.Cities, selection.ISPs, selection.Owned, nil)
 	if len(servers) == 0 {
 		return connection, fmt.Errorf("no server found for countries %s, cities %s, ISPs %s and owned %t",
 			commaJoin(selection.Countries), commaJoin(selection.Cities), commaJoin(selection.ISPs), selection.Owned)
@@ -71,14 +79,17 @@ func (m *mullvad) GetOpenVPNConnection(selection models.ServerSelection) (
 		}
 	}
 
-	return pickRandomConnection(connections, m.randSource), nil
+	return pickConnection(connections, selection, m.selectionSource(selection, m.randSource), m.failedAttempts), nil
+}
+
+// DefaultCipherAuth returns the default cipher, without default auth algorithm.
+func (m *mullvad) DefaultCipherAuth(settings.OpenVPN) (cipher, auth string) {
+	return aes256cbc, ""
 }
 
 func (m *mullvad) BuildConf(connection models.OpenVPNConnection,
 	username string, settings settings.OpenVPN) (lines []string) {
-	if len(settings.Cipher) == 0 {
-		settings.Cipher = aes256cbc
-	}
+	setCipherAuthDefaults(&settings, m)
 	lines = []string{
 		"client",
 		"dev tun",
@@ -120,9 +131,6 @@ func (m *mullvad) BuildConf(connection models.OpenVPNConnection,
 	if !settings.Root {
 		lines = append(lines, "user "+username)
 	}
-	if settings.MSSFix > 0 {
-		lines = append(lines, "mssfix "+strconv.Itoa(int(settings.MSSFix)))
-	}
 	lines = append(lines, []string{
 		"<ca>",
 		"-----BEGIN CERTIFICATE-----",
@@ -131,7 +139,7 @@ func (m *mullvad) BuildConf(connection models.OpenVPNConnection,
 		"</ca>",
 		"",
 	}...)
-	return lines
+	return customizeConf(lines, settings)
 }
 
 func (m *mullvad) PortForward(ctx context.Context, client *http.Client,
@@ -6,7 +6,6 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
-	"strconv"
 
 	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/firewall"
@@ -17,6 +16,7 @@ import (
 )
 
 type nordvpn struct {
+	rotation
 	servers    []models.NordvpnServer
 	randSource rand.Source
 }
@@ -28,27 +28,39 @@ func newNordvpn(servers []models.NordvpnServer, timeNow timeNowFunc) *nordvpn {
 	}
 }
 
-func (n *nordvpn) filterServers(regions []string, protocol models.NetworkProtocol, numbers []uint16) (
-	servers []models.NordvpnServer) {
+func (n *nordvpn) filterServers(regions, excludeRegions []string, protocol models.NetworkProtocol,
+	numbers []uint16, stage stageFunc) (servers []models.NordvpnServer) {
 	numbersStr := make([]string, len(numbers))
 	for i := range numbers {
 		numbersStr[i] = fmt.Sprintf("%d", numbers[i])
 	}
-	for _, server := range n.servers {
-		numberStr := fmt.Sprintf("%d", server.Number)
-		switch {
-		case
-			protocol == constants.TCP && !server.TCP,
-			protocol == constants.UDP && !server.UDP,
-			filterByPossibilities(server.Region, regions),
-			filterByPossibilities(numberStr, numbersStr):
-		default:
-			servers = append(servers, server)
-		}
+	region := func(i int) string { return n.servers[i].Region }
+	filters := []serverFilter{
+		{name: "protocol", values: []string{string(protocol)}, enabled: true,
+			filtered: func(i int) bool {
+				return (protocol == constants.TCP && !n.servers[i].TCP) ||
+					(protocol == constants.UDP && !n.servers[i].UDP)
+			}},
+		possibilitiesFilter("regions", regions, region),
+		exclusionsFilter("excluded regions", excludeRegions, region),
+		possibilitiesFilter("numbers", numbersStr, func(i int) string {
+			return fmt.Sprintf("%d", n.servers[i].Number)
+		}),
+	}
+	for _, i := range applyFilters(len(n.servers), filters, stage) {
+		servers = append(servers, n.servers[i])
 	}
 	return servers
 }
 
+// ExplainSelection returns the number of servers remaining after each
+// server filter of the selection given.
+func (n *nordvpn) ExplainSelection(selection models.ServerSelection) (stages []SelectionStage) {
+	n.filterServers(selection.Regions, selection.ExcludeRegions, selection.Protocol, selection.Numbers,
+		func(stage SelectionStage) { stages = append(stages, stage) })
+	return stages
+}
+
 func (n *nordvpn) GetOpenVPNConnection(selection models.ServerSelection) (
 	connection models.OpenVPNConnection, err error) {
 	var port uint16
@@ -65,7 +77,7 @@ func (n *nordvpn) GetOpenVPNConnection(selection models.ServerSelection) (
 		return models.OpenVPNConnection{IP: selection.TargetIP, Port: port, Protocol: selection.Protocol}, nil
 	}
 
-	servers := n.filterServers(selection.Regions, selection.Protocol, selection.Numbers)
+	servers := n.filterServers(selection.Regions, selection.ExcludeRegions, selection.Protocol, selection.Numbers, nil)
 	if len(servers) == 0 {
 		return connection, fmt.Errorf("no server found for region %s, protocol %s and numbers %v",
 			commaJoin(selection.Regions), selection.Protocol, selection.Numbers)
@@ -77,22 +89,18 @@ func (n *nordvpn) GetOpenVPNConnection(selection models.ServerSelection) (
 		connections = append(connections, connection)
 	}
 
-	return pickRandomConnection(connections, n.randSource), nil
+	return pickConnection(connections, selection, n.selectionSource(selection, n.randSource), n.failedAttempts), nil
+}
+
+// DefaultCipherAuth returns the default cipher and auth algorithms.
+func (n *nordvpn) DefaultCipherAuth(settings.OpenVPN) (cipher, auth string) {
+	return aes256cbc, "sha512"
 }
 
 func (n *nordvpn) BuildConf(connection models.OpenVPNConnection,
 	username string, settings settings.OpenVPN) (lines []string) {
-	if len(settings.Cipher) == 0 {
-		settings.Cipher = aes256cbc
-	}
-	if len(settings.Auth) == 0 {
-		settings.Auth = "sha512"
-	}
+	setCipherAuthDefaults(&settings, n)
 
-	const defaultMSSFix = 1450
-	if settings.MSSFix == 0 {
-		settings.MSSFix = defaultMSSFix
-	}
 	lines = []string{
 		"client",
 		"dev tun",
@@ -107,7 +115,7 @@ func (n *nordvpn) BuildConf(connection models.OpenVPNConnection,
 		// Nordvpn specific
 		"tun-mtu 1500",
 		"tun-mtu-extra 32",
-		"mssfix " + strconv.Itoa(int(settings.MSSFix)),
+		"mssfix 1450",
 		"reneg-sec 0",
 		"comp-lzo no",
 		"fast-io",
@@ -146,7 +154,7 @@ func (n *nordvpn) BuildConf(connection models.OpenVPNConnection,
 		"</tls-auth>",
 		"",
 	}...)
-	return lines
+	return customizeConf(lines, settings)
 }
 
 func (n *nordvpn) PortForward(ctx context.Context, client *http.Client,
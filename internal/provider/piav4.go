@@ -12,7 +12,6 @@ import (
 	"net"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 	"time"
 
@@ -26,11 +25,13 @@ import (
 )
 
 type pia struct {
+	rotation
 	servers        []models.PIAServer
 	timeNow        timeNowFunc
 	randSource     rand.Source
 	activeServer   models.PIAServer
 	activeProtocol models.NetworkProtocol
+	portForwarding models.PortForwarding
 }
 
 func newPrivateInternetAccess(servers []models.PIAServer, timeNow timeNowFunc) *pia {
@@ -41,6 +42,12 @@ func newPrivateInternetAccess(servers []models.PIAServer, timeNow timeNowFunc) *
 	}
 }
 
+// SetPortForwardSettings sets the port forwarding settings, such as
+// the period to renew the forwarded port at.
+func (p *pia) SetPortForwardSettings(settings models.PortForwarding) {
+	p.portForwarding = settings
+}
+
 func (p *pia) GetOpenVPNConnection(selection models.ServerSelection) (
 	connection models.OpenVPNConnection, err error) {
 	var port uint16
@@ -67,11 +74,17 @@ func (p *pia) GetOpenVPNConnection(selection models.ServerSelection) (
 	}
 
 	if selection.TargetIP != nil {
+		p.setActiveServer(p.servers, selection.TargetIP, selection.Protocol)
 		return models.OpenVPNConnection{IP: selection.TargetIP, Port: port, Protocol: selection.Protocol}, nil
 	}
 
-	servers := filterPIAServers(p.servers, selection.Regions)
+	servers := filterPIAServers(p.servers, selection.Regions, selection.ExcludeRegions,
+		selection.PortForwardOnly, nil)
 	if len(servers) == 0 {
+		if selection.PortForwardOnly {
+			return connection, fmt.Errorf("no server supporting port forwarding found for region %s",
+				commaJoin(selection.Regions))
+		}
 		return connection, fmt.Errorf("no server found for region %s", commaJoin(selection.Regions))
 	}
 
@@ -86,52 +99,49 @@ func (p *pia) GetOpenVPNConnection(selection models.ServerSelection) (
 		}
 	}
 
-	connection = pickRandomConnection(connections, p.randSource)
+	connection = pickConnection(connections, selection, p.selectionSource(selection, p.randSource), p.failedAttempts)
+	p.setActiveServer(servers, connection.IP, selection.Protocol)
+	return connection, nil
+}
 
-	// Reverse lookup server from picked connection
-	found := false
+// setActiveServer sets the active server by reverse looking up
+// the server having the IP address given.
+func (p *pia) setActiveServer(servers []models.PIAServer, ip net.IP, protocol models.NetworkProtocol) {
+	p.activeProtocol = protocol
 	for _, server := range servers {
 		IPs := server.OpenvpnUDP.IPs
-		if selection.Protocol == constants.TCP {
+		if protocol == constants.TCP {
 			IPs = server.OpenvpnTCP.IPs
 		}
 		for _, IP := range IPs {
-			if connection.IP.Equal(IP) {
+			if ip.Equal(IP) {
 				p.activeServer = server
-				found = true
-				break
+				return
 			}
 		}
-		if found {
-			break
-		}
 	}
-	p.activeProtocol = selection.Protocol
+}
 
-	return connection, nil
+// DefaultCipherAuth returns the default cipher and auth algorithms
+// of the encryption preset of the settings.
+func (p *pia) DefaultCipherAuth(settings settings.OpenVPN) (cipher, auth string) {
+	if settings.Provider.ExtraConfigOptions.EncryptionPreset == constants.PIAEncryptionPresetNormal {
+		return "aes-128-cbc", "sha1"
+	}
+	return aes256cbc, sha256
 }
 
 func (p *pia) BuildConf(connection models.OpenVPNConnection,
 	username string, settings settings.OpenVPN) (lines []string) {
 	var X509CRL, certificate string
-	var defaultCipher, defaultAuth string
 	if settings.Provider.ExtraConfigOptions.EncryptionPreset == constants.PIAEncryptionPresetNormal {
-		defaultCipher = "aes-128-cbc"
-		defaultAuth = "sha1"
 		X509CRL = constants.PiaX509CRLNormal
 		certificate = constants.PIACertificateNormal
 	} else { // strong encryption
-		defaultCipher = aes256cbc
-		defaultAuth = "sha256"
 		X509CRL = constants.PiaX509CRLStrong
 		certificate = constants.PIACertificateStrong
 	}
-	if len(settings.Cipher) == 0 {
-		settings.Cipher = defaultCipher
-	}
-	if len(settings.Auth) == 0 {
-		settings.Auth = defaultAuth
-	}
+	setCipherAuthDefaults(&settings, p)
 	lines = []string{
 		"client",
 		"dev tun",
@@ -168,9 +178,6 @@ func (p *pia) BuildConf(connection models.OpenVPNConnection,
 	if !settings.Root {
 		lines = append(lines, "user "+username)
 	}
-	if settings.MSSFix > 0 {
-		lines = append(lines, "mssfix "+strconv.Itoa(int(settings.MSSFix)))
-	}
 	lines = append(lines, []string{
 		"<crl-verify>",
 		"-----BEGIN X509 CRL-----",
@@ -186,7 +193,7 @@ func (p *pia) BuildConf(connection models.OpenVPNConnection,
 		"</ca>",
 		"",
 	}...)
-	return lines
+	return customizeConf(lines, settings)
 }
 
 //nolint:gocognit
@@ -211,7 +218,8 @@ func (p *pia) PortForward(ctx context.Context, client *http.Client,
 		return
 	}
 	defer pfLogger.Warn("loop exited")
-	data, err := readPIAPortForwardData(openFile)
+	dataFilepath := string(p.portForwarding.DataFilepath)
+	data, err := readPIAPortForwardData(openFile, dataFilepath)
 	if err != nil {
 		pfLogger.Error(err)
 	}
@@ -230,7 +238,7 @@ func (p *pia) PortForward(ctx context.Context, client *http.Client,
 
 	if !dataFound || expired {
 		tryUntilSuccessful(ctx, pfLogger, func() error {
-			data, err = refreshPIAPortForwardData(ctx, client, gateway, openFile)
+			data, err = refreshPIAPortForwardData(ctx, client, gateway, openFile, dataFilepath)
 			return err
 		})
 		if ctx.Err() != nil {
@@ -259,7 +267,11 @@ func (p *pia) PortForward(ctx context.Context, client *http.Client,
 	}
 
 	expiryTimer := time.NewTimer(durationToExpiration)
-	const keepAlivePeriod = 15 * time.Minute
+	keepAlivePeriod := p.portForwarding.RenewPeriod
+	if keepAlivePeriod == 0 {
+		const defaultKeepAlivePeriod = 15 * time.Minute
+		keepAlivePeriod = defaultKeepAlivePeriod
+	}
 	// Timer behaving as a ticker
 	keepAliveTimer := time.NewTimer(keepAlivePeriod)
 	for {
@@ -279,14 +291,16 @@ func (p *pia) PortForward(ctx context.Context, client *http.Client,
 			return
 		case <-keepAliveTimer.C:
 			if err := bindPIAPort(ctx, client, gateway, data); err != nil {
-				pfLogger.Error(err)
+				pfLogger.Error("cannot renew port %d: %s", data.Port, err)
+			} else {
+				pfLogger.Info("Port %d renewed", data.Port)
 			}
 			keepAliveTimer.Reset(keepAlivePeriod)
 		case <-expiryTimer.C:
 			pfLogger.Warn("Forward port has expired on %s, getting another one", data.Expiration.Format(time.RFC1123))
 			oldPort := data.Port
 			for {
-				data, err = refreshPIAPortForwardData(ctx, client, gateway, openFile)
+				data, err = refreshPIAPortForwardData(ctx, client, gateway, openFile, dataFilepath)
 				if err != nil {
 					pfLogger.Error(err)
 					continue
@@ -318,17 +332,29 @@ func (p *pia) PortForward(ctx context.Context, client *http.Client,
 	}
 }
 
-func filterPIAServers(servers []models.PIAServer, regions []string) (filtered []models.PIAServer) {
-	for _, server := range servers {
-		switch {
-		case filterByPossibilities(server.Region, regions):
-		default:
-			filtered = append(filtered, server)
-		}
+func filterPIAServers(servers []models.PIAServer, regions, excludeRegions []string,
+	portForwardOnly bool, stage stageFunc) (filtered []models.PIAServer) {
+	region := func(i int) string { return servers[i].Region }
+	filters := []serverFilter{
+		{name: "port forwarding servers only", enabled: portForwardOnly,
+			filtered: func(i int) bool { return !servers[i].PortForward }},
+		possibilitiesFilter("regions", regions, region),
+		exclusionsFilter("excluded regions", excludeRegions, region),
+	}
+	for _, i := range applyFilters(len(servers), filters, stage) {
+		filtered = append(filtered, servers[i])
 	}
 	return filtered
 }
 
+// ExplainSelection returns the number of servers remaining after each
+// server filter of the selection given.
+func (p *pia) ExplainSelection(selection models.ServerSelection) (stages []SelectionStage) {
+	filterPIAServers(p.servers, selection.Regions, selection.ExcludeRegions, selection.PortForwardOnly,
+		func(stage SelectionStage) { stages = append(stages, stage) })
+	return stages
+}
+
 func newPIAHTTPClient(serverName string) (client *http.Client, err error) {
 	certificateBytes, err := base64.StdEncoding.DecodeString(constants.PIACertificateStrong)
 	if err != nil {
@@ -367,7 +393,7 @@ func newPIAHTTPClient(serverName string) (client *http.Client, err error) {
 }
 
 func refreshPIAPortForwardData(ctx context.Context, client *http.Client,
-	gateway net.IP, openFile os.OpenFileFunc) (data piaPortForwardData, err error) {
+	gateway net.IP, openFile os.OpenFileFunc, dataFilepath string) (data piaPortForwardData, err error) {
 	data.Token, err = fetchPIAToken(ctx, openFile, client)
 	if err != nil {
 		return data, fmt.Errorf("cannot obtain token: %w", err)
@@ -376,7 +402,7 @@ func refreshPIAPortForwardData(ctx context.Context, client *http.Client,
 	if err != nil {
 		return data, fmt.Errorf("cannot obtain port forwarding data: %w", err)
 	}
-	if err := writePIAPortForwardData(openFile, data); err != nil {
+	if err := writePIAPortForwardData(openFile, dataFilepath, data); err != nil {
 		return data, fmt.Errorf("cannot persist port forwarding information to file: %w", err)
 	}
 	return data, nil
@@ -395,8 +421,7 @@ type piaPortForwardData struct {
 	Expiration time.Time `json:"expires_at"`
 }
 
-func readPIAPortForwardData(openFile os.OpenFileFunc) (data piaPortForwardData, err error) {
-	const filepath = string(constants.PIAPortForward)
+func readPIAPortForwardData(openFile os.OpenFileFunc, filepath string) (data piaPortForwardData, err error) {
 	file, err := openFile(filepath, os.O_RDONLY, 0)
 	if os.IsNotExist(err) {
 		return data, nil
@@ -413,8 +438,7 @@ func readPIAPortForwardData(openFile os.OpenFileFunc) (data piaPortForwardData,
 	return data, file.Close()
 }
 
-func writePIAPortForwardData(openFile os.OpenFileFunc, data piaPortForwardData) (err error) {
-	const filepath = string(constants.PIAPortForward)
+func writePIAPortForwardData(openFile os.OpenFileFunc, filepath string, data piaPortForwardData) (err error) {
 	file, err := openFile(filepath,
 		os.O_CREATE|os.O_TRUNC|os.O_WRONLY,
 		0644)
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_filterPIAServers(t *testing.T) {
+	t.Parallel()
+	servers := []models.PIAServer{
+		{Region: "A", PortForward: true},
+		{Region: "B"},
+		{Region: "C", PortForward: true},
+	}
+	testCases := map[string]struct {
+		regions         []string
+		excludeRegions  []string
+		portForwardOnly bool
+		filtered        []models.PIAServer
+	}{
+		"no filter": {
+			filtered: servers,
+		},
+		"regions and exclusions": {
+			regions:        []string{"a", "b"},
+			excludeRegions: []string{"a"},
+			filtered:       []models.PIAServer{{Region: "B"}},
+		},
+		"port forwarding only": {
+			portForwardOnly: true,
+			filtered:        []models.PIAServer{{Region: "A", PortForward: true}, {Region: "C", PortForward: true}},
+		},
+		"no port forwarding server in region": {
+			regions:         []string{"b"},
+			portForwardOnly: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			filtered := filterPIAServers(servers, testCase.regions,
+				testCase.excludeRegions, testCase.portForwardOnly, nil)
+			assert.Equal(t, testCase.filtered, filtered)
+		})
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
-	"strconv"
 
 	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/firewall"
@@ -17,6 +16,7 @@ import (
 )
 
 type privado struct {
+	rotation
 	servers    []models.PrivadoServer
 	randSource rand.Source
 }
@@ -28,17 +28,27 @@ func newPrivado(servers []models.PrivadoServer, timeNow timeNowFunc) *privado {
 	}
 }
 
-func (s *privado) filterServers(hostnames []string) (servers []models.PrivadoServer) {
-	for _, server := range s.servers {
-		switch {
-		case filterByPossibilities(server.Hostname, hostnames):
-		default:
-			servers = append(servers, server)
-		}
+func (s *privado) filterServers(hostnames, excludeHostnames []string, stage stageFunc) (
+	servers []models.PrivadoServer) {
+	hostname := func(i int) string { return s.servers[i].Hostname }
+	filters := []serverFilter{
+		possibilitiesFilter("hostnames", hostnames, hostname),
+		exclusionsFilter("excluded hostnames", excludeHostnames, hostname),
+	}
+	for _, i := range applyFilters(len(s.servers), filters, stage) {
+		servers = append(servers, s.servers[i])
 	}
 	return servers
 }
 
+// ExplainSelection returns the number of servers remaining after each
+// server filter of the selection given.
+func (s *privado) ExplainSelection(selection models.ServerSelection) (stages []SelectionStage) {
+	s.filterServers(selection.Hostnames, selection.ExcludeHostnames,
+		func(stage SelectionStage) { stages = append(stages, stage) })
+	return stages
+}
+
 func (s *privado) GetOpenVPNConnection(selection models.ServerSelection) (
 	connection models.OpenVPNConnection, err error) {
 	var port uint16 = 1194
@@ -52,7 +62,7 @@ func (s *privado) GetOpenVPNConnection(selection models.ServerSelection) (
 		return models.OpenVPNConnection{IP: selection.TargetIP, Port: port, Protocol: selection.Protocol}, nil
 	}
 
-	servers := s.filterServers(selection.Hostnames)
+	servers := s.filterServers(selection.Hostnames, selection.ExcludeHostnames, nil)
 	if len(servers) == 0 {
 		return connection, fmt.Errorf("no server found for cities %s and server numbers %v",
 			commaJoin(selection.Cities), selection.Numbers)
@@ -69,17 +79,17 @@ func (s *privado) GetOpenVPNConnection(selection models.ServerSelection) (
 		connections[i] = connection
 	}
 
-	return pickRandomConnection(connections, s.randSource), nil
+	return pickConnection(connections, selection, s.selectionSource(selection, s.randSource), s.failedAttempts), nil
+}
+
+// DefaultCipherAuth returns the default cipher and auth algorithms.
+func (s *privado) DefaultCipherAuth(settings.OpenVPN) (cipher, auth string) {
+	return aes256cbc, sha256
 }
 
 func (s *privado) BuildConf(connection models.OpenVPNConnection,
 	username string, settings settings.OpenVPN) (lines []string) {
-	if len(settings.Cipher) == 0 {
-		settings.Cipher = aes256cbc
-	}
-	if len(settings.Auth) == 0 {
-		settings.Auth = sha256
-	}
+	setCipherAuthDefaults(&settings, s)
 	lines = []string{
 		"client",
 		"dev tun",
@@ -112,9 +122,6 @@ func (s *privado) BuildConf(connection models.OpenVPNConnection,
 	if !settings.Root {
 		lines = append(lines, "user "+username)
 	}
-	if settings.MSSFix > 0 {
-		lines = append(lines, "mssfix "+strconv.Itoa(int(settings.MSSFix)))
-	}
 	lines = append(lines, []string{
 		"<ca>",
 		"-----BEGIN CERTIFICATE-----",
@@ -122,7 +129,7 @@ func (s *privado) BuildConf(connection models.OpenVPNConnection,
 		"-----END CERTIFICATE-----",
 		"</ca>",
 	}...)
-	return lines
+	return customizeConf(lines, settings)
 }
 
 func (s *privado) PortForward(ctx context.Context, client *http.Client,
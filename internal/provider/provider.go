@@ -2,6 +2,7 @@ package provider
 
 import (
 	"context"
+	"math/rand"
 	"net"
 	"net/http"
 
@@ -22,6 +23,49 @@ type Provider interface {
 		syncState func(port uint16) (pfFilepath models.Filepath))
 }
 
+// Rotator is implemented by providers rotating through their server
+// candidates in order after failed connection attempts.
+type Rotator interface {
+	SetFailedAttempts(failedAttempts int)
+	SetRandSource(source rand.Source)
+}
+
+// rotation implements the Rotator interface and is embedded in providers.
+type rotation struct {
+	failedAttempts int
+	randSource     rand.Source
+}
+
+// SetFailedAttempts sets the number of consecutive failed connection
+// attempts, used to go through the servers in order for the sequential
+// and ping connect orders, and to rotate through the selected Cyberghost
+// regions.
+func (r *rotation) SetFailedAttempts(failedAttempts int) {
+	r.failedAttempts = failedAttempts
+}
+
+// SetRandSource sets the random source to pick servers randomly with,
+// such that it is reused across connection attempts instead of being
+// seeded again with the selection seed for each attempt.
+func (r *rotation) SetRandSource(source rand.Source) {
+	r.randSource = source
+}
+
+// selectionSource returns the random source set with SetRandSource if any,
+// or the source seeded with the selection seed or the default source given.
+func (r *rotation) selectionSource(selection models.ServerSelection, defaultSource rand.Source) rand.Source {
+	if r.randSource != nil {
+		return r.randSource
+	}
+	return seededRandSource(selection, defaultSource)
+}
+
+// PortForwardConfigurer is implemented by providers needing the port
+// forwarding settings, such as to renew their forwarded port periodically.
+type PortForwardConfigurer interface {
+	SetPortForwardSettings(settings models.PortForwarding)
+}
+
 func New(provider models.VPNProvider, allServers models.AllServers, timeNow timeNowFunc) Provider {
 	switch provider {
 	case constants.PrivateInternetAccess:
@@ -42,6 +86,8 @@ func New(provider models.VPNProvider, allServers models.AllServers, timeNow time
 		return newPurevpn(allServers.Purevpn.Servers, timeNow)
 	case constants.Privado:
 		return newPrivado(allServers.Privado.Servers, timeNow)
+	case constants.Custom:
+		return newCustom(timeNow)
 	default:
 		return nil // should never occur
 	}
@@ -6,7 +6,6 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
-	"strconv"
 
 	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/firewall"
@@ -17,6 +16,7 @@ import (
 )
 
 type purevpn struct {
+	rotation
 	servers    []models.PurevpnServer
 	randSource rand.Source
 }
@@ -28,20 +28,29 @@ func newPurevpn(servers []models.PurevpnServer, timeNow timeNowFunc) *purevpn {
 	}
 }
 
-func (p *purevpn) filterServers(regions, countries, cities []string) (servers []models.PurevpnServer) {
-	for _, server := range p.servers {
-		switch {
-		case
-			filterByPossibilities(server.Region, regions),
-			filterByPossibilities(server.Country, countries),
-			filterByPossibilities(server.City, cities):
-		default:
-			servers = append(servers, server)
-		}
+func (p *purevpn) filterServers(regions, excludeRegions, countries, cities []string, stage stageFunc) (
+	servers []models.PurevpnServer) {
+	region := func(i int) string { return p.servers[i].Region }
+	filters := []serverFilter{
+		possibilitiesFilter("regions", regions, region),
+		exclusionsFilter("excluded regions", excludeRegions, region),
+		possibilitiesFilter("countries", countries, func(i int) string { return p.servers[i].Country }),
+		possibilitiesFilter("cities", cities, func(i int) string { return p.servers[i].City }),
+	}
+	for _, i := range applyFilters(len(p.servers), filters, stage) {
+		servers = append(servers, p.servers[i])
 	}
 	return servers
 }
 
+// ExplainSelection returns the number of servers remaining after each
+// server filter of the selection given.
+func (p *purevpn) ExplainSelection(selection models.ServerSelection) (stages []SelectionStage) {
+	p.filterServers(selection.Regions, selection.ExcludeRegions, selection.Countries, selection.Cities,
+		func(stage SelectionStage) { stages = append(stages, stage) })
+	return stages
+}
+
 func (p *purevpn) GetOpenVPNConnection(selection models.ServerSelection) (
 	connection models.OpenVPNConnection, err error) {
 	var port uint16
@@ -58,7 +67,7 @@ func (p *purevpn) GetOpenVPNConnection(selection models.ServerSelection) (
 		return models.OpenVPNConnection{IP: selection.TargetIP, Port: port, Protocol: selection.Protocol}, nil
 	}
 
-	servers := p.filterServers(selection.Regions, selection.Countries, selection.Cities)
+	servers := p.filterServers(selection.Regions, selection.ExcludeRegions, selection.Countries, selection.Cities, nil)
 	if len(servers) == 0 {
 		return connection, fmt.Errorf("no server found for regions %s, countries %s and cities %s",
 			commaJoin(selection.Regions), commaJoin(selection.Countries), commaJoin(selection.Cities))
@@ -71,14 +80,17 @@ func (p *purevpn) GetOpenVPNConnection(selection models.ServerSelection) (
 		}
 	}
 
-	return pickRandomConnection(connections, p.randSource), nil
+	return pickConnection(connections, selection, p.selectionSource(selection, p.randSource), p.failedAttempts), nil
+}
+
+// DefaultCipherAuth returns the default cipher, without default auth algorithm.
+func (p *purevpn) DefaultCipherAuth(settings.OpenVPN) (cipher, auth string) {
+	return aes256cbc, ""
 }
 
 func (p *purevpn) BuildConf(connection models.OpenVPNConnection,
 	username string, settings settings.OpenVPN) (lines []string) {
-	if len(settings.Cipher) == 0 {
-		settings.Cipher = aes256cbc
-	}
+	setCipherAuthDefaults(&settings, p)
 	lines = []string{
 		"client",
 		"dev tun",
@@ -116,9 +128,6 @@ func (p *purevpn) BuildConf(connection models.OpenVPNConnection,
 	if !settings.Root {
 		lines = append(lines, "user "+username)
 	}
-	if settings.MSSFix > 0 {
-		lines = append(lines, "mssfix "+strconv.Itoa(int(settings.MSSFix)))
-	}
 	lines = append(lines, []string{
 		"<ca>",
 		"-----BEGIN CERTIFICATE-----",
@@ -155,7 +164,7 @@ func (p *purevpn) BuildConf(connection models.OpenVPNConnection,
 	if connection.Protocol == constants.UDP {
 		lines = append(lines, "explicit-exit-notify")
 	}
-	return lines
+	return customizeConf(lines, settings)
 }
 
 func (p *purevpn) PortForward(ctx context.Context, client *http.Client,
@@ -6,7 +6,6 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
-	"strconv"
 
 	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/firewall"
@@ -17,6 +16,7 @@ import (
 )
 
 type surfshark struct {
+	rotation
 	servers    []models.SurfsharkServer
 	randSource rand.Source
 }
@@ -28,18 +28,27 @@ func newSurfshark(servers []models.SurfsharkServer, timeNow timeNowFunc) *surfsh
 	}
 }
 
-func (s *surfshark) filterServers(regions []string) (servers []models.SurfsharkServer) {
-	for _, server := range s.servers {
-		switch {
-		case
-			filterByPossibilities(server.Region, regions):
-		default:
-			servers = append(servers, server)
-		}
+func (s *surfshark) filterServers(regions, excludeRegions []string, stage stageFunc) (
+	servers []models.SurfsharkServer) {
+	region := func(i int) string { return s.servers[i].Region }
+	filters := []serverFilter{
+		possibilitiesFilter("regions", regions, region),
+		exclusionsFilter("excluded regions", excludeRegions, region),
+	}
+	for _, i := range applyFilters(len(s.servers), filters, stage) {
+		servers = append(servers, s.servers[i])
 	}
 	return servers
 }
 
+// ExplainSelection returns the number of servers remaining after each
+// server filter of the selection given.
+func (s *surfshark) ExplainSelection(selection models.ServerSelection) (stages []SelectionStage) {
+	s.filterServers(selection.Regions, selection.ExcludeRegions,
+		func(stage SelectionStage) { stages = append(stages, stage) })
+	return stages
+}
+
 func (s *surfshark) GetOpenVPNConnection(selection models.ServerSelection) (
 	connection models.OpenVPNConnection, err error) {
 	var port uint16
@@ -56,7 +65,7 @@ func (s *surfshark) GetOpenVPNConnection(selection models.ServerSelection) (
 		return models.OpenVPNConnection{IP: selection.TargetIP, Port: port, Protocol: selection.Protocol}, nil
 	}
 
-	servers := s.filterServers(selection.Regions)
+	servers := s.filterServers(selection.Regions, selection.ExcludeRegions, nil)
 	if len(servers) == 0 {
 		return connection, fmt.Errorf("no server found for region %s", commaJoin(selection.Regions))
 	}
@@ -72,22 +81,17 @@ func (s *surfshark) GetOpenVPNConnection(selection models.ServerSelection) (
 		return connection, fmt.Errorf("target IP %s not found in IP addresses", selection.TargetIP)
 	}
 
-	return pickRandomConnection(connections, s.randSource), nil
+	return pickConnection(connections, selection, s.selectionSource(selection, s.randSource), s.failedAttempts), nil
+}
+
+// DefaultCipherAuth returns the default cipher and auth algorithms.
+func (s *surfshark) DefaultCipherAuth(settings.OpenVPN) (cipher, auth string) {
+	return aes256cbc, "SHA512"
 }
 
 func (s *surfshark) BuildConf(connection models.OpenVPNConnection,
 	username string, settings settings.OpenVPN) (lines []string) {
-	if len(settings.Cipher) == 0 {
-		settings.Cipher = aes256cbc
-	}
-	if len(settings.Auth) == 0 {
-		settings.Auth = "SHA512"
-	}
-
-	const defaultMSSFix = 1450
-	if settings.MSSFix == 0 {
-		settings.MSSFix = defaultMSSFix
-	}
+	setCipherAuthDefaults(&settings, s)
 
 	lines = []string{
 		"client",
@@ -103,7 +107,7 @@ func (s *surfshark) BuildConf(connection models.OpenVPNConnection,
 		// Surfshark specific
 		"tun-mtu 1500",
 		"tun-mtu-extra 32",
-		"mssfix " + strconv.Itoa(int(settings.MSSFix)),
+		"mssfix 1450",
 		"reneg-sec 0",
 		"fast-io",
 		"key-direction 1",
@@ -143,7 +147,7 @@ func (s *surfshark) BuildConf(connection models.OpenVPNConnection,
 		"</tls-auth>",
 		"",
 	}...)
-	return lines
+	return customizeConf(lines, settings)
 }
 
 func (s *surfshark) PortForward(ctx context.Context, client *http.Client,
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/qdm12/gluetun/internal/params"
+	"github.com/qdm12/gluetun/internal/settings"
+)
+
+// TemplateData is the data passed to a user OpenVPN configuration template.
+type TemplateData struct {
+	Connection models.OpenVPNConnection
+	Username   string
+	Settings   settings.OpenVPN
+	// DefaultLines are the configuration lines built by the provider.
+	DefaultLines []string
+}
+
+// BuildConfFromTemplate renders the OpenVPN configuration lines using the
+// template text of the OpenVPN settings, and falls back on the provider
+// BuildConf method if no template is set. The rendered lines not built by
+// the provider must not use unsafe directives. The configuration fragments
+// of the settings are appended to the lines.
+func BuildConfFromTemplate(provider Provider, connection models.OpenVPNConnection,
+	username string, settings settings.OpenVPN) (lines []string, err error) {
+	lines = provider.BuildConf(connection, username, settings)
+	if len(settings.ConfigTemplate) == 0 {
+		return append(lines, settings.ConfigFragments...), nil
+	}
+	tmpl, err := template.New("openvpn").Parse(settings.ConfigTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse OpenVPN configuration template: %w", err)
+	}
+	data := TemplateData{
+		Connection:   connection,
+		Username:     username,
+		Settings:     settings,
+		DefaultLines: lines,
+	}
+	buffer := bytes.NewBuffer(nil)
+	if err := tmpl.Execute(buffer, data); err != nil {
+		return nil, fmt.Errorf("cannot execute OpenVPN configuration template: %w", err)
+	}
+	lines = strings.Split(buffer.String(), "\n")
+	if err := params.CheckUnsafeDirectives(removeLines(lines, data.DefaultLines)); err != nil {
+		return nil, fmt.Errorf("in OpenVPN configuration template: %w", err)
+	}
+	return append(lines, settings.ConfigFragments...), nil
+}
+
+// removeLines returns the lines which are not in the lines to remove.
+func removeLines(lines, toRemove []string) (filtered []string) {
+	remove := make(map[string]struct{}, len(toRemove))
+	for _, line := range toRemove {
+		remove[line] = struct{}{}
+	}
+	for _, line := range lines {
+		if _, ok := remove[line]; !ok {
+			filtered = append(filtered, line)
+		}
+	}
+	return filtered
+}
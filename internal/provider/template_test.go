@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/qdm12/gluetun/internal/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeProvider struct {
+	Provider
+	lines []string
+}
+
+func (f *fakeProvider) BuildConf(models.OpenVPNConnection, string, settings.OpenVPN) []string {
+	return f.lines
+}
+
+func Test_BuildConfFromTemplate(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		template string
+		lines    []string
+		err      string
+	}{
+		"no template": {
+			lines: []string{"client", "script-security 2"},
+		},
+		"template using data": {
+			template: "{{range .DefaultLines}}{{.}}\n{{end}}remote {{.Connection.Hostname}} {{.Connection.Port}}",
+			lines:    []string{"client", "script-security 2", "remote host 1194"},
+		},
+		"template with unsafe directive": {
+			template: "client\nup /script.sh",
+			err:      "in OpenVPN configuration template: OpenVPN directive is not allowed since it can run commands: up",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			provider := &fakeProvider{lines: []string{"client", "script-security 2"}}
+			connection := models.OpenVPNConnection{Hostname: "host", Port: 1194}
+			settings := settings.OpenVPN{ConfigTemplate: testCase.template}
+			lines, err := BuildConfFromTemplate(provider, connection, "user", settings)
+			if len(testCase.err) > 0 {
+				assert.EqualError(t, err, testCase.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.lines, lines)
+		})
+	}
+}
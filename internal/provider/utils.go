@@ -1,11 +1,14 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"math/rand"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/models"
 	"github.com/qdm12/golibs/logging"
 )
@@ -37,6 +40,36 @@ func pickRandomConnection(connections []models.OpenVPNConnection, source rand.So
 	return connections[rand.New(source).Intn(len(connections))] //nolint:gosec
 }
 
+// pickConnection picks a connection randomly with the source given for the
+// random connect order, or the connection at the attempt index of the connections sorted by IP
+// address and port for the sequential and ping connect orders.
+func pickConnection(connections []models.OpenVPNConnection, selection models.ServerSelection,
+	source rand.Source, attempt int) models.OpenVPNConnection {
+	switch selection.ConnectOrder {
+	case constants.SequentialOrder, constants.PingOrder:
+		sorted := make([]models.OpenVPNConnection, len(connections))
+		copy(sorted, connections)
+		sort.Slice(sorted, func(i, j int) bool {
+			if c := bytes.Compare(sorted[i].IP.To16(), sorted[j].IP.To16()); c != 0 {
+				return c < 0
+			}
+			return sorted[i].Port < sorted[j].Port
+		})
+		return sorted[attempt%len(sorted)]
+	default:
+		return pickRandomConnection(connections, source)
+	}
+}
+
+// seededRandSource returns a random source seeded with the selection seed
+// if it is set, or the default source given otherwise.
+func seededRandSource(selection models.ServerSelection, defaultSource rand.Source) rand.Source {
+	if selection.Seed == nil {
+		return defaultSource
+	}
+	return rand.NewSource(*selection.Seed)
+}
+
 func filterByPossibilities(value string, possibilities []string) (filtered bool) {
 	if len(possibilities) == 0 {
 		return false
@@ -49,6 +82,16 @@ func filterByPossibilities(value string, possibilities []string) (filtered bool)
 	return true
 }
 
+// filterByExclusions returns true if the value is one of the exclusions.
+func filterByExclusions(value string, exclusions []string) (filtered bool) {
+	for _, exclusion := range exclusions {
+		if strings.EqualFold(value, exclusion) {
+			return true
+		}
+	}
+	return false
+}
+
 func commaJoin(slice []string) string {
 	return strings.Join(slice, ",")
 }
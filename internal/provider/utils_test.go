@@ -2,8 +2,10 @@ package provider
 
 import (
 	"math/rand"
+	"net"
 	"testing"
 
+	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/models"
 	"github.com/stretchr/testify/assert"
 )
@@ -25,6 +27,23 @@ func Test_pickRandomConnection(t *testing.T) {
 	assert.Equal(t, models.OpenVPNConnection{Port: 2}, connection)
 }
 
+func Test_pickConnection(t *testing.T) {
+	t.Parallel()
+	connections := []models.OpenVPNConnection{
+		{IP: net.IPv4(2, 2, 2, 2), Port: 1},
+		{IP: net.IPv4(1, 1, 1, 1), Port: 2},
+		{IP: net.IPv4(1, 1, 1, 1), Port: 1},
+	}
+	selection := models.ServerSelection{ConnectOrder: constants.SequentialOrder}
+	expected := []models.OpenVPNConnection{
+		connections[2], connections[1], connections[0], connections[2],
+	}
+	for attempt, expectedConnection := range expected {
+		connection := pickConnection(connections, selection, nil, attempt)
+		assert.Equal(t, expectedConnection, connection)
+	}
+}
+
 func Test_filterByPossibilities(t *testing.T) {
 	t.Parallel()
 	testCases := map[string]struct {
@@ -52,3 +71,65 @@ func Test_filterByPossibilities(t *testing.T) {
 		})
 	}
 }
+
+func Test_filterByExclusions(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		value      string
+		exclusions []string
+		filtered   bool
+	}{
+		"no exclusions": {},
+		"value not in exclusions": {
+			value:      "c",
+			exclusions: []string{"a", "b"},
+		},
+		"value in exclusions": {
+			value:      "C",
+			exclusions: []string{"a", "b", "c"},
+			filtered:   true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			filtered := filterByExclusions(testCase.value, testCase.exclusions)
+			assert.Equal(t, testCase.filtered, filtered)
+		})
+	}
+}
+
+func Test_seededRandSource(t *testing.T) {
+	t.Parallel()
+	defaultSource := rand.NewSource(0)
+
+	source := seededRandSource(models.ServerSelection{}, defaultSource)
+	assert.Equal(t, defaultSource, source)
+
+	seed := int64(5)
+	selection := models.ServerSelection{Seed: &seed}
+	first := rand.New(seededRandSource(selection, defaultSource)).Int63()
+	second := rand.New(seededRandSource(selection, defaultSource)).Int63()
+	assert.Equal(t, first, second)
+}
+
+func Test_rotation_selectionSource(t *testing.T) {
+	t.Parallel()
+	seed := int64(5)
+	selection := models.ServerSelection{Seed: &seed}
+	defaultSource := rand.NewSource(0)
+
+	r := &rotation{}
+	first := rand.New(r.selectionSource(selection, defaultSource)).Int63()
+	second := rand.New(r.selectionSource(selection, defaultSource)).Int63()
+	assert.Equal(t, first, second)
+
+	source := rand.NewSource(seed)
+	r.SetRandSource(source)
+	assert.Equal(t, source, r.selectionSource(selection, defaultSource))
+	first = rand.New(r.selectionSource(selection, defaultSource)).Int63()
+	second = rand.New(r.selectionSource(selection, defaultSource)).Int63()
+	assert.NotEqual(t, first, second)
+}
@@ -6,7 +6,6 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
-	"strconv"
 
 	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/firewall"
@@ -17,6 +16,7 @@ import (
 )
 
 type vyprvpn struct {
+	rotation
 	servers    []models.VyprvpnServer
 	randSource rand.Source
 }
@@ -28,18 +28,27 @@ func newVyprvpn(servers []models.VyprvpnServer, timeNow timeNowFunc) *vyprvpn {
 	}
 }
 
-func (v *vyprvpn) filterServers(regions []string) (servers []models.VyprvpnServer) {
-	for _, server := range v.servers {
-		switch {
-		case
-			filterByPossibilities(server.Region, regions):
-		default:
-			servers = append(servers, server)
-		}
+func (v *vyprvpn) filterServers(regions, excludeRegions []string, stage stageFunc) (
+	servers []models.VyprvpnServer) {
+	region := func(i int) string { return v.servers[i].Region }
+	filters := []serverFilter{
+		possibilitiesFilter("regions", regions, region),
+		exclusionsFilter("excluded regions", excludeRegions, region),
+	}
+	for _, i := range applyFilters(len(v.servers), filters, stage) {
+		servers = append(servers, v.servers[i])
 	}
 	return servers
 }
 
+// ExplainSelection returns the number of servers remaining after each
+// server filter of the selection given.
+func (v *vyprvpn) ExplainSelection(selection models.ServerSelection) (stages []SelectionStage) {
+	v.filterServers(selection.Regions, selection.ExcludeRegions,
+		func(stage SelectionStage) { stages = append(stages, stage) })
+	return stages
+}
+
 func (v *vyprvpn) GetOpenVPNConnection(selection models.ServerSelection) (
 	connection models.OpenVPNConnection, err error) {
 	var port uint16
@@ -56,7 +65,7 @@ func (v *vyprvpn) GetOpenVPNConnection(selection models.ServerSelection) (
 		return models.OpenVPNConnection{IP: selection.TargetIP, Port: port, Protocol: selection.Protocol}, nil
 	}
 
-	servers := v.filterServers(selection.Regions)
+	servers := v.filterServers(selection.Regions, selection.ExcludeRegions, nil)
 	if len(servers) == 0 {
 		return connection, fmt.Errorf("no server found for region %s", commaJoin(selection.Regions))
 	}
@@ -68,17 +77,17 @@ func (v *vyprvpn) GetOpenVPNConnection(selection models.ServerSelection) (
 		}
 	}
 
-	return pickRandomConnection(connections, v.randSource), nil
+	return pickConnection(connections, selection, v.selectionSource(selection, v.randSource), v.failedAttempts), nil
+}
+
+// DefaultCipherAuth returns the default cipher and auth algorithms.
+func (v *vyprvpn) DefaultCipherAuth(settings.OpenVPN) (cipher, auth string) {
+	return aes256cbc, "SHA256"
 }
 
 func (v *vyprvpn) BuildConf(connection models.OpenVPNConnection,
 	username string, settings settings.OpenVPN) (lines []string) {
-	if len(settings.Cipher) == 0 {
-		settings.Cipher = aes256cbc
-	}
-	if len(settings.Auth) == 0 {
-		settings.Auth = "SHA256"
-	}
+	setCipherAuthDefaults(&settings, v)
 	lines = []string{
 		"client",
 		"dev tun",
@@ -113,9 +122,6 @@ func (v *vyprvpn) BuildConf(connection models.OpenVPNConnection,
 	if !settings.Root {
 		lines = append(lines, "user "+username)
 	}
-	if settings.MSSFix > 0 {
-		lines = append(lines, "mssfix "+strconv.Itoa(int(settings.MSSFix)))
-	}
 	lines = append(lines, []string{
 		"<ca>",
 		"-----BEGIN CERTIFICATE-----",
@@ -123,7 +129,7 @@ func (v *vyprvpn) BuildConf(connection models.OpenVPNConnection,
 		"-----END CERTIFICATE-----",
 		"</ca>",
 	}...)
-	return lines
+	return customizeConf(lines, settings)
 }
 
 func (v *vyprvpn) PortForward(ctx context.Context, client *http.Client,
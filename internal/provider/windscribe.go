@@ -6,7 +6,6 @@ import (
 	"math/rand"
 	"net"
 	"net/http"
-	"strconv"
 	"strings"
 
 	"github.com/qdm12/gluetun/internal/constants"
@@ -18,6 +17,7 @@ import (
 )
 
 type windscribe struct {
+	rotation
 	servers    []models.WindscribeServer
 	randSource rand.Source
 }
@@ -29,20 +29,32 @@ func newWindscribe(servers []models.WindscribeServer, timeNow timeNowFunc) *wind
 	}
 }
 
-func (w *windscribe) filterServers(regions, cities, hostnames []string) (servers []models.WindscribeServer) {
-	for _, server := range w.servers {
-		switch {
-		case
-			filterByPossibilities(server.Region, regions),
-			filterByPossibilities(server.City, cities),
-			filterByPossibilities(server.Hostname, hostnames):
-		default:
-			servers = append(servers, server)
-		}
+func (w *windscribe) filterServers(regions, excludeRegions, cities, hostnames, excludeHostnames []string,
+	stage stageFunc) (servers []models.WindscribeServer) {
+	region := func(i int) string { return w.servers[i].Region }
+	hostname := func(i int) string { return w.servers[i].Hostname }
+	filters := []serverFilter{
+		possibilitiesFilter("regions", regions, region),
+		exclusionsFilter("excluded regions", excludeRegions, region),
+		possibilitiesFilter("cities", cities, func(i int) string { return w.servers[i].City }),
+		possibilitiesFilter("hostnames", hostnames, hostname),
+		exclusionsFilter("excluded hostnames", excludeHostnames, hostname),
+	}
+	for _, i := range applyFilters(len(w.servers), filters, stage) {
+		servers = append(servers, w.servers[i])
 	}
 	return servers
 }
 
+// ExplainSelection returns the number of servers remaining after each
+// server filter of the selection given.
+func (w *windscribe) ExplainSelection(selection models.ServerSelection) (stages []SelectionStage) {
+	w.filterServers(selection.Regions, selection.ExcludeRegions,
+		selection.Cities, selection.Hostnames, selection.ExcludeHostnames,
+		func(stage SelectionStage) { stages = append(stages, stage) })
+	return stages
+}
+
 //nolint:lll
 func (w *windscribe) GetOpenVPNConnection(selection models.ServerSelection) (connection models.OpenVPNConnection, err error) {
 	var port uint16
@@ -61,7 +73,8 @@ func (w *windscribe) GetOpenVPNConnection(selection models.ServerSelection) (con
 		return models.OpenVPNConnection{IP: selection.TargetIP, Port: port, Protocol: selection.Protocol}, nil
 	}
 
-	servers := w.filterServers(selection.Regions, selection.Cities, selection.Hostnames)
+	servers := w.filterServers(selection.Regions, selection.ExcludeRegions,
+		selection.Cities, selection.Hostnames, selection.ExcludeHostnames, nil)
 	if len(servers) == 0 {
 		return connection, fmt.Errorf("no server found for region %s", commaJoin(selection.Regions))
 	}
@@ -71,17 +84,17 @@ func (w *windscribe) GetOpenVPNConnection(selection models.ServerSelection) (con
 		connections = append(connections, models.OpenVPNConnection{IP: server.IP, Port: port, Protocol: selection.Protocol})
 	}
 
-	return pickRandomConnection(connections, w.randSource), nil
+	return pickConnection(connections, selection, w.selectionSource(selection, w.randSource), w.failedAttempts), nil
+}
+
+// DefaultCipherAuth returns the default cipher and auth algorithms.
+func (w *windscribe) DefaultCipherAuth(settings.OpenVPN) (cipher, auth string) {
+	return aes256cbc, "sha512"
 }
 
 func (w *windscribe) BuildConf(connection models.OpenVPNConnection,
 	username string, settings settings.OpenVPN) (lines []string) {
-	if len(settings.Cipher) == 0 {
-		settings.Cipher = aes256cbc
-	}
-	if len(settings.Auth) == 0 {
-		settings.Auth = "sha512"
-	}
+	setCipherAuthDefaults(&settings, w)
 	lines = []string{
 		"client",
 		"dev tun",
@@ -119,9 +132,6 @@ func (w *windscribe) BuildConf(connection models.OpenVPNConnection,
 	if !settings.Root {
 		lines = append(lines, "user "+username)
 	}
-	if settings.MSSFix > 0 {
-		lines = append(lines, "mssfix "+strconv.Itoa(int(settings.MSSFix)))
-	}
 	lines = append(lines, []string{
 		"<ca>",
 		"-----BEGIN CERTIFICATE-----",
@@ -137,7 +147,7 @@ func (w *windscribe) BuildConf(connection models.OpenVPNConnection,
 		"</tls-auth>",
 		"",
 	}...)
-	return lines
+	return customizeConf(lines, settings)
 }
 
 func (w *windscribe) PortForward(ctx context.Context, client *http.Client,
@@ -5,4 +5,5 @@ import "errors"
 var (
 	ErrBadStatusCode  = errors.New("bad HTTP status")
 	ErrCannotReadBody = errors.New("cannot read response body")
+	ErrTimeout        = errors.New("timed out")
 )
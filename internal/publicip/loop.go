@@ -2,6 +2,8 @@ package publicip
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net"
 	"net/http"
 	"sync"
@@ -22,14 +24,17 @@ type Looper interface {
 	GetSettings() (settings settings.PublicIP)
 	SetSettings(settings settings.PublicIP) (outcome string)
 	GetPublicIP() (publicIP net.IP)
+	GetPublicIPs() (publicIPs []models.PublicIP)
+	ExpectIPChange()
 }
 
 type looper struct {
 	state state
 	// Objects
-	getter IPGetter
-	logger logging.Logger
-	os     os.OS
+	getter       IPGetter
+	logger       logging.Logger
+	os           os.OS
+	reconnectVPN func()
 	// Fixed settings
 	puid int
 	pgid int
@@ -50,7 +55,7 @@ const defaultBackoffTime = 5 * time.Second
 
 func NewLooper(client *http.Client, logger logging.Logger,
 	settings settings.PublicIP, puid, pgid int,
-	os os.OS) Looper {
+	os os.OS, reconnectVPN func()) Looper {
 	return &looper{
 		state: state{
 			status:   constants.Stopped,
@@ -60,6 +65,7 @@ func NewLooper(client *http.Client, logger logging.Logger,
 		getter:       NewIPGetter(client),
 		logger:       logger.WithPrefix("ip getter: "),
 		os:           os,
+		reconnectVPN: reconnectVPN,
 		puid:         puid,
 		pgid:         pgid,
 		start:        make(chan struct{}),
@@ -105,10 +111,16 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 
 		ipCh := make(chan net.IP)
 		errorCh := make(chan error)
+		timeout := l.GetSettings().Timeout
 		go func() {
-			ip, err := l.getter.Get(getCtx)
+			timeoutCtx, timeoutCancel := context.WithTimeout(getCtx, timeout)
+			defer timeoutCancel()
+			ip, err := l.getter.Get(timeoutCtx)
 			if err != nil {
 				if getCtx.Err() == nil {
+					if timeoutCtx.Err() == context.DeadlineExceeded {
+						err = fmt.Errorf("%w after %s: %s", ErrTimeout, timeout, err)
+					}
 					errorCh <- err
 				}
 				return
@@ -148,8 +160,11 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 				l.stopped <- struct{}{}
 			case ip := <-ipCh:
 				getCancel()
-				l.state.setPublicIP(ip)
+				previousIP, expected := l.state.swapPublicIP(ip)
 				l.logger.Info("Public IP address is %s", ip)
+				if len(previousIP) > 0 && !expected && !previousIP.Equal(ip) {
+					l.onUnexpectedIPChange(previousIP, ip)
+				}
 				filepath := string(l.state.settings.IPFilepath)
 				err := persistPublicIP(l.os.OpenFile, filepath, ip.String(), l.puid, l.pgid)
 				if err != nil {
@@ -158,6 +173,18 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 				l.state.setStatusWithLock(constants.Completed)
 			case err := <-errorCh:
 				getCancel()
+				if errors.Is(err, ErrTimeout) {
+					// do not block on a slow IP echo service
+					l.logger.Warn("cannot get public IP address: %s", err)
+					l.state.clearPublicIP()
+					// empty the file so it does not hold a stale IP address
+					filepath := string(l.GetSettings().IPFilepath)
+					if err := persistPublicIP(l.os.OpenFile, filepath, "", l.puid, l.pgid); err != nil {
+						l.logger.Error(err)
+					}
+					l.state.setStatusWithLock(constants.Completed)
+					continue
+				}
 				close(ipCh)
 				l.state.setStatusWithLock(constants.Crashed)
 				l.logAndWait(ctx, err)
@@ -169,6 +196,16 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 	}
 }
 
+func (l *looper) onUnexpectedIPChange(previousIP, ip net.IP) {
+	if !l.GetSettings().ReconnectOnChange {
+		l.logger.Warn("public IP address changed from %s to %s without reconnecting", previousIP, ip)
+		return
+	}
+	l.logger.Warn("public IP address changed from %s to %s without reconnecting: reconnecting",
+		previousIP, ip)
+	go l.reconnectVPN()
+}
+
 func (l *looper) RunRestartTicker(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
 	timer := time.NewTimer(time.Hour)
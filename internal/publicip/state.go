@@ -15,6 +15,7 @@ type state struct {
 	status     models.LoopStatus
 	settings   settings.PublicIP
 	ip         net.IP
+	ipExpected bool
 	statusMu   sync.RWMutex
 	settingsMu sync.RWMutex
 	ipMu       sync.RWMutex
@@ -102,9 +103,39 @@ func (l *looper) GetPublicIP() (publicIP net.IP) {
 	return publicIP
 }
 
-func (s *state) setPublicIP(publicIP net.IP) {
+// GetPublicIPs returns the public IP address of each VPN exit.
+// Only the public IP address of the VPN tunnel is populated for now.
+func (l *looper) GetPublicIPs() (publicIPs []models.PublicIP) {
+	publicIP := l.GetPublicIP()
+	if len(publicIP) == 0 {
+		return []models.PublicIP{}
+	}
+	return []models.PublicIP{{Interface: constants.TUN, IP: publicIP}}
+}
+
+// ExpectIPChange signals the next public IP address obtained
+// may differ from the previous one, for example after a VPN reconnect.
+func (l *looper) ExpectIPChange() {
+	l.state.ipMu.Lock()
+	defer l.state.ipMu.Unlock()
+	l.state.ipExpected = true
+}
+
+// swapPublicIP sets the public IP address and returns the previous one,
+// together with whether a change of public IP address was expected.
+func (s *state) swapPublicIP(publicIP net.IP) (previousIP net.IP, expected bool) {
 	s.ipMu.Lock()
 	defer s.ipMu.Unlock()
+	previousIP, expected = s.ip, s.ipExpected
 	s.ip = make(net.IP, len(publicIP))
 	copy(s.ip, publicIP)
+	s.ipExpected = false
+	return previousIP, expected
+}
+
+// clearPublicIP marks the public IP address as unknown.
+func (s *state) clearPublicIP() {
+	s.ipMu.Lock()
+	defer s.ipMu.Unlock()
+	s.ip = nil
 }
@@ -0,0 +1,78 @@
+package routing
+
+import (
+	"fmt"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// Interface is a network interface with its IP addresses.
+type Interface struct {
+	Name      string   `json:"name"`
+	Up        bool     `json:"up"`
+	Addresses []string `json:"addresses"`
+}
+
+// Route is a route of one of the routing tables.
+type Route struct {
+	Destination string `json:"destination"`
+	Gateway     string `json:"gateway,omitempty"`
+	Interface   string `json:"interface,omitempty"`
+	Table       int    `json:"table"`
+}
+
+// Interfaces returns the network interfaces and their IP addresses.
+func (r *routing) Interfaces() (interfaces []Interface, err error) {
+	links, err := netlink.LinkList()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list links: %w", err)
+	}
+	interfaces = make([]Interface, len(links))
+	for i, link := range links {
+		attributes := link.Attrs()
+		addresses, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list addresses of link %s: %w", attributes.Name, err)
+		}
+		interfaces[i] = Interface{
+			Name:      attributes.Name,
+			Up:        attributes.OperState == netlink.OperUp,
+			Addresses: make([]string, len(addresses)),
+		}
+		for j, address := range addresses {
+			interfaces[i].Addresses[j] = address.IPNet.String()
+		}
+	}
+	return interfaces, nil
+}
+
+// Routes returns the routes of all the routing tables except the local one.
+func (r *routing) Routes() (routes []Route, err error) {
+	filter := &netlink.Route{Table: unix.RT_TABLE_UNSPEC}
+	netlinkRoutes, err := netlink.RouteListFiltered(netlink.FAMILY_ALL, filter, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list routes: %w", err)
+	}
+	routes = make([]Route, 0, len(netlinkRoutes))
+	for _, netlinkRoute := range netlinkRoutes {
+		if netlinkRoute.Table == unix.RT_TABLE_LOCAL {
+			continue
+		}
+		route := Route{
+			Destination: "default",
+			Table:       netlinkRoute.Table,
+		}
+		if netlinkRoute.Dst != nil {
+			route.Destination = netlinkRoute.Dst.String()
+		}
+		if netlinkRoute.Gw != nil {
+			route.Gateway = netlinkRoute.Gw.String()
+		}
+		if link, err := netlink.LinkByIndex(netlinkRoute.LinkIndex); err == nil {
+			route.Interface = link.Attrs().Name
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
@@ -15,7 +15,7 @@ func (r *routing) DefaultRoute() (defaultInterface string, defaultGateway net.IP
 		return "", nil, fmt.Errorf("cannot list routes: %w", err)
 	}
 	for _, route := range routes {
-		if route.Dst == nil {
+		if r.isDefaultRoute(route) {
 			defaultGateway = route.Gw
 			linkIndex := route.LinkIndex
 			link, err := netlink.LinkByIndex(linkIndex)
@@ -30,9 +30,23 @@ func (r *routing) DefaultRoute() (defaultInterface string, defaultGateway net.IP
 			return defaultInterface, defaultGateway, nil
 		}
 	}
+	r.stateMutex.RLock()
+	uplink := r.uplinkName
+	r.stateMutex.RUnlock()
+	if len(uplink) > 0 {
+		return "", nil, fmt.Errorf("%w: %s", ErrUplinkNoGateway, uplink)
+	}
 	return "", nil, fmt.Errorf("cannot find default route in %d routes", len(routes))
 }
 
+// isDefaultRoute returns true if the route is a default route
+// going through the uplink interface if one is set.
+func (r *routing) isDefaultRoute(route netlink.Route) bool {
+	r.stateMutex.RLock()
+	defer r.stateMutex.RUnlock()
+	return route.Dst == nil && (r.uplinkIndex == 0 || route.LinkIndex == r.uplinkIndex)
+}
+
 func (r *routing) DefaultIP() (ip net.IP, err error) {
 	routes, err := netlink.RouteList(nil, netlink.FAMILY_ALL)
 	if err != nil {
@@ -41,7 +55,7 @@ func (r *routing) DefaultIP() (ip net.IP, err error) {
 
 	defaultLinkName := ""
 	for _, route := range routes {
-		if route.Dst == nil {
+		if r.isDefaultRoute(route) {
 			linkIndex := route.LinkIndex
 			link, err := netlink.LinkByIndex(linkIndex)
 			if err != nil {
@@ -65,7 +79,7 @@ func (r *routing) LocalSubnet() (defaultSubnet net.IPNet, err error) {
 
 	defaultLinkIndex := -1
 	for _, route := range routes {
-		if route.Dst == nil {
+		if r.isDefaultRoute(route) {
 			defaultLinkIndex = route.LinkIndex
 			break
 		}
@@ -116,7 +130,7 @@ func (r *routing) VPNDestinationIP() (ip net.IP, err error) {
 
 	defaultLinkIndex := -1
 	for _, route := range routes {
-		if route.Dst == nil {
+		if r.isDefaultRoute(route) {
 			defaultLinkIndex = route.LinkIndex
 			break
 		}
@@ -1,8 +1,10 @@
 package routing
 
 import (
+	"context"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/qdm12/golibs/logging"
 )
@@ -12,13 +14,19 @@ type Routing interface {
 	Setup() (err error)
 	TearDown() error
 	SetOutboundRoutes(outboundSubnets []net.IPNet) error
+	SetUplinkInterface(name string) error
+	SetVPNServerRoute(ip net.IP) error
 
 	// Read only
 	DefaultRoute() (defaultInterface string, defaultGateway net.IP, err error)
+	WaitDefaultRoute(ctx context.Context, timeout time.Duration) (
+		defaultInterface string, defaultGateway net.IP, err error)
 	LocalSubnet() (defaultSubnet net.IPNet, err error)
 	DefaultIP() (defaultIP net.IP, err error)
 	VPNDestinationIP() (ip net.IP, err error)
 	VPNLocalGatewayIP() (ip net.IP, err error)
+	Interfaces() (interfaces []Interface, err error)
+	Routes() (routes []Route, err error)
 
 	// Internal state
 	SetVerbose(verbose bool)
@@ -30,6 +38,9 @@ type routing struct {
 	verbose         bool
 	debug           bool
 	outboundSubnets []net.IPNet
+	uplinkName      string
+	uplinkIndex     int // 0 for any interface
+	vpnServerIP     net.IP
 	stateMutex      sync.RWMutex
 }
 
@@ -0,0 +1,78 @@
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+var ErrUplinkNoGateway = errors.New("uplink interface has no default route gateway")
+
+// SetUplinkInterface restricts the default route used before the
+// tunnel is up to the network interface given. An empty name
+// allows the default route of any interface.
+func (r *routing) SetUplinkInterface(name string) error {
+	index := 0
+	if len(name) > 0 {
+		link, err := netlink.LinkByName(name)
+		if err != nil {
+			return fmt.Errorf("cannot find uplink interface %s: %w", name, err)
+		}
+		index = link.Attrs().Index
+	}
+	r.stateMutex.Lock()
+	defer r.stateMutex.Unlock()
+	r.uplinkName = name
+	r.uplinkIndex = index
+	return nil
+}
+
+// SetVPNServerRoute adds a host route to the VPN server IP address through
+// the gateway of the uplink interface, so the OpenVPN traffic leaves through
+// the uplink interface whatever the other routes are. It removes the route
+// to the previous VPN server, and does nothing if no uplink interface is set.
+func (r *routing) SetVPNServerRoute(ip net.IP) (err error) {
+	r.stateMutex.RLock()
+	uplink, previous := r.uplinkName, r.vpnServerIP
+	r.stateMutex.RUnlock()
+	if len(uplink) == 0 || previous.Equal(ip) {
+		return nil
+	}
+
+	_, gateway, err := r.DefaultRoute()
+	if err != nil {
+		return err
+	} else if gateway == nil {
+		return fmt.Errorf("%w: %s", ErrUplinkNoGateway, uplink)
+	}
+
+	if previous != nil {
+		if err := r.deleteRouteVia(hostIPNet(previous), gateway, uplink, unix.RT_TABLE_MAIN); err != nil {
+			r.logger.Warn(err)
+		}
+	}
+	r.stateMutex.Lock()
+	r.vpnServerIP = nil
+	r.stateMutex.Unlock()
+	if ip == nil {
+		return nil
+	}
+
+	if err := r.addRouteVia(hostIPNet(ip), gateway, uplink, unix.RT_TABLE_MAIN); err != nil {
+		return err
+	}
+	r.stateMutex.Lock()
+	r.vpnServerIP = ip
+	r.stateMutex.Unlock()
+	return nil
+}
+
+func hostIPNet(ip net.IP) net.IPNet {
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return net.IPNet{IP: ipv4, Mask: net.CIDRMask(net.IPv4len*8, net.IPv4len*8)} //nolint:gomnd
+	}
+	return net.IPNet{IP: ip, Mask: net.CIDRMask(net.IPv6len*8, net.IPv6len*8)} //nolint:gomnd
+}
@@ -0,0 +1,41 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+var (
+	ErrDefaultRouteTimeout = errors.New("timed out waiting for a default route")
+	ErrNegativeTimeout     = errors.New("timeout cannot be negative")
+)
+
+// WaitDefaultRoute polls for the default route every second until it is
+// found or the timeout is reached, for the network of the host to be ready.
+// Only the default route is awaited, name resolution is not checked since
+// the DNS is only set up once the tunnel is up.
+func (r *routing) WaitDefaultRoute(ctx context.Context, timeout time.Duration) (
+	defaultInterface string, defaultGateway net.IP, err error) {
+	if timeout < 0 {
+		return "", nil, fmt.Errorf("%w: %s", ErrNegativeTimeout, timeout)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	const pollPeriod = time.Second
+	ticker := time.NewTicker(pollPeriod)
+	defer ticker.Stop()
+	for {
+		defaultInterface, defaultGateway, err = r.DefaultRoute()
+		if err == nil {
+			return defaultInterface, defaultGateway, nil
+		}
+		select {
+		case <-ctx.Done():
+			return "", nil, fmt.Errorf("%w after %s: %s", ErrDefaultRouteTimeout, timeout, err)
+		case <-ticker.C:
+		}
+	}
+}
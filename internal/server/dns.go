@@ -3,6 +3,7 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -34,11 +35,37 @@ func (h *dnsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		default:
 			http.Error(w, "", http.StatusNotFound)
 		}
+	case "/stats":
+		switch r.Method {
+		case http.MethodGet:
+			h.getStats(w, r)
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
 	default:
 		http.Error(w, "", http.StatusNotFound)
 	}
 }
 
+func (h *dnsHandler) getStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.looper.GetStats(r.Context())
+	switch {
+	case errors.Is(err, dns.ErrStatsDisabled):
+		http.Error(w, err.Error()+": set DOT_STATS=on to enable them", http.StatusNotFound)
+		return
+	case err != nil:
+		h.logger.Warn(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(stats); err != nil {
+		h.logger.Warn(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
 func (h *dnsHandler) getStatus(w http.ResponseWriter) {
 	status := h.looper.GetStatus()
 	encoder := json.NewEncoder(w)
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/qdm12/gluetun/internal/openvpn"
+	"github.com/qdm12/gluetun/internal/publicip"
+	"github.com/qdm12/golibs/logging"
+)
+
+func newEventsHandler(openvpnLooper openvpn.Looper, publicIPLooper publicip.Looper,
+	logger logging.Logger) http.Handler {
+	return &eventsHandler{
+		openvpnLooper:  openvpnLooper,
+		publicIPLooper: publicIPLooper,
+		logger:         logger,
+		timeNow:        time.Now,
+	}
+}
+
+type eventsHandler struct {
+	openvpnLooper  openvpn.Looper
+	publicIPLooper publicip.Looper
+	logger         logging.Logger
+	timeNow        func() time.Time
+}
+
+func (h *eventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.RequestURI = strings.TrimPrefix(r.RequestURI, "/events")
+	switch r.RequestURI {
+	case "":
+		switch r.Method {
+		case http.MethodGet:
+			h.streamEvents(w, r)
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	default:
+		http.Error(w, "", http.StatusNotFound)
+	}
+}
+
+const (
+	statusEvent       = "status"
+	connectedEvent    = "connected"
+	disconnectedEvent = "disconnected"
+	reconnectedEvent  = "reconnected"
+	publicIPEvent     = "public_ip"
+)
+
+type event struct {
+	Type   string    `json:"type"`
+	Time   time.Time `json:"time"`
+	Status string    `json:"status,omitempty"`
+	IP     string    `json:"ip,omitempty"`
+}
+
+// connectionState is the state polled to detect the events to stream.
+type connectionState struct {
+	status    string
+	connected bool
+	publicIP  string
+}
+
+// stateEvents returns the events for the changes from the previous state
+// to the current state. A connection is reported as a reconnection if
+// the VPN was connected before in the stream.
+func stateEvents(previous, current connectionState, wasConnected bool,
+	now time.Time) (events []event) {
+	if current.status != previous.status {
+		events = append(events, event{Type: statusEvent, Time: now, Status: current.status})
+	}
+	switch {
+	case current.connected && !previous.connected && wasConnected:
+		events = append(events, event{Type: reconnectedEvent, Time: now})
+	case current.connected && !previous.connected:
+		events = append(events, event{Type: connectedEvent, Time: now})
+	case !current.connected && previous.connected:
+		events = append(events, event{Type: disconnectedEvent, Time: now})
+	}
+	if current.publicIP != previous.publicIP {
+		events = append(events, event{Type: publicIPEvent, Time: now, IP: current.publicIP})
+	}
+	return events
+}
+
+func (h *eventsHandler) getState() (state connectionState) {
+	state.status = string(h.openvpnLooper.GetStatus())
+	state.connected = h.openvpnLooper.IsConnected()
+	if ip := h.publicIPLooper.GetPublicIP(); len(ip) > 0 {
+		state.publicIP = ip.String()
+	}
+	return state
+}
+
+// streamEvents streams the events as server-sent events until the client
+// disconnects or the server shuts down, polling the state every second.
+func (h *eventsHandler) streamEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	const pollPeriod = time.Second
+	ticker := time.NewTicker(pollPeriod)
+	defer ticker.Stop()
+	var previous connectionState
+	wasConnected := false
+	for {
+		current := h.getState()
+		for _, e := range stateEvents(previous, current, wasConnected, h.timeNow()) {
+			data, err := json.Marshal(e)
+			if err != nil {
+				h.logger.Warn(err)
+				return
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data); err != nil {
+				return // client disconnected
+			}
+		}
+		flusher.Flush()
+		wasConnected = wasConnected || current.connected
+		previous = current
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_stateEvents(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1, 0)
+	testCases := map[string]struct {
+		previous     connectionState
+		current      connectionState
+		wasConnected bool
+		events       []event
+	}{
+		"no change": {
+			previous: connectionState{status: "running", connected: true, publicIP: "1.2.3.4"},
+			current:  connectionState{status: "running", connected: true, publicIP: "1.2.3.4"},
+		},
+		"initial state": {
+			current: connectionState{status: "running", connected: true, publicIP: "1.2.3.4"},
+			events: []event{
+				{Type: statusEvent, Time: now, Status: "running"},
+				{Type: connectedEvent, Time: now},
+				{Type: publicIPEvent, Time: now, IP: "1.2.3.4"},
+			},
+		},
+		"disconnected": {
+			previous:     connectionState{status: "running", connected: true},
+			current:      connectionState{status: "crashed"},
+			wasConnected: true,
+			events: []event{
+				{Type: statusEvent, Time: now, Status: "crashed"},
+				{Type: disconnectedEvent, Time: now},
+			},
+		},
+		"reconnected": {
+			previous:     connectionState{status: "running", publicIP: "1.2.3.4"},
+			current:      connectionState{status: "running", connected: true, publicIP: "5.6.7.8"},
+			wasConnected: true,
+			events: []event{
+				{Type: reconnectedEvent, Time: now},
+				{Type: publicIPEvent, Time: now, IP: "5.6.7.8"},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			events := stateEvents(testCase.previous, testCase.current, testCase.wasConnected, now)
+			assert.Equal(t, testCase.events, events)
+		})
+	}
+}
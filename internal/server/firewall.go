@@ -0,0 +1,65 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/qdm12/gluetun/internal/firewall"
+	"github.com/qdm12/golibs/logging"
+)
+
+func newFirewallHandler(
+	firewallConf firewall.Configurator,
+	logger logging.Logger) http.Handler {
+	return &firewallHandler{
+		firewallConf: firewallConf,
+		logger:       logger,
+	}
+}
+
+type firewallHandler struct {
+	firewallConf firewall.Configurator
+	logger       logging.Logger
+}
+
+func (h *firewallHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.RequestURI = strings.TrimPrefix(r.RequestURI, "/firewall")
+	path := strings.SplitN(r.RequestURI, "?", 2)[0] //nolint:gomnd
+	switch path {
+	case "/pause":
+		switch r.Method {
+		case http.MethodPost:
+			h.pause(w, r)
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	default:
+		http.Error(w, "", http.StatusNotFound)
+	}
+}
+
+func (h *firewallHandler) pause(w http.ResponseWriter, r *http.Request) {
+	secondsString := r.URL.Query().Get("seconds")
+	seconds, err := strconv.Atoi(secondsString)
+	if err != nil || seconds <= 0 {
+		errString := fmt.Sprintf("seconds %q is not a valid positive integer", secondsString)
+		http.Error(w, errString, http.StatusBadRequest)
+		return
+	}
+	duration := time.Duration(seconds) * time.Second
+	if err := h.firewallConf.Pause(r.Context(), duration); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	encoder := json.NewEncoder(w)
+	outcome := fmt.Sprintf("paused for %s", duration)
+	if err := encoder.Encode(outcomeWrapper{Outcome: outcome}); err != nil {
+		h.logger.Warn(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"strings"
 
 	"github.com/qdm12/gluetun/internal/dns"
+	"github.com/qdm12/gluetun/internal/firewall"
 	"github.com/qdm12/gluetun/internal/models"
 	"github.com/qdm12/gluetun/internal/openvpn"
 	"github.com/qdm12/gluetun/internal/publicip"
+	"github.com/qdm12/gluetun/internal/routing"
 	"github.com/qdm12/gluetun/internal/updater"
 	"github.com/qdm12/golibs/logging"
 )
@@ -18,6 +20,8 @@ func newHandler(logger logging.Logger, logging bool,
 	unboundLooper dns.Looper,
 	updaterLooper updater.Looper,
 	publicIPLooper publicip.Looper,
+	firewallConf firewall.Configurator,
+	routing routing.Routing,
 ) http.Handler {
 	handler := &handler{}
 
@@ -25,9 +29,15 @@ func newHandler(logger logging.Logger, logging bool,
 	dns := newDNSHandler(unboundLooper, logger)
 	updater := newUpdaterHandler(updaterLooper, logger)
 	publicip := newPublicIPHandler(publicIPLooper, logger)
+	firewall := newFirewallHandler(firewallConf, logger)
+	health := newHealthHandler(openvpnLooper, logger)
+	vpn := newVPNHandler(openvpnLooper, logger)
+	network := newNetworkHandler(routing, logger)
+	events := newEventsHandler(openvpnLooper, publicIPLooper, logger)
 
 	handler.v0 = newHandlerV0(logger, openvpnLooper, unboundLooper, updaterLooper)
-	handler.v1 = newHandlerV1(logger, buildInfo, openvpn, dns, updater, publicip)
+	handler.v1 = newHandlerV1(logger, buildInfo, openvpn, dns, updater, publicip, firewall, health, vpn, network,
+		events)
 
 	handlerWithLog := withLogMiddleware(handler, logger, logging)
 	handler.setLogEnabled = handlerWithLog.setEnabled
@@ -11,7 +11,7 @@ import (
 )
 
 func newHandlerV1(logger logging.Logger, buildInfo models.BuildInformation,
-	openvpn, dns, updater, publicip http.Handler) http.Handler {
+	openvpn, dns, updater, publicip, firewall, health, vpn, network, events http.Handler) http.Handler {
 	return &handlerV1{
 		logger:    logger,
 		buildInfo: buildInfo,
@@ -19,6 +19,11 @@ func newHandlerV1(logger logging.Logger, buildInfo models.BuildInformation,
 		dns:       dns,
 		updater:   updater,
 		publicip:  publicip,
+		firewall:  firewall,
+		health:    health,
+		vpn:       vpn,
+		network:   network,
+		events:    events,
 	}
 }
 
@@ -29,6 +34,11 @@ type handlerV1 struct {
 	dns       http.Handler
 	updater   http.Handler
 	publicip  http.Handler
+	firewall  http.Handler
+	health    http.Handler
+	vpn       http.Handler
+	network   http.Handler
+	events    http.Handler
 }
 
 func (h *handlerV1) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -43,12 +53,28 @@ func (h *handlerV1) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		h.updater.ServeHTTP(w, r)
 	case strings.HasPrefix(r.RequestURI, "/publicip"):
 		h.publicip.ServeHTTP(w, r)
+	case strings.HasPrefix(r.RequestURI, "/firewall"):
+		h.firewall.ServeHTTP(w, r)
+	case strings.HasPrefix(r.RequestURI, "/vpn"):
+		h.vpn.ServeHTTP(w, r)
+	case strings.HasPrefix(r.RequestURI, "/network"):
+		h.network.ServeHTTP(w, r)
+	case strings.HasPrefix(r.RequestURI, "/events"):
+		h.events.ServeHTTP(w, r)
+	case v1Path(r) == "/healthcheck", v1Path(r) == "/ready":
+		h.health.ServeHTTP(w, r)
 	default:
 		errString := fmt.Sprintf("%s %s not found", r.Method, r.RequestURI)
 		http.Error(w, errString, http.StatusNotFound)
 	}
 }
 
+// v1Path returns the URL path of the request without the /v1 prefix and
+// trailing slash, such that the query string does not affect matching.
+func v1Path(r *http.Request) string {
+	return strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1"), "/")
+}
+
 func (h *handlerV1) getVersion(w http.ResponseWriter) {
 	encoder := json.NewEncoder(w)
 	if err := encoder.Encode(h.buildInfo); err != nil {
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_v1Path(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		target string
+		path   string
+	}{
+		"healthcheck": {
+			target: "/v1/healthcheck",
+			path:   "/healthcheck",
+		},
+		"healthcheck with query": {
+			target: "/v1/healthcheck?x=1",
+			path:   "/healthcheck",
+		},
+		"ready with trailing slash": {
+			target: "/v1/ready/",
+			path:   "/ready",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			request := httptest.NewRequest(http.MethodGet, testCase.target, nil)
+			assert.Equal(t, testCase.path, v1Path(request))
+		})
+	}
+}
+
+func Test_healthHandler_healthcheckQuery(t *testing.T) {
+	t.Parallel()
+	handler := &handlerV1{health: &healthHandler{}}
+	request := httptest.NewRequest(http.MethodGet, "/v1/healthcheck?x=1", nil)
+	request.RequestURI = "/healthcheck?x=1" // as trimmed by the root handler
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
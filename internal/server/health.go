@@ -0,0 +1,51 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/qdm12/gluetun/internal/openvpn"
+	"github.com/qdm12/golibs/logging"
+)
+
+func newHealthHandler(looper openvpn.Looper, logger logging.Logger) http.Handler {
+	return &healthHandler{
+		looper: looper,
+		logger: logger,
+	}
+}
+
+// healthHandler serves the liveness endpoint /healthcheck, which succeeds
+// as long as the program is responsive, and the readiness endpoint /ready,
+// which only succeeds once the VPN is connected and its routes installed.
+type healthHandler struct {
+	looper openvpn.Looper
+	logger logging.Logger
+}
+
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "", http.StatusNotFound)
+		return
+	}
+	switch v1Path(r) {
+	case "/healthcheck":
+		h.writeStatus(w, http.StatusOK, "alive")
+	case "/ready":
+		if h.looper.IsConnected() {
+			h.writeStatus(w, http.StatusOK, "ready")
+		} else {
+			h.writeStatus(w, http.StatusServiceUnavailable, "not ready")
+		}
+	default:
+		http.Error(w, "", http.StatusNotFound)
+	}
+}
+
+func (h *healthHandler) writeStatus(w http.ResponseWriter, code int, status string) {
+	w.WriteHeader(code)
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(statusWrapper{Status: status}); err != nil {
+		h.logger.Warn(err)
+	}
+}
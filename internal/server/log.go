@@ -73,3 +73,11 @@ func (w *statefulResponseWriter) WriteHeader(statusCode int) {
 func (w *statefulResponseWriter) Header() http.Header {
 	return w.httpWriter.Header()
 }
+
+// Flush flushes the data written so far if the underlying writer supports
+// it, for streamed responses such as server-sent events.
+func (w *statefulResponseWriter) Flush() {
+	if flusher, ok := w.httpWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/qdm12/gluetun/internal/routing"
+	"github.com/qdm12/golibs/logging"
+)
+
+func newNetworkHandler(
+	routing routing.Routing,
+	logger logging.Logger) http.Handler {
+	return &networkHandler{
+		routing: routing,
+		logger:  logger,
+	}
+}
+
+type networkHandler struct {
+	routing routing.Routing
+	logger  logging.Logger
+}
+
+func (h *networkHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.RequestURI = strings.TrimPrefix(r.RequestURI, "/network")
+	switch r.RequestURI {
+	case "":
+		switch r.Method {
+		case http.MethodGet:
+			h.getNetwork(w)
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	default:
+		http.Error(w, "", http.StatusNotFound)
+	}
+}
+
+type networkWrapper struct {
+	Interfaces []routing.Interface `json:"interfaces"`
+	Routes     []routing.Route     `json:"routes"`
+}
+
+func (h *networkHandler) getNetwork(w http.ResponseWriter) {
+	var data networkWrapper
+	var err error
+	data.Interfaces, err = h.routing.Interfaces()
+	if err != nil {
+		h.logger.Warn(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	data.Routes, err = h.routing.Routes()
+	if err != nil {
+		h.logger.Warn(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(data); err != nil {
+		h.logger.Warn(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
@@ -1,7 +1,9 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -27,7 +29,7 @@ func (h *openvpnHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	case "/status":
 		switch r.Method {
 		case http.MethodGet:
-			h.getStatus(w)
+			h.getStatus(r.Context(), w)
 		case http.MethodPut:
 			h.setStatus(w, r)
 		default:
@@ -52,10 +54,18 @@ func (h *openvpnHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *openvpnHandler) getStatus(w http.ResponseWriter) {
+func (h *openvpnHandler) getStatus(ctx context.Context, w http.ResponseWriter) {
 	status := h.looper.GetStatus()
 	encoder := json.NewEncoder(w)
-	data := statusWrapper{Status: string(status)}
+	data := openvpnStatusWrapper{Status: string(status)}
+	stats, err := h.looper.GetStats(ctx)
+	switch {
+	case err == nil:
+		data.Stats = &stats
+	case errors.Is(err, openvpn.ErrManagementDisabled), errors.Is(err, openvpn.ErrNotConnected):
+	default:
+		h.logger.Warn(err)
+	}
 	if err := encoder.Encode(data); err != nil {
 		h.logger.Warn(err)
 		w.WriteHeader(http.StatusInternalServerError)
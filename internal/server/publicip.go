@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/qdm12/gluetun/internal/models"
 	"github.com/qdm12/gluetun/internal/publicip"
 	"github.com/qdm12/golibs/logging"
 )
@@ -39,13 +40,17 @@ func (h *publicIPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 }
 
 type publicIPWrapper struct {
-	PublicIP string `json:"public_ip"`
+	PublicIP  string            `json:"public_ip"`
+	PublicIPs []models.PublicIP `json:"public_ips"`
 }
 
 func (h *publicIPHandler) getPublicIP(w http.ResponseWriter) {
 	publicIP := h.looper.GetPublicIP()
 	encoder := json.NewEncoder(w)
-	data := publicIPWrapper{PublicIP: publicIP.String()}
+	data := publicIPWrapper{
+		PublicIP:  publicIP.String(),
+		PublicIPs: h.looper.GetPublicIPs(),
+	}
 	if err := encoder.Encode(data); err != nil {
 		h.logger.Warn(err)
 		w.WriteHeader(http.StatusInternalServerError)
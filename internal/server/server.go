@@ -2,14 +2,17 @@ package server
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"sync"
 	"time"
 
 	"github.com/qdm12/gluetun/internal/dns"
+	"github.com/qdm12/gluetun/internal/firewall"
 	"github.com/qdm12/gluetun/internal/models"
 	"github.com/qdm12/gluetun/internal/openvpn"
 	"github.com/qdm12/gluetun/internal/publicip"
+	"github.com/qdm12/gluetun/internal/routing"
 	"github.com/qdm12/gluetun/internal/updater"
 	"github.com/qdm12/golibs/logging"
 )
@@ -27,10 +30,11 @@ type server struct {
 func New(address string, logging bool, logger logging.Logger,
 	buildInfo models.BuildInformation,
 	openvpnLooper openvpn.Looper, unboundLooper dns.Looper,
-	updaterLooper updater.Looper, publicIPLooper publicip.Looper) Server {
+	updaterLooper updater.Looper, publicIPLooper publicip.Looper,
+	firewallConf firewall.Configurator, routing routing.Routing) Server {
 	serverLogger := logger.WithPrefix("http server: ")
 	handler := newHandler(serverLogger, logging, buildInfo,
-		openvpnLooper, unboundLooper, updaterLooper, publicIPLooper)
+		openvpnLooper, unboundLooper, updaterLooper, publicIPLooper, firewallConf, routing)
 	return &server{
 		address: address,
 		logger:  serverLogger,
@@ -40,7 +44,12 @@ func New(address string, logging bool, logger logging.Logger,
 
 func (s *server) Run(ctx context.Context, wg *sync.WaitGroup) {
 	defer wg.Done()
-	server := http.Server{Addr: s.address, Handler: s.handler}
+	server := http.Server{
+		Addr:    s.address,
+		Handler: s.handler,
+		// cancel the requests context on shutdown to end event streams
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
 	go func() {
 		<-ctx.Done()
 		s.logger.Warn("context canceled: shutting down")
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/qdm12/gluetun/internal/openvpn"
+	"github.com/qdm12/gluetun/internal/provider"
+	"github.com/qdm12/golibs/logging"
+)
+
+func newVPNHandler(looper openvpn.Looper, logger logging.Logger) http.Handler {
+	return &vpnHandler{
+		looper: looper,
+		logger: logger,
+	}
+}
+
+type vpnHandler struct {
+	looper openvpn.Looper
+	logger logging.Logger
+}
+
+func (h *vpnHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.RequestURI = strings.TrimPrefix(r.RequestURI, "/vpn")
+	path := strings.SplitN(r.RequestURI, "?", 2)[0] //nolint:gomnd
+	switch path {
+	case "/servers":
+		switch r.Method {
+		case http.MethodGet:
+			h.getServerChoices(w, r)
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	case "/selection":
+		switch r.Method {
+		case http.MethodPut:
+			h.setSelection(w, r)
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	default:
+		http.Error(w, "", http.StatusNotFound)
+	}
+}
+
+type serverChoices struct {
+	Regions   []string `json:"regions,omitempty"`
+	Groups    []string `json:"groups,omitempty"`
+	Countries []string `json:"countries,omitempty"`
+	Cities    []string `json:"cities,omitempty"`
+	ISPs      []string `json:"isps,omitempty"`
+	Hostnames []string `json:"hostnames,omitempty"`
+}
+
+func (h *vpnHandler) getServerChoices(w http.ResponseWriter, r *http.Request) {
+	provider := models.VPNProvider(strings.ToLower(r.URL.Query().Get("provider")))
+	choices, ok := getServerChoices(provider)
+	if !ok {
+		errString := fmt.Sprintf("provider %q not found", provider)
+		http.Error(w, errString, http.StatusNotFound)
+		return
+	}
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(choices); err != nil {
+		h.logger.Warn(err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func getServerChoices(provider models.VPNProvider) (choices serverChoices, ok bool) {
+	switch provider {
+	case constants.Cyberghost:
+		choices.Regions = constants.CyberghostRegionChoices()
+		choices.Groups = constants.CyberghostGroupChoices()
+	case constants.Mullvad:
+		choices.Countries = constants.MullvadCountryChoices()
+		choices.Cities = constants.MullvadCityChoices()
+		choices.ISPs = constants.MullvadISPChoices()
+	case constants.Nordvpn:
+		choices.Regions = constants.NordvpnRegionChoices()
+	case constants.PrivateInternetAccess, "pia":
+		choices.Regions = constants.PIAGeoChoices()
+	case constants.Privado:
+		choices.Hostnames = constants.PrivadoHostnameChoices()
+	case constants.Purevpn:
+		choices.Regions = constants.PurevpnRegionChoices()
+		choices.Countries = constants.PurevpnCountryChoices()
+		choices.Cities = constants.PurevpnCityChoices()
+	case constants.Surfshark:
+		choices.Regions = constants.SurfsharkRegionChoices()
+	case constants.Vyprvpn:
+		choices.Regions = constants.VyprvpnRegionChoices()
+	case constants.Windscribe:
+		choices.Regions = constants.WindscribeRegionChoices()
+		choices.Cities = constants.WindscribeCityChoices()
+		choices.Hostnames = constants.WindscribeHostnameChoices()
+	default:
+		return choices, false
+	}
+	return choices, true
+}
+
+// selectionWrapper contains the server filters replacing
+// the current ones of the server selection.
+type selectionWrapper struct {
+	Regions   []string `json:"regions"`
+	Group     string   `json:"group"`
+	Hostnames []string `json:"hostnames"`
+}
+
+func (h *vpnHandler) setSelection(w http.ResponseWriter, r *http.Request) {
+	decoder := json.NewDecoder(r.Body)
+	var data selectionWrapper
+	if err := decoder.Decode(&data); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	settings := h.looper.GetSettings()
+	selection, err := applySelection(settings.Provider.Name, settings.Provider.ServerSelection, data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// check a server matches the filters before reconnecting
+	providerConf := provider.New(settings.Provider.Name, h.looper.GetServers(), time.Now)
+	if _, err := providerConf.GetOpenVPNConnection(selection); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	settings.Provider.ServerSelection = selection
+	outcome := h.looper.SetSettings(settings)
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(outcomeWrapper{Outcome: outcome}); err != nil {
+		h.logger.Warn(err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+}
+
+// applySelection validates the filters against the choices of the provider
+// and returns the server selection with the filters replaced. The server
+// selection given is left unchanged if any filter is invalid.
+func applySelection(provider models.VPNProvider, selection models.ServerSelection,
+	data selectionWrapper) (newSelection models.ServerSelection, err error) {
+	choices, ok := getServerChoices(provider)
+	if !ok {
+		return newSelection, fmt.Errorf("provider %q does not support server filters", provider)
+	}
+	regions, err := matchChoices("region", data.Regions, choices.Regions)
+	if err != nil {
+		return newSelection, err
+	}
+	var group string
+	if len(data.Group) > 0 {
+		groups, err := matchChoices("group", []string{data.Group}, choices.Groups)
+		if err != nil {
+			return newSelection, err
+		}
+		group = groups[0]
+	}
+	hostnames, err := matchChoices("hostname", data.Hostnames, choices.Hostnames)
+	if err != nil {
+		return newSelection, err
+	}
+	newSelection = selection
+	newSelection.Regions = regions
+	newSelection.Group = group
+	newSelection.Hostnames = hostnames
+	return newSelection, nil
+}
+
+func matchChoices(filter string, values, choices []string) (matched []string, err error) {
+	if len(values) > 0 && len(choices) == 0 {
+		return nil, fmt.Errorf("filtering by %s is not supported by this provider", filter)
+	}
+	for _, value := range values {
+		found := false
+		for _, choice := range choices {
+			if strings.EqualFold(value, choice) {
+				matched = append(matched, choice)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%s %q is not valid: it can only be one of: %s",
+				filter, value, strings.Join(choices, ", "))
+		}
+	}
+	return matched, nil
+}
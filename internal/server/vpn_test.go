@@ -0,0 +1,58 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_applySelection(t *testing.T) {
+	t.Parallel()
+	region := constants.SurfsharkRegionChoices()[0]
+	testCases := map[string]struct {
+		provider  models.VPNProvider
+		selection models.ServerSelection
+		data      selectionWrapper
+		expected  models.ServerSelection
+		err       string
+	}{
+		"unknown provider": {
+			provider: "unknown",
+			err:      `provider "unknown" does not support server filters`,
+		},
+		"region matched case insensitively": {
+			provider:  constants.Surfshark,
+			selection: models.ServerSelection{Protocol: constants.UDP, Regions: []string{"x"}},
+			data:      selectionWrapper{Regions: []string{strings.ToUpper(region)}},
+			expected:  models.ServerSelection{Protocol: constants.UDP, Regions: []string{region}},
+		},
+		"invalid region": {
+			provider: constants.Surfshark,
+			data:     selectionWrapper{Regions: []string{"nowhere"}},
+			err:      `region "nowhere" is not valid: it can only be one of: ` + strings.Join(constants.SurfsharkRegionChoices(), ", "), //nolint:lll
+		},
+		"unsupported group filter": {
+			provider: constants.Surfshark,
+			data:     selectionWrapper{Group: "premium"},
+			err:      "filtering by group is not supported by this provider",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			selection, err := applySelection(testCase.provider, testCase.selection, testCase.data)
+			if len(testCase.err) > 0 {
+				require.EqualError(t, err, testCase.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, selection)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/models"
+	"github.com/qdm12/gluetun/internal/openvpn"
 )
 
 type statusWrapper struct {
@@ -23,6 +24,11 @@ func (sw *statusWrapper) getStatus() (status models.LoopStatus, err error) {
 	}
 }
 
+type openvpnStatusWrapper struct {
+	Status string         `json:"status"`
+	Stats  *openvpn.Stats `json:"stats,omitempty"`
+}
+
 type portWrapper struct {
 	Port uint16 `json:"port"`
 }
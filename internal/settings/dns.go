@@ -3,24 +3,75 @@ package settings
 import (
 	"fmt"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
 	unboundmodels "github.com/qdm12/dns/pkg/models"
 	unbound "github.com/qdm12/dns/pkg/unbound"
+	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/params"
 )
 
 // DNS contains settings to configure Unbound for DNS over TLS operation.
 type DNS struct { //nolint:maligned
-	Enabled           bool
-	PlaintextAddress  net.IP
-	KeepNameserver    bool
-	BlockMalicious    bool
-	BlockAds          bool
-	BlockSurveillance bool
-	UpdatePeriod      time.Duration
-	Unbound           unboundmodels.Settings
+	Enabled          bool
+	PlaintextAddress net.IP
+	KeepNameserver   bool
+	// KeepNameserverInterface is the interface to keep
+	// the original nameservers of in /etc/resolv.conf
+	KeepNameserverInterface string
+	BlockMalicious          bool
+	BlockAds                bool
+	BlockSurveillance       bool
+	UpdatePeriod            time.Duration
+	// BlockListsMirrors are base URLs to download the block
+	// lists from if the primary source fails.
+	BlockListsMirrors []string
+	// Threads is the number of threads Unbound uses.
+	Threads int
+	// RateLimit is the maximum number of queries per second
+	// allowed by Unbound, and 0 means no limit.
+	RateLimit int
+	// MaxQueries is the maximum number of queries each Unbound
+	// thread serves at the same time, and 0 keeps the Unbound default.
+	MaxQueries int
+	// TLSMinVersion is the minimum TLS version, 1.2 or 1.3,
+	// used to connect to the DNS over TLS upstream servers.
+	TLSMinVersion string
+	// CABundle is the file path of the certificate authorities
+	// bundle to verify the DNS over TLS upstream servers with,
+	// and is empty to use the system bundle.
+	CABundle string
+	// LogFile is the file path to write Unbound logs to,
+	// and is empty to log to the standard output.
+	LogFile string
+	// RootHints is the file path of the root hints file,
+	// and is empty to use the root hints built in Unbound.
+	RootHints string
+	// Prefetch is true if Unbound prefetches popular records
+	// about to expire, and only matters if Unbound caching is on.
+	Prefetch bool
+	// SoRcvBuf and SoSndBuf are the Unbound socket receive and send
+	// buffer sizes in bytes, and are 0 to keep the system defaults.
+	SoRcvBuf uint64
+	SoSndBuf uint64
+	// ServeTLS is true if Unbound also serves DNS over TLS
+	// to its clients on ServeTLSAddress.
+	ServeTLS        bool
+	ServeTLSAddress string
+	// ServeTLSCertFile and ServeTLSKeyFile are the file paths of the
+	// certificate and key to serve DNS over TLS with, and are empty
+	// to use a self-signed certificate.
+	ServeTLSCertFile string
+	ServeTLSKeyFile  string
+	// Stats is true if the Unbound remote control interface is
+	// enabled on a unix socket to obtain query statistics.
+	Stats bool
+	// IPFamily is the IP family used through the tunnel, and only
+	// the upstream servers addresses of this family are used.
+	IPFamily string
+	Unbound  unboundmodels.Settings
 }
 
 func (d *DNS) String() string {
@@ -69,12 +120,76 @@ func (d *DNS) lines() (lines []string) {
 	}
 	lines = append(lines, prefix+"Update: "+update)
 
+	if len(d.BlockListsMirrors) > 0 {
+		lines = append(lines, prefix+"Block lists mirrors: "+strings.Join(d.BlockListsMirrors, ", "))
+	}
+
+	if d.Threads > 0 {
+		lines = append(lines, prefix+"Threads: "+strconv.Itoa(d.Threads))
+	}
+
+	if d.RateLimit > 0 {
+		lines = append(lines, prefix+"Rate limit: "+strconv.Itoa(d.RateLimit)+" queries per second")
+	}
+
+	if d.MaxQueries > 0 {
+		lines = append(lines, prefix+"Maximum queries: "+strconv.Itoa(d.MaxQueries)+" per thread")
+	}
+
+	if len(d.TLSMinVersion) > 0 {
+		lines = append(lines, prefix+"Minimum TLS version: "+d.TLSMinVersion)
+	}
+
+	if len(d.CABundle) > 0 {
+		lines = append(lines, prefix+"CA bundle: "+d.CABundle)
+	}
+
+	if len(d.LogFile) > 0 {
+		lines = append(lines, prefix+"Log file: "+d.LogFile)
+	}
+
+	if len(d.RootHints) > 0 {
+		lines = append(lines, prefix+"Root hints: "+d.RootHints)
+	}
+
+	if d.Prefetch {
+		prefetch := "on"
+		if !d.Unbound.Caching {
+			prefetch += " (no effect since caching is off)"
+		}
+		lines = append(lines, prefix+"Prefetch: "+prefetch)
+	}
+
+	if d.SoRcvBuf > 0 {
+		lines = append(lines, prefix+"Socket receive buffer: "+strconv.FormatUint(d.SoRcvBuf, 10)+" bytes")
+	}
+
+	if d.SoSndBuf > 0 {
+		lines = append(lines, prefix+"Socket send buffer: "+strconv.FormatUint(d.SoSndBuf, 10)+" bytes")
+	}
+
+	if d.ServeTLS {
+		certificate := "self-signed"
+		if len(d.ServeTLSCertFile) > 0 {
+			certificate = d.ServeTLSCertFile
+		}
+		lines = append(lines, prefix+"Serve DNS over TLS: "+d.ServeTLSAddress+
+			" with certificate "+certificate)
+	}
+
+	if d.Stats {
+		lines = append(lines, prefix+"Statistics: on")
+	}
+
 	keepNameserver := "no"
 	if d.KeepNameserver {
 		keepNameserver = "yes"
 	}
 	lines = append(lines,
 		prefix+"Keep nameserver (disabled blocking): "+keepNameserver)
+	if len(d.KeepNameserverInterface) > 0 {
+		lines = append(lines, prefix+"Keep nameservers of interface: "+d.KeepNameserverInterface)
+	}
 
 	return lines
 }
@@ -95,6 +210,10 @@ func GetDNSSettings(paramsReader params.Reader) (settings DNS, err error) {
 	if err != nil {
 		return settings, err
 	}
+	settings.KeepNameserverInterface, err = paramsReader.GetDNSKeepNameserverInterface()
+	if err != nil {
+		return settings, err
+	}
 
 	// DNS over TLS external settings
 	settings.BlockMalicious, err = paramsReader.GetDNSMaliciousBlocking()
@@ -113,12 +232,74 @@ func GetDNSSettings(paramsReader params.Reader) (settings DNS, err error) {
 	if err != nil {
 		return settings, err
 	}
+	settings.BlockListsMirrors, err = paramsReader.GetDNSBlockListsMirrors()
+	if err != nil {
+		return settings, err
+	}
+	settings.Threads, err = paramsReader.GetDNSThreads()
+	if err != nil {
+		return settings, err
+	}
+	settings.RateLimit, err = paramsReader.GetDNSRateLimit()
+	if err != nil {
+		return settings, err
+	}
+	settings.MaxQueries, err = paramsReader.GetDNSMaxQueries()
+	if err != nil {
+		return settings, err
+	}
+	settings.TLSMinVersion, err = paramsReader.GetDNSOverTLSMinVersion()
+	if err != nil {
+		return settings, err
+	}
+	settings.LogFile, err = paramsReader.GetDNSLogFile()
+	if err != nil {
+		return settings, err
+	}
+	settings.CABundle, err = paramsReader.GetDNSOverTLSCABundle()
+	if err != nil {
+		return settings, err
+	}
+	settings.RootHints, err = paramsReader.GetDNSRootHints()
+	if err != nil {
+		return settings, err
+	}
+	settings.Prefetch, err = paramsReader.GetDNSPrefetch()
+	if err != nil {
+		return settings, err
+	}
+	settings.SoRcvBuf, settings.SoSndBuf, err = paramsReader.GetDNSSocketBuffers()
+	if err != nil {
+		return settings, err
+	}
+	settings.Stats, err = paramsReader.GetDNSStats()
+	if err != nil {
+		return settings, err
+	}
+	settings.ServeTLS, err = paramsReader.GetDNSServeTLS()
+	if err != nil {
+		return settings, err
+	}
+	if settings.ServeTLS {
+		settings.ServeTLSAddress, err = paramsReader.GetDNSServeTLSAddress()
+		if err != nil {
+			return settings, err
+		}
+		settings.ServeTLSCertFile, settings.ServeTLSKeyFile, err = paramsReader.GetDNSServeTLSCertificate()
+		if err != nil {
+			return settings, err
+		}
+	}
 
 	// Unbound specific settings
 	settings.Unbound, err = getUnboundSettings(paramsReader)
 	if err != nil {
 		return settings, err
 	}
+	settings.IPFamily, err = paramsReader.GetIPFamily()
+	if err != nil {
+		return settings, err
+	}
 
 	// Consistency check
 	IPv6Support := false
@@ -136,6 +317,9 @@ func GetDNSSettings(paramsReader params.Reader) (settings DNS, err error) {
 	if settings.Unbound.IPv6 && !IPv6Support {
 		return settings, fmt.Errorf("None of the DNS over TLS provider(s) set support IPv6")
 	}
+	if settings.IPFamily == constants.IPv6Family && !settings.Unbound.IPv6 {
+		return settings, fmt.Errorf("DOT_IPV6 cannot be off with the IP family %s", settings.IPFamily)
+	}
 	return settings, nil
 }
 
@@ -175,15 +359,9 @@ func getUnboundSettings(reader params.Reader) (settings unboundmodels.Settings,
 	if err != nil {
 		return settings, err
 	}
-	settings.AccessControl.Allowed = []net.IPNet{
-		{
-			IP:   net.IPv4zero,
-			Mask: net.IPv4Mask(0, 0, 0, 0),
-		},
-		{
-			IP:   net.IPv6zero,
-			Mask: net.IPMask{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
-		},
+	settings.AccessControl.Allowed, err = reader.GetDNSAccessControl()
+	if err != nil {
+		return settings, err
 	}
 	return settings, nil
 }
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
+	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/params"
 )
 
@@ -12,9 +14,22 @@ import (
 type Firewall struct {
 	VPNInputPorts   []uint16
 	InputPorts      []uint16
+	InputSources    []net.IPNet
 	OutboundSubnets []net.IPNet
 	Enabled         bool
 	Debug           bool
+	// Backend is the firewall backend to use, which can be
+	// iptables, nftables or auto.
+	Backend string
+	// RuleComment is the comment tagging each firewall rule
+	// set by the program.
+	RuleComment string
+	// UplinkInterface is the network interface to reach the VPN
+	// server through, and is empty to use the default route one.
+	UplinkInterface string
+	// StartupGrace is the duration to allow all traffic for
+	// at startup before enabling the firewall.
+	StartupGrace time.Duration
 }
 
 func (f *Firewall) String() string {
@@ -40,33 +55,72 @@ func (f *Firewall) String() string {
 		"Input ports: " + strings.Join(inputPorts, ", "),
 		"Outbound subnets: " + strings.Join(outboundSubnets, ", "),
 	}
+	if len(f.InputSources) > 0 {
+		inputSources := make([]string, len(f.InputSources))
+		for i := range f.InputSources {
+			inputSources[i] = f.InputSources[i].String()
+		}
+		settingsList = append(settingsList, "Input sources: "+strings.Join(inputSources, ", "))
+	}
 	if f.Debug {
 		settingsList = append(settingsList, "Debug: on")
 	}
+	if f.Backend != constants.AutoFirewallBackend {
+		settingsList = append(settingsList, "Backend: "+f.Backend)
+	}
+	if f.RuleComment != "gluetun" {
+		settingsList = append(settingsList, "Rule comment: "+f.RuleComment)
+	}
+	if len(f.UplinkInterface) > 0 {
+		settingsList = append(settingsList, "Uplink interface: "+f.UplinkInterface)
+	}
+	if f.StartupGrace > 0 {
+		settingsList = append(settingsList, "Startup grace: "+f.StartupGrace.String())
+	}
 	return strings.Join(settingsList, "\n |--")
 }
 
 // GetFirewallSettings obtains firewall settings from environment variables using the params package.
-func GetFirewallSettings(paramsReader params.Reader) (settings Firewall, err error) {
+func GetFirewallSettings(paramsReader params.Reader) (settings Firewall, warning string, err error) {
 	settings.VPNInputPorts, err = paramsReader.GetVPNInputPorts()
 	if err != nil {
-		return settings, err
+		return settings, "", err
 	}
 	settings.InputPorts, err = paramsReader.GetInputPorts()
 	if err != nil {
-		return settings, err
+		return settings, "", err
+	}
+	settings.InputSources, err = paramsReader.GetInputSources()
+	if err != nil {
+		return settings, "", err
 	}
 	settings.OutboundSubnets, err = paramsReader.GetOutboundSubnets()
 	if err != nil {
-		return settings, err
+		return settings, "", err
 	}
 	settings.Enabled, err = paramsReader.GetFirewall()
 	if err != nil {
-		return settings, err
+		return settings, "", err
 	}
 	settings.Debug, err = paramsReader.GetFirewallDebug()
 	if err != nil {
-		return settings, err
+		return settings, "", err
+	}
+	settings.Backend, err = paramsReader.GetFirewallBackend()
+	if err != nil {
+		return settings, "", err
+	}
+	settings.RuleComment, err = paramsReader.GetFirewallRuleComment()
+	if err != nil {
+		return settings, "", err
+	}
+	settings.UplinkInterface, err = paramsReader.GetUplinkInterface()
+	if err != nil {
+		return settings, "", err
+	}
+	settings.StartupGrace, warning, err = paramsReader.GetFirewallStartupGrace()
+	if err != nil {
+		return settings, warning, err
 	}
-	return settings, nil
+	return settings, warning, nil
 }
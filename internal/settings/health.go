@@ -0,0 +1,57 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/qdm12/gluetun/internal/params"
+)
+
+var ErrHealthQuorumTooHigh = errors.New("health quorum is higher than the number of health targets")
+
+// Health contains settings to configure the healthcheck.
+type Health struct {
+	IncludeDNS bool     `json:"include_dns"`
+	Targets    []string `json:"targets"`
+	Quorum     int      `json:"quorum"`
+}
+
+// GetHealthSettings obtains the healthcheck settings using the params functions.
+func GetHealthSettings(paramsReader params.Reader) (settings Health, err error) {
+	settings.IncludeDNS, err = paramsReader.GetHealthIncludeDNS()
+	if err != nil {
+		return settings, err
+	}
+	settings.Targets, err = paramsReader.GetHealthTargets()
+	if err != nil {
+		return settings, err
+	}
+	settings.Quorum, err = paramsReader.GetHealthQuorum()
+	if err != nil {
+		return settings, err
+	}
+	if settings.Quorum > len(settings.Targets) {
+		return settings, fmt.Errorf("%w: %d targets for a quorum of %d",
+			ErrHealthQuorumTooHigh, len(settings.Targets), settings.Quorum)
+	}
+	return settings, nil
+}
+
+func (h *Health) String() string {
+	includeDNS := disabled
+	if h.IncludeDNS {
+		includeDNS = enabled
+	}
+	settingsList := []string{
+		"Health settings:",
+		"DNS resolution check: " + includeDNS,
+	}
+	if len(h.Targets) > 0 {
+		settingsList = append(settingsList,
+			"Targets: "+strings.Join(h.Targets, ", "),
+			"Quorum: "+strconv.Itoa(h.Quorum))
+	}
+	return strings.Join(settingsList, "\n|--")
+}
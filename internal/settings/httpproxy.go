@@ -12,9 +12,16 @@ type HTTPProxy struct {
 	User     string
 	Password string
 	Port     uint16
-	Enabled  bool
-	Stealth  bool
-	Log      bool
+	// PortEnd is the end of the listening port range starting
+	// at Port, and is equal to Port if no range is used.
+	PortEnd uint16
+	Enabled bool
+	Stealth bool
+	Log     bool
+	// Sticky is true if each client should always exit through
+	// the same VPN server, chosen from its source IP address.
+	// It has no effect with the single VPN connection.
+	Sticky bool
 }
 
 func (h *HTTPProxy) String() string {
@@ -31,13 +38,20 @@ func (h *HTTPProxy) String() string {
 	if h.Stealth {
 		stealth = enabled
 	}
+	port := fmt.Sprintf("%d", h.Port)
+	if h.PortEnd > h.Port {
+		port = fmt.Sprintf("first available in %d-%d", h.Port, h.PortEnd)
+	}
 	settingsList := []string{
 		"HTTP proxy settings:",
-		fmt.Sprintf("Port: %d", h.Port),
+		"Port: " + port,
 		"Authentication: " + auth,
 		"Stealth: " + stealth,
 		"Log: " + log,
 	}
+	if h.Sticky {
+		settingsList = append(settingsList, "Sticky sessions: on (no effect with a single exit server)")
+	}
 	return strings.Join(settingsList, "\n |--")
 }
 
@@ -63,7 +77,11 @@ func GetHTTPProxySettings(paramsReader params.Reader) (settings HTTPProxy, warni
 	if err != nil {
 		return settings, "", err
 	}
-	settings.Port, warning, err = paramsReader.GetHTTPProxyPort()
+	settings.Sticky, err = paramsReader.GetHTTPProxySticky()
+	if err != nil {
+		return settings, "", err
+	}
+	settings.Port, settings.PortEnd, warning, err = paramsReader.GetHTTPProxyPort()
 	if err != nil {
 		return settings, warning, err
 	}
@@ -1,28 +1,144 @@
 package settings
 
 import (
+	"errors"
 	"fmt"
+	"net"
 	"strings"
+	"time"
 
 	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/models"
 	"github.com/qdm12/gluetun/internal/params"
 )
 
+var (
+	ErrHTTPProxyRequiresTCP   = errors.New("the HTTP pre-connect proxy can only be used with the TCP protocol")
+	ErrPersistTunRequiresRoot = errors.New(
+		"OpenVPN persist-tun can only be disabled when running OpenVPN as root, " +
+			"since OpenVPN without root privileges cannot recreate the tun device on a restart")
+	ErrCustomRequiresConfig = errors.New(
+		"the custom provider requires OPENVPN_CONFIG_TEMPLATE or OPENVPN_CONFIG_DIR " +
+			"to set the certificate authority")
+)
+
 // OpenVPN contains settings to configure the OpenVPN client.
 type OpenVPN struct {
-	User      string                  `json:"user"`
-	Password  string                  `json:"password"`
-	Verbosity int                     `json:"verbosity"`
-	MSSFix    uint16                  `json:"mssfix"`
-	Root      bool                    `json:"run_as_root"`
-	Cipher    string                  `json:"cipher"`
-	Auth      string                  `json:"auth"`
-	Provider  models.ProviderSettings `json:"provider"`
+	User               string                  `json:"user"`
+	Password           string                  `json:"password"`
+	Verbosity          int                     `json:"verbosity"`
+	MSSFix             *uint16                 `json:"mssfix,omitempty"`
+	MTUProbe           bool                    `json:"mtu_probe"`
+	Root               bool                    `json:"run_as_root"`
+	Cipher             string                  `json:"cipher"`
+	Auth               string                  `json:"auth"`
+	TUNDevice          models.Filepath         `json:"tun_device"`
+	ReconnectJitter    float64                 `json:"reconnect_jitter"`
+	ConfigTemplate     string                  `json:"config_template"`
+	ConfigFragments    []string                `json:"config_fragments"`
+	Compression        string                  `json:"compression"`
+	ExtraRoutes        []net.IPNet             `json:"extra_routes"`
+	ConnectTimeout     time.Duration           `json:"connect_timeout"`
+	AuthNoCache        bool                    `json:"auth_nocache"`
+	AuthRetry          string                  `json:"auth_retry"`
+	FastIO             bool                    `json:"fast_io"`
+	SndBuf             *uint64                 `json:"sndbuf,omitempty"`
+	RcvBuf             *uint64                 `json:"rcvbuf,omitempty"`
+	PreConnectProxy    models.PreConnectProxy  `json:"pre_connect_proxy"`
+	PullFilters        []models.PullFilter     `json:"pull_filters"`
+	IgnoreDNSPush      bool                    `json:"ignore_dns_push"`
+	ExplicitExitNotify bool                    `json:"explicit_exit_notify"`
+	ReachabilityCheck  int                     `json:"reachability_check"`
+	TLSVersionMin      string                  `json:"tls_version_min"`
+	Management         bool                    `json:"management"`
+	RouteNoPull        bool                    `json:"route_nopull"`
+	ResolvRetry        string                  `json:"resolv_retry"`
+	PersistTun         bool                    `json:"persist_tun"`
+	PersistKey         bool                    `json:"persist_key"`
+	RenegSec           *int                    `json:"reneg_sec,omitempty"`
+	ConnectRetry       *int                    `json:"connect_retry,omitempty"`
+	ConnectRetryMax    *int                    `json:"connect_retry_max,omitempty"`
+	RouteDelay         *int                    `json:"route_delay,omitempty"`
+	TunMTUExtra        *int                    `json:"tun_mtu_extra,omitempty"`
+	LogScrub           bool                    `json:"log_scrub"`
+	Scramble           string                  `json:"scramble"`
+	Keepalive          string                  `json:"keepalive"`
+	ConnectionLogFile  string                  `json:"connection_log_file"`
+	ConnectionLogSize  uint64                  `json:"connection_log_max_size"`
+	IPFamily           string                  `json:"ip_family"`
+	Provider           models.ProviderSettings `json:"provider"`
 }
 
 // GetOpenVPNSettings obtains the OpenVPN settings using the params functions.
-func GetOpenVPNSettings(paramsReader params.Reader, vpnProvider models.VPNProvider) (settings OpenVPN, err error) {
+func GetOpenVPNSettings(paramsReader params.Reader, vpnProvider models.VPNProvider) (
+	settings OpenVPN, warnings []string, err error) {
+	settings, err = getOpenVPNSettings(paramsReader, vpnProvider)
+	if err != nil {
+		return settings, nil, err
+	}
+	if settings.Compression == constants.LZO || settings.Compression == constants.LZ4 {
+		warnings = append(warnings, "OpenVPN compression "+settings.Compression+
+			" is enabled: this is insecure and exposes you to VORACLE-like attacks, "+
+			"only use it if your provider requires it")
+	}
+	warnings = append(warnings, exclusionWarnings(settings.Provider.ServerSelection)...)
+	if !settings.AuthNoCache {
+		warnings = append(warnings, "OpenVPN auth-nocache is disabled: "+
+			"your credentials may be kept in the OpenVPN process memory")
+	}
+	if settings.AuthRetry == constants.AuthRetryInteract {
+		warnings = append(warnings, "OpenVPN auth-retry interact prompts for the credentials "+
+			"on an authentication failure, but no terminal is attached to answer it")
+	}
+	if !settings.IgnoreDNSPush {
+		warning := "OpenVPN ignore DNS push is disabled: " +
+			"DNS servers pushed by the VPN server may be used and bypass the DNS server of the container"
+		const minPushLogVerbosity = 3
+		if settings.Verbosity < minPushLogVerbosity {
+			warning += ", and they are only detected and logged with OPENVPN_VERBOSITY of at least 3"
+		}
+		warnings = append(warnings, warning)
+	}
+	if settings.MTUProbe && settings.Provider.ServerSelection.Protocol == constants.TCP {
+		warnings = append(warnings, "OpenVPN MTU probe is enabled but only works with UDP")
+	}
+	if settings.ConnectRetry != nil && settings.ConnectRetryMax != nil &&
+		time.Duration(*settings.ConnectRetry)*time.Second*time.Duration(*settings.ConnectRetryMax) >=
+			settings.ConnectTimeout {
+		warnings = append(warnings, "OpenVPN connect retries take longer than the connection timeout "+
+			settings.ConnectTimeout.String()+": another server is tried before OpenVPN retries are exhausted")
+	}
+	if len(settings.Keepalive) > 0 {
+		warnings = append(warnings, "OpenVPN keepalive "+settings.Keepalive+
+			" takes precedence over the ping, ping-restart and ping-exit directives of the provider")
+	}
+	if settings.RouteNoPull && len(settings.ExtraRoutes) == 0 {
+		warnings = append(warnings, "OpenVPN route-nopull is enabled without VPN_ROUTES: "+
+			"no traffic will use the tunnel")
+	}
+	notify, err := paramsReader.GetOpenVPNExplicitExitNotify()
+	if err != nil {
+		return settings, warnings, err
+	}
+	isUDP := settings.Provider.ServerSelection.Protocol == constants.UDP
+	switch {
+	case notify == nil: // on by default for UDP
+		settings.ExplicitExitNotify = isUDP
+	case *notify && !isUDP:
+		warnings = append(warnings, "OpenVPN explicit exit notify is only supported "+
+			"with the UDP protocol and is ignored")
+	default:
+		settings.ExplicitExitNotify = *notify
+	}
+	if settings.Provider.ServerSelection.ConnectOrder == constants.PingOrder && isUDP {
+		warnings = append(warnings, "Server connect order ping is only supported "+
+			"with the TCP protocol, using the sequential order instead")
+		settings.Provider.ServerSelection.ConnectOrder = constants.SequentialOrder
+	}
+	return settings, warnings, nil
+}
+
+func getOpenVPNSettings(paramsReader params.Reader, vpnProvider models.VPNProvider) (settings OpenVPN, err error) {
 	settings.User, err = paramsReader.GetUser()
 	if err != nil {
 		return settings, err
@@ -57,6 +173,142 @@ func GetOpenVPNSettings(paramsReader params.Reader, vpnProvider models.VPNProvid
 	if err != nil {
 		return settings, err
 	}
+	settings.MTUProbe, err = paramsReader.GetVPNMTUProbe()
+	if err != nil {
+		return settings, err
+	}
+	settings.TUNDevice, err = paramsReader.GetTUNDevicePath()
+	if err != nil {
+		return settings, err
+	}
+	settings.ReconnectJitter, err = paramsReader.GetOpenVPNReconnectJitter()
+	if err != nil {
+		return settings, err
+	}
+	settings.ConfigTemplate, err = paramsReader.GetOpenVPNConfigTemplate()
+	if err != nil {
+		return settings, err
+	}
+	settings.ConfigFragments, err = paramsReader.GetOpenVPNConfigDir()
+	if err != nil {
+		return settings, err
+	}
+	settings.Compression, err = paramsReader.GetOpenVPNCompression()
+	if err != nil {
+		return settings, err
+	}
+	settings.ExtraRoutes, err = paramsReader.GetVPNExtraRoutes()
+	if err != nil {
+		return settings, err
+	}
+	settings.ConnectTimeout, err = paramsReader.GetOpenVPNConnectTimeout()
+	if err != nil {
+		return settings, err
+	}
+	settings.AuthNoCache, err = paramsReader.GetOpenVPNAuthNocache()
+	if err != nil {
+		return settings, err
+	}
+	settings.AuthRetry, err = paramsReader.GetOpenVPNAuthRetry()
+	if err != nil {
+		return settings, err
+	}
+	settings.PreConnectProxy, err = paramsReader.GetPreConnectProxy()
+	if err != nil {
+		return settings, err
+	}
+	settings.FastIO, err = paramsReader.GetOpenVPNFastIO()
+	if err != nil {
+		return settings, err
+	}
+	settings.SndBuf, err = paramsReader.GetOpenVPNSndBuf()
+	if err != nil {
+		return settings, err
+	}
+	settings.RcvBuf, err = paramsReader.GetOpenVPNRcvBuf()
+	if err != nil {
+		return settings, err
+	}
+	settings.PullFilters, err = paramsReader.GetOpenVPNPullFilters()
+	if err != nil {
+		return settings, err
+	}
+	settings.IgnoreDNSPush, err = paramsReader.GetOpenVPNIgnoreDNSPush()
+	if err != nil {
+		return settings, err
+	}
+	settings.ReachabilityCheck, err = paramsReader.GetOpenVPNReachabilityCheck()
+	if err != nil {
+		return settings, err
+	}
+	settings.TLSVersionMin, err = paramsReader.GetOpenVPNTLSVersionMin()
+	if err != nil {
+		return settings, err
+	}
+	settings.Management, err = paramsReader.GetOpenVPNManagement()
+	if err != nil {
+		return settings, err
+	}
+	settings.RouteNoPull, err = paramsReader.GetOpenVPNRouteNoPull()
+	if err != nil {
+		return settings, err
+	}
+	settings.ResolvRetry, err = paramsReader.GetOpenVPNResolvRetry()
+	if err != nil {
+		return settings, err
+	}
+	settings.PersistTun, err = paramsReader.GetOpenVPNPersistTun()
+	if err != nil {
+		return settings, err
+	}
+	settings.PersistKey, err = paramsReader.GetOpenVPNPersistKey()
+	if err != nil {
+		return settings, err
+	}
+	settings.RenegSec, err = paramsReader.GetOpenVPNRenegSec()
+	if err != nil {
+		return settings, err
+	}
+	settings.ConnectRetry, err = paramsReader.GetOpenVPNConnectRetry()
+	if err != nil {
+		return settings, err
+	}
+	settings.ConnectRetryMax, err = paramsReader.GetOpenVPNConnectRetryMax()
+	if err != nil {
+		return settings, err
+	}
+	settings.RouteDelay, err = paramsReader.GetOpenVPNRouteDelay()
+	if err != nil {
+		return settings, err
+	}
+	settings.TunMTUExtra, err = paramsReader.GetOpenVPNTunMTUExtra()
+	if err != nil {
+		return settings, err
+	}
+	settings.LogScrub, err = paramsReader.GetOpenVPNLogScrub()
+	if err != nil {
+		return settings, err
+	}
+	settings.Scramble, err = paramsReader.GetOpenVPNScramble()
+	if err != nil {
+		return settings, err
+	}
+	settings.Keepalive, err = paramsReader.GetOpenVPNKeepalive()
+	if err != nil {
+		return settings, err
+	}
+	settings.ConnectionLogFile, err = paramsReader.GetConnectionLogFile()
+	if err != nil {
+		return settings, err
+	}
+	settings.ConnectionLogSize, err = paramsReader.GetConnectionLogMaxSize()
+	if err != nil {
+		return settings, err
+	}
+	settings.IPFamily, err = paramsReader.GetIPFamily()
+	if err != nil {
+		return settings, err
+	}
 	switch vpnProvider {
 	case constants.PrivateInternetAccess:
 		settings.Provider, err = GetPIASettings(paramsReader)
@@ -76,10 +328,34 @@ func GetOpenVPNSettings(paramsReader params.Reader, vpnProvider models.VPNProvid
 		settings.Provider, err = GetPurevpnSettings(paramsReader)
 	case constants.Privado:
 		settings.Provider, err = GetPrivadoSettings(paramsReader)
+	case constants.Custom:
+		settings.Provider, err = GetCustomSettings(paramsReader)
 	default:
 		err = fmt.Errorf("VPN service provider %q is not valid", vpnProvider)
 	}
-	return settings, err
+	if err != nil {
+		return settings, err
+	}
+	settings.Provider.ServerSelection.Seed, err = paramsReader.GetServerSelectionSeed()
+	if err != nil {
+		return settings, err
+	}
+	settings.Provider.ServerSelection.ConnectOrder, err = paramsReader.GetServerConnectOrder()
+	if err != nil {
+		return settings, err
+	}
+	if settings.PreConnectProxy.Type == constants.HTTPProxy &&
+		settings.Provider.ServerSelection.Protocol != constants.TCP {
+		return settings, ErrHTTPProxyRequiresTCP
+	}
+	if !settings.PersistTun && !settings.Root {
+		return settings, ErrPersistTunRequiresRoot
+	}
+	if vpnProvider == constants.Custom &&
+		len(settings.ConfigTemplate) == 0 && len(settings.ConfigFragments) == 0 {
+		return settings, ErrCustomRequiresConfig
+	}
+	return settings, nil
 }
 
 func (o *OpenVPN) String() string {
@@ -93,8 +369,116 @@ func (o *OpenVPN) String() string {
 		"Password: [redacted]",
 		"Verbosity level: " + fmt.Sprintf("%d", o.Verbosity),
 		"Run as root: " + runAsRoot,
+		"Connection timeout: " + o.ConnectTimeout.String(),
+		"TUN device: " + string(o.TUNDevice),
 		o.Provider.String(),
 	}
+	if o.ReconnectJitter > 0 {
+		settingsList = append(settingsList, fmt.Sprintf("Reconnect jitter: %.2f", o.ReconnectJitter))
+	}
+	if len(o.ExtraRoutes) > 0 {
+		routes := make([]string, len(o.ExtraRoutes))
+		for i := range o.ExtraRoutes {
+			routes[i] = o.ExtraRoutes[i].String()
+		}
+		settingsList = append(settingsList, "Extra routes: "+strings.Join(routes, ", "))
+	}
+	if len(o.Compression) > 0 && o.Compression != constants.NoCompression {
+		settingsList = append(settingsList, "Compression: "+o.Compression)
+	}
+	if !o.AuthNoCache {
+		settingsList = append(settingsList, "Auth no cache: off")
+	}
+	if o.AuthRetry != constants.AuthRetryNoInteract {
+		settingsList = append(settingsList, "Auth retry: "+o.AuthRetry)
+	}
+	if len(o.PreConnectProxy.Type) > 0 {
+		settingsList = append(settingsList, "Pre-connect proxy: "+o.PreConnectProxy.String())
+	}
+	if o.MSSFix != nil {
+		settingsList = append(settingsList, fmt.Sprintf("MSS fix: %d bytes", *o.MSSFix))
+	}
+	if o.MTUProbe {
+		settingsList = append(settingsList, "MTU probe: on")
+	}
+	if o.ExplicitExitNotify {
+		settingsList = append(settingsList, "Explicit exit notify: on")
+	}
+	if o.ReachabilityCheck > 0 {
+		settingsList = append(settingsList, fmt.Sprintf("Reachability check: %d servers", o.ReachabilityCheck))
+	}
+	if o.FastIO {
+		settingsList = append(settingsList, "Fast IO: on")
+	}
+	if o.SndBuf != nil {
+		settingsList = append(settingsList, "Send buffer size: "+bufferSizeString(*o.SndBuf))
+	}
+	if o.RcvBuf != nil {
+		settingsList = append(settingsList, "Receive buffer size: "+bufferSizeString(*o.RcvBuf))
+	}
+	if len(o.PullFilters) > 0 {
+		filters := make([]string, len(o.PullFilters))
+		for i := range o.PullFilters {
+			filters[i] = o.PullFilters[i].String()
+		}
+		settingsList = append(settingsList, "Pull filters: "+strings.Join(filters, ", "))
+	}
+	if !o.IgnoreDNSPush {
+		settingsList = append(settingsList, "Ignore DNS push: off")
+	}
+	if len(o.ConfigTemplate) > 0 {
+		settingsList = append(settingsList, "Configuration template: yes")
+	}
+	if len(o.ConfigFragments) > 0 {
+		settingsList = append(settingsList, fmt.Sprintf("Configuration fragments: %d lines", len(o.ConfigFragments)))
+	}
+	if len(o.TLSVersionMin) > 0 {
+		settingsList = append(settingsList, "Minimum TLS version: "+o.TLSVersionMin)
+	}
+	if len(o.ResolvRetry) > 0 && o.ResolvRetry != "infinite" {
+		settingsList = append(settingsList, "Resolv retry: "+o.ResolvRetry+"s")
+	}
+	if o.RouteNoPull {
+		settingsList = append(settingsList, "Route no pull: on")
+	}
+	if o.RouteDelay != nil {
+		settingsList = append(settingsList, fmt.Sprintf("Route delay: %ds", *o.RouteDelay))
+	}
+	if o.TunMTUExtra != nil {
+		settingsList = append(settingsList, fmt.Sprintf("TUN MTU extra: %d bytes", *o.TunMTUExtra))
+	}
+	if !o.PersistTun {
+		settingsList = append(settingsList, "Persist tun: off")
+	}
+	if !o.PersistKey {
+		settingsList = append(settingsList, "Persist key: off")
+	}
+	if o.RenegSec != nil {
+		settingsList = append(settingsList, fmt.Sprintf("Renegotiation: every %ds", *o.RenegSec))
+	}
+	if len(o.Scramble) > 0 {
+		method := strings.Fields(o.Scramble)[0] // the argument may be secret
+		settingsList = append(settingsList, "Scramble: "+method)
+	}
+	if len(o.Keepalive) > 0 {
+		settingsList = append(settingsList, "Keepalive: "+o.Keepalive)
+	}
+	if len(o.ConnectionLogFile) > 0 {
+		settingsList = append(settingsList, fmt.Sprintf("Connection log: %s rotated at %d bytes",
+			o.ConnectionLogFile, o.ConnectionLogSize))
+	}
+	if !o.LogScrub {
+		settingsList = append(settingsList, "Log scrubbing: off")
+	}
+	if o.ConnectRetry != nil {
+		settingsList = append(settingsList, fmt.Sprintf("Connect retry: every %ds", *o.ConnectRetry))
+	}
+	if o.ConnectRetryMax != nil {
+		settingsList = append(settingsList, fmt.Sprintf("Connect retry max: %d attempts", *o.ConnectRetryMax))
+	}
+	if o.Management {
+		settingsList = append(settingsList, "Management interface: "+string(constants.OpenVPNManagementSocket))
+	}
 	if len(o.Cipher) > 0 {
 		settingsList = append(settingsList, "Custom cipher: "+o.Cipher)
 	}
@@ -103,3 +487,10 @@ func (o *OpenVPN) String() string {
 	}
 	return strings.Join(settingsList, "\n|--")
 }
+
+func bufferSizeString(size uint64) string {
+	if size == 0 {
+		return "operating system default"
+	}
+	return fmt.Sprintf("%d bytes", size)
+}
@@ -20,7 +20,7 @@ func Test_OpenVPN_JSON(t *testing.T) {
 	data, err := json.Marshal(in)
 	require.NoError(t, err)
 	//nolint:lll
-	assert.Equal(t, `{"user":"","password":"","verbosity":0,"mssfix":0,"run_as_root":true,"cipher":"","auth":"","provider":{"name":"name","server_selection":{"network_protocol":"","regions":null,"group":"","countries":null,"cities":null,"hostnames":null,"isps":null,"owned":false,"custom_port":0,"numbers":null,"encryption_preset":""},"extra_config":{"encryption_preset":"","openvpn_ipv6":false},"port_forwarding":{"enabled":false,"filepath":""}}}`, string(data))
+	assert.Equal(t, `{"user":"","password":"","verbosity":0,"mtu_probe":false,"run_as_root":true,"cipher":"","auth":"","tun_device":"","reconnect_jitter":0,"config_template":"","config_fragments":null,"compression":"","extra_routes":null,"connect_timeout":0,"auth_nocache":false,"auth_retry":"","fast_io":false,"pre_connect_proxy":{"type":"","host":"","port":0},"pull_filters":null,"ignore_dns_push":false,"explicit_exit_notify":false,"reachability_check":0,"tls_version_min":"","management":false,"route_nopull":false,"resolv_retry":"","persist_tun":false,"persist_key":false,"log_scrub":false,"scramble":"","keepalive":"","connection_log_file":"","connection_log_max_size":0,"ip_family":"","provider":{"name":"name","server_selection":{"network_protocol":"","connect_order":"","regions":null,"exclude_regions":null,"group":"","countries":null,"cities":null,"hostnames":null,"exclude_hostnames":null,"isps":null,"owned":false,"custom_port":0,"numbers":null,"custom_remotes":null,"encryption_preset":"","port_forward_only":false},"extra_config":{"encryption_preset":"","openvpn_ipv6":false},"port_forwarding":{"enabled":false,"filepath":"","renew_period":0,"data_filepath":""}}}`, string(data))
 	var out OpenVPN
 	err = json.Unmarshal(data, &out)
 	require.NoError(t, err)
@@ -1,13 +1,18 @@
 package settings
 
 import (
+	"errors"
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/qdm12/gluetun/internal/constants"
 	"github.com/qdm12/gluetun/internal/models"
 	"github.com/qdm12/gluetun/internal/params"
 )
 
+var ErrNoServerForContinent = errors.New("no server available for continent")
+
 // GetPIASettings obtains PIA settings from environment variables using the params package.
 func GetPIASettings(paramsReader params.Reader) (settings models.ProviderSettings, err error) {
 	settings.Name = constants.PrivateInternetAccess
@@ -29,6 +34,19 @@ func GetPIASettings(paramsReader params.Reader) (settings models.ProviderSetting
 	if err != nil {
 		return settings, err
 	}
+	settings.ServerSelection.Regions, err = mergeContinents(paramsReader,
+		settings.ServerSelection.Regions, constants.PIAGeoChoices())
+	if err != nil {
+		return settings, err
+	}
+	settings.ServerSelection.ExcludeRegions, err = paramsReader.GetRegionsExclusion(constants.PIAGeoChoices())
+	if err != nil {
+		return settings, err
+	}
+	settings.ServerSelection.PortForwardOnly, err = paramsReader.GetPortForwardingOnly()
+	if err != nil {
+		return settings, err
+	}
 	settings.PortForwarding.Enabled, err = paramsReader.GetPortForwarding()
 	if err != nil {
 		return settings, err
@@ -38,6 +56,16 @@ func GetPIASettings(paramsReader params.Reader) (settings models.ProviderSetting
 		if err != nil {
 			return settings, err
 		}
+		settings.PortForwarding.RenewPeriod, err = paramsReader.GetPortForwardingRenewPeriod()
+		if err != nil {
+			return settings, err
+		}
+		dataDir, err := paramsReader.GetDataDir()
+		if err != nil {
+			return settings, err
+		}
+		settings.PortForwarding.DataFilepath = models.Filepath(
+			filepath.Join(dataDir, constants.PIAPortForwardFilename))
 	}
 	return settings, nil
 }
@@ -57,6 +85,11 @@ func GetMullvadSettings(paramsReader params.Reader) (settings models.ProviderSet
 	if err != nil {
 		return settings, err
 	}
+	settings.ServerSelection.Countries, err = mergeContinents(paramsReader,
+		settings.ServerSelection.Countries, constants.MullvadCountryChoices())
+	if err != nil {
+		return settings, err
+	}
 	settings.ServerSelection.Cities, err = paramsReader.GetMullvadCities()
 	if err != nil {
 		return settings, err
@@ -108,6 +141,15 @@ func GetWindscribeSettings(paramsReader params.Reader) (settings models.Provider
 	if err != nil {
 		return settings, err
 	}
+	settings.ServerSelection.Regions, err = mergeContinents(paramsReader,
+		settings.ServerSelection.Regions, constants.WindscribeRegionChoices())
+	if err != nil {
+		return settings, err
+	}
+	settings.ServerSelection.ExcludeRegions, err = paramsReader.GetRegionsExclusion(constants.WindscribeRegionChoices())
+	if err != nil {
+		return settings, err
+	}
 	settings.ServerSelection.Cities, err = paramsReader.GetWindscribeCities()
 	if err != nil {
 		return settings, err
@@ -116,6 +158,10 @@ func GetWindscribeSettings(paramsReader params.Reader) (settings models.Provider
 	if err != nil {
 		return settings, err
 	}
+	settings.ServerSelection.ExcludeHostnames, err = paramsReader.GetHostnamesExclusion(constants.WindscribeHostnameChoices())
+	if err != nil {
+		return settings, err
+	}
 	settings.ServerSelection.CustomPort, err = paramsReader.GetWindscribePort(settings.ServerSelection.Protocol)
 	if err != nil {
 		return settings, err
@@ -138,6 +184,15 @@ func GetSurfsharkSettings(paramsReader params.Reader) (settings models.ProviderS
 	if err != nil {
 		return settings, err
 	}
+	settings.ServerSelection.Regions, err = mergeContinents(paramsReader,
+		settings.ServerSelection.Regions, constants.SurfsharkRegionChoices())
+	if err != nil {
+		return settings, err
+	}
+	settings.ServerSelection.ExcludeRegions, err = paramsReader.GetRegionsExclusion(constants.SurfsharkRegionChoices())
+	if err != nil {
+		return settings, err
+	}
 	return settings, nil
 }
 
@@ -168,6 +223,15 @@ func GetCyberghostSettings(paramsReader params.Reader) (settings models.Provider
 	if err != nil {
 		return settings, err
 	}
+	settings.ServerSelection.Regions, err = mergeContinents(paramsReader,
+		settings.ServerSelection.Regions, constants.CyberghostRegionChoices())
+	if err != nil {
+		return settings, err
+	}
+	settings.ServerSelection.ExcludeRegions, err = paramsReader.GetRegionsExclusion(constants.CyberghostRegionChoices())
+	if err != nil {
+		return settings, err
+	}
 	return settings, nil
 }
 
@@ -186,6 +250,15 @@ func GetVyprvpnSettings(paramsReader params.Reader) (settings models.ProviderSet
 	if err != nil {
 		return settings, err
 	}
+	settings.ServerSelection.Regions, err = mergeContinents(paramsReader,
+		settings.ServerSelection.Regions, constants.VyprvpnRegionChoices())
+	if err != nil {
+		return settings, err
+	}
+	settings.ServerSelection.ExcludeRegions, err = paramsReader.GetRegionsExclusion(constants.VyprvpnRegionChoices())
+	if err != nil {
+		return settings, err
+	}
 	return settings, nil
 }
 
@@ -204,6 +277,15 @@ func GetNordvpnSettings(paramsReader params.Reader) (settings models.ProviderSet
 	if err != nil {
 		return settings, err
 	}
+	settings.ServerSelection.Regions, err = mergeContinents(paramsReader,
+		settings.ServerSelection.Regions, constants.NordvpnRegionChoices())
+	if err != nil {
+		return settings, err
+	}
+	settings.ServerSelection.ExcludeRegions, err = paramsReader.GetRegionsExclusion(constants.NordvpnRegionChoices())
+	if err != nil {
+		return settings, err
+	}
 	settings.ServerSelection.Numbers, err = paramsReader.GetNordvpnNumbers()
 	if err != nil {
 		return settings, err
@@ -226,10 +308,19 @@ func GetPurevpnSettings(paramsReader params.Reader) (settings models.ProviderSet
 	if err != nil {
 		return settings, err
 	}
+	settings.ServerSelection.ExcludeRegions, err = paramsReader.GetRegionsExclusion(constants.PurevpnRegionChoices())
+	if err != nil {
+		return settings, err
+	}
 	settings.ServerSelection.Countries, err = paramsReader.GetPurevpnCountries()
 	if err != nil {
 		return settings, err
 	}
+	settings.ServerSelection.Countries, err = mergeContinents(paramsReader,
+		settings.ServerSelection.Countries, constants.PurevpnCountryChoices())
+	if err != nil {
+		return settings, err
+	}
 	settings.ServerSelection.Cities, err = paramsReader.GetPurevpnCities()
 	if err != nil {
 		return settings, err
@@ -252,5 +343,94 @@ func GetPrivadoSettings(paramsReader params.Reader) (settings models.ProviderSet
 	if err != nil {
 		return settings, err
 	}
+	settings.ServerSelection.ExcludeHostnames, err = paramsReader.GetHostnamesExclusion(constants.PrivadoHostnameChoices())
+	if err != nil {
+		return settings, err
+	}
+	return settings, nil
+}
+
+// GetCustomSettings obtains the custom provider settings from environment
+// variables using the params package.
+func GetCustomSettings(paramsReader params.Reader) (settings models.ProviderSettings, err error) {
+	settings.Name = constants.Custom
+	settings.ServerSelection.CustomRemotes, err = paramsReader.GetOpenVPNCustomRemotes()
+	if err != nil {
+		return settings, err
+	}
+	settings.ServerSelection.Protocol = settings.ServerSelection.CustomRemotes[0].Protocol
 	return settings, nil
 }
+
+// mergeContinents adds the choices matching the countries of the continents
+// obtained from the params reader to the selections given.
+func mergeContinents(paramsReader params.Reader, selections, choices []string) (
+	merged []string, err error) {
+	continents, err := paramsReader.GetContinents()
+	if err != nil {
+		return nil, err
+	}
+	merged = selections
+	for _, continent := range continents {
+		continentChoices := filterChoicesByCountries(choices, constants.ContinentCountries(continent))
+		if len(continentChoices) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNoServerForContinent, continent)
+		}
+		merged = appendUnique(merged, continentChoices...)
+	}
+	return merged, nil
+}
+
+func filterChoicesByCountries(choices, countries []string) (filtered []string) {
+	for _, choice := range choices {
+		for _, country := range countries {
+			if strings.EqualFold(choice, country) ||
+				strings.HasPrefix(strings.ToLower(choice), strings.ToLower(country)+" ") {
+				filtered = append(filtered, choice)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+func appendUnique(slice []string, values ...string) []string {
+	for _, value := range values {
+		if !containsFold(slice, value) {
+			slice = append(slice, value)
+		}
+	}
+	return slice
+}
+
+func containsFold(slice []string, value string) bool {
+	for _, element := range slice {
+		if strings.EqualFold(element, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// exclusionWarnings returns warnings for the excluded regions and hostnames
+// not matching any of the selected regions and hostnames, since excluding
+// them has no effect.
+func exclusionWarnings(selection models.ServerSelection) (warnings []string) {
+	if len(selection.Regions) > 0 {
+		for _, region := range selection.ExcludeRegions {
+			if !containsFold(selection.Regions, region) {
+				warnings = append(warnings, "excluded region "+region+
+					" does not match any of the selected regions")
+			}
+		}
+	}
+	if len(selection.Hostnames) > 0 {
+		for _, hostname := range selection.ExcludeHostnames {
+			if !containsFold(selection.Hostnames, hostname) {
+				warnings = append(warnings, "excluded hostname "+hostname+
+					" does not match any of the selected hostnames")
+			}
+		}
+	}
+	return warnings
+}
@@ -0,0 +1,92 @@
+package settings
+
+import (
+	"testing"
+
+	"github.com/qdm12/gluetun/internal/constants"
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_filterChoicesByCountries(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		choices   []string
+		countries []string
+		filtered  []string
+	}{
+		"no choices": {
+			countries: []string{"France"},
+		},
+		"exact and prefixed matches": {
+			choices:   []string{"Australia Perth", "Austria", "france", "Germany"},
+			countries: []string{"Australia", "France"},
+			filtered:  []string{"Australia Perth", "france"},
+		},
+		"no match": {
+			choices:   []string{"Germany"},
+			countries: []string{"France"},
+		},
+		"PIA regions with country codes": {
+			choices: []string{"AU Melbourne", "CA Toronto", "DE Berlin", "France",
+				"UK London", "US Texas", "US West"},
+			countries: constants.ContinentCountries("North America"),
+			filtered:  []string{"CA Toronto", "US Texas", "US West"},
+		},
+		"PIA regions of Europe": {
+			choices:   []string{"AU Sydney", "DE Frankfurt", "France", "UK Manchester", "US East"},
+			countries: constants.ContinentCountries("europe"),
+			filtered:  []string{"DE Frankfurt", "France", "UK Manchester"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			filtered := filterChoicesByCountries(testCase.choices, testCase.countries)
+			assert.Equal(t, testCase.filtered, filtered)
+		})
+	}
+}
+
+func Test_exclusionWarnings(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		selection models.ServerSelection
+		warnings  []string
+	}{
+		"no selection": {
+			selection: models.ServerSelection{ExcludeRegions: []string{"France"}},
+		},
+		"matching exclusions": {
+			selection: models.ServerSelection{
+				Regions:          []string{"France", "Germany"},
+				ExcludeRegions:   []string{"france"},
+				Hostnames:        []string{"a.com", "b.com"},
+				ExcludeHostnames: []string{"b.com"},
+			},
+		},
+		"exclusions matching nothing": {
+			selection: models.ServerSelection{
+				Regions:          []string{"France"},
+				ExcludeRegions:   []string{"Germany"},
+				Hostnames:        []string{"a.com"},
+				ExcludeHostnames: []string{"b.com"},
+			},
+			warnings: []string{
+				"excluded region Germany does not match any of the selected regions",
+				"excluded hostname b.com does not match any of the selected hostnames",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			warnings := exclusionWarnings(testCase.selection)
+			assert.Equal(t, testCase.warnings, warnings)
+		})
+	}
+}
@@ -10,8 +10,10 @@ import (
 )
 
 type PublicIP struct {
-	Period     time.Duration   `json:"period"`
-	IPFilepath models.Filepath `json:"ip_filepath"`
+	Period            time.Duration   `json:"period"`
+	IPFilepath        models.Filepath `json:"ip_filepath"`
+	Timeout           time.Duration   `json:"timeout"`
+	ReconnectOnChange bool            `json:"reconnect_on_change"`
 }
 
 func getPublicIPSettings(paramsReader params.Reader) (settings PublicIP, err error) {
@@ -23,6 +25,14 @@ func getPublicIPSettings(paramsReader params.Reader) (settings PublicIP, err err
 	if err != nil {
 		return settings, err
 	}
+	settings.Timeout, err = paramsReader.GetPublicIPTimeout()
+	if err != nil {
+		return settings, err
+	}
+	settings.ReconnectOnChange, err = paramsReader.GetReconnectOnIPChange()
+	if err != nil {
+		return settings, err
+	}
 	return settings, nil
 }
 
@@ -35,5 +45,12 @@ func (s *PublicIP) String() string {
 		fmt.Sprintf("Period: %s", s.Period),
 		fmt.Sprintf("IP file: %s", s.IPFilepath),
 	}
+	const defaultTimeout = 10 * time.Second
+	if s.Timeout != defaultTimeout {
+		settingsList = append(settingsList, fmt.Sprintf("Timeout: %s", s.Timeout))
+	}
+	if s.ReconnectOnChange {
+		settingsList = append(settingsList, "Reconnect on IP change: on")
+	}
 	return strings.Join(settingsList, "\n|--")
 }
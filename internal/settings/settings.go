@@ -25,6 +25,7 @@ type Settings struct {
 	PublicIP           PublicIP
 	VersionInformation bool
 	ControlServer      ControlServer
+	Health             Health
 }
 
 func (s *Settings) String() string {
@@ -43,6 +44,7 @@ func (s *Settings) String() string {
 		s.ControlServer.String(),
 		s.Updater.String(),
 		s.PublicIP.String(),
+		s.Health.String(),
 		"Version information: " + versionInformation,
 		"", // new line at the end
 	}, "\n")
@@ -55,17 +57,23 @@ func GetAllSettings(paramsReader params.Reader) (settings Settings, warnings []s
 	if err != nil {
 		return settings, nil, err
 	}
-	settings.OpenVPN, err = GetOpenVPNSettings(paramsReader, settings.VPNSP)
+	var openvpnWarnings []string
+	settings.OpenVPN, openvpnWarnings, err = GetOpenVPNSettings(paramsReader, settings.VPNSP)
+	warnings = append(warnings, openvpnWarnings...)
 	if err != nil {
-		return settings, nil, err
+		return settings, warnings, err
 	}
 	settings.DNS, err = GetDNSSettings(paramsReader)
 	if err != nil {
 		return settings, nil, err
 	}
-	settings.Firewall, err = GetFirewallSettings(paramsReader)
+	var firewallWarning string
+	settings.Firewall, firewallWarning, err = GetFirewallSettings(paramsReader)
+	if firewallWarning != "" {
+		warnings = append(warnings, firewallWarning)
+	}
 	if err != nil {
-		return settings, nil, err
+		return settings, warnings, err
 	}
 	settings.System, err = GetSystemSettings(paramsReader)
 	if err != nil {
@@ -83,6 +91,10 @@ func GetAllSettings(paramsReader params.Reader) (settings Settings, warnings []s
 	if err != nil {
 		return settings, nil, err
 	}
+	settings.Health, err = GetHealthSettings(paramsReader)
+	if err != nil {
+		return settings, nil, err
+	}
 
 	var warning string
 	settings.HTTPProxy, warning, err = GetHTTPProxySettings(paramsReader)
@@ -109,5 +121,11 @@ func GetAllSettings(paramsReader params.Reader) (settings Settings, warnings []s
 		return settings, warnings, err
 	}
 
+	unknownEnvWarnings, err := paramsReader.GetUnknownEnvWarnings()
+	warnings = append(warnings, unknownEnvWarnings...)
+	if err != nil {
+		return settings, warnings, err
+	}
+
 	return settings, warnings, nil
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/qdm12/gluetun/internal/models"
 	"github.com/qdm12/gluetun/internal/params"
 )
 
@@ -14,6 +15,9 @@ type ShadowSocks struct {
 	Port     uint16
 	Enabled  bool
 	Log      bool
+	// Users are the users each served on their own port, and
+	// replace Port and Password if set.
+	Users []models.ShadowSocksUser
 }
 
 func (s *ShadowSocks) String() string {
@@ -26,11 +30,20 @@ func (s *ShadowSocks) String() string {
 	}
 	settingsList := []string{
 		"ShadowSocks settings:",
-		"Password: [redacted]",
 		"Log: " + log,
-		fmt.Sprintf("Port: %d", s.Port),
 		"Method: " + s.Method,
 	}
+	if len(s.Users) == 0 {
+		settingsList = append(settingsList,
+			"Password: [redacted]",
+			fmt.Sprintf("Port: %d", s.Port))
+	} else {
+		ports := make([]string, len(s.Users))
+		for i, user := range s.Users {
+			ports[i] = fmt.Sprintf("%d", user.Port)
+		}
+		settingsList = append(settingsList, "Users ports: "+strings.Join(ports, ", "))
+	}
 	return strings.Join(settingsList, "\n |--")
 }
 
@@ -40,15 +53,19 @@ func GetShadowSocksSettings(paramsReader params.Reader) (settings ShadowSocks, w
 	if err != nil || !settings.Enabled {
 		return settings, "", err
 	}
-	settings.Password, err = paramsReader.GetShadowSocksPassword()
+	settings.Log, err = paramsReader.GetShadowSocksLog()
 	if err != nil {
 		return settings, "", err
 	}
-	settings.Log, err = paramsReader.GetShadowSocksLog()
+	settings.Method, err = paramsReader.GetShadowSocksMethod()
 	if err != nil {
 		return settings, "", err
 	}
-	settings.Method, err = paramsReader.GetShadowSocksMethod()
+	settings.Users, err = paramsReader.GetShadowSocksUsers()
+	if err != nil || len(settings.Users) > 0 {
+		return settings, "", err
+	}
+	settings.Password, err = paramsReader.GetShadowSocksPassword()
 	if err != nil {
 		return settings, "", err
 	}
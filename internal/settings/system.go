@@ -3,6 +3,7 @@ package settings
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/qdm12/gluetun/internal/params"
 )
@@ -12,6 +13,30 @@ type System struct {
 	PUID     int
 	PGID     int
 	Timezone string
+	// RandomizeHostname is true if the hostname should be
+	// set to a random value at startup.
+	RandomizeHostname bool
+	// IPFamily is the IP family used through the tunnel,
+	// which can be ipv4, ipv6 or dual.
+	IPFamily string
+	// DisableIPv6 is true if IPv6 should be disabled
+	// on the network interfaces at startup.
+	DisableIPv6 bool
+	// DataDir is the directory path to store data
+	// and generated files in.
+	DataDir string
+	// StorageLockTimeout is how long to wait for another
+	// process to release the servers data file.
+	StorageLockTimeout time.Duration
+	// SystemdNotify is true if systemd should be notified
+	// once the VPN is connected.
+	SystemdNotify bool
+	// SystemdNotifySocket is the systemd notification socket,
+	// and is empty if not running as a systemd service.
+	SystemdNotifySocket string
+	// StartupWaitNetwork is the maximum duration to wait
+	// for a default route at startup. Name resolution is not awaited.
+	StartupWaitNetwork time.Duration
 }
 
 // GetSystemSettings obtains the System settings using the params functions.
@@ -28,6 +53,38 @@ func GetSystemSettings(paramsReader params.Reader) (settings System, err error)
 	if err != nil {
 		return settings, err
 	}
+	settings.RandomizeHostname, err = paramsReader.GetRandomizeHostname()
+	if err != nil {
+		return settings, err
+	}
+	settings.IPFamily, err = paramsReader.GetIPFamily()
+	if err != nil {
+		return settings, err
+	}
+	settings.DisableIPv6, err = paramsReader.GetDisableIPv6()
+	if err != nil {
+		return settings, err
+	}
+	settings.DataDir, err = paramsReader.GetDataDir()
+	if err != nil {
+		return settings, err
+	}
+	settings.StorageLockTimeout, err = paramsReader.GetStorageLockTimeout()
+	if err != nil {
+		return settings, err
+	}
+	settings.StartupWaitNetwork, err = paramsReader.GetStartupWaitForNetwork()
+	if err != nil {
+		return settings, err
+	}
+	settings.SystemdNotify, err = paramsReader.GetSystemdNotify()
+	if err != nil {
+		return settings, err
+	}
+	settings.SystemdNotifySocket, err = paramsReader.GetSystemdNotifySocket()
+	if err != nil {
+		return settings, err
+	}
 	return settings, nil
 }
 
@@ -37,6 +94,24 @@ func (s *System) String() string {
 		fmt.Sprintf("Process user ID: %d", s.PUID),
 		fmt.Sprintf("Process group ID: %d", s.PGID),
 		fmt.Sprintf("Timezone: %s", s.Timezone),
+		"Data directory: " + s.DataDir,
+		"IP family: " + s.IPFamily,
+	}
+	if s.RandomizeHostname {
+		settingsList = append(settingsList, "Randomize hostname: on")
+	}
+	if s.DisableIPv6 {
+		settingsList = append(settingsList, "Disable IPv6: on")
+	}
+	const defaultStorageLockTimeout = 10 * time.Second
+	if s.StorageLockTimeout != defaultStorageLockTimeout {
+		settingsList = append(settingsList, "Storage lock timeout: "+s.StorageLockTimeout.String())
+	}
+	if s.StartupWaitNetwork > 0 {
+		settingsList = append(settingsList, "Startup wait for network: "+s.StartupWaitNetwork.String())
+	}
+	if s.SystemdNotify {
+		settingsList = append(settingsList, "Systemd notify: on")
 	}
 	return strings.Join(settingsList, "\n|--")
 }
@@ -11,6 +11,7 @@ import (
 type Updater struct {
 	Period     time.Duration `json:"period"`
 	DNSAddress string        `json:"dns_address"`
+	Mirror     string        `json:"mirror"`
 	Cyberghost bool          `json:"cyberghost"`
 	Mullvad    bool          `json:"mullvad"`
 	Nordvpn    bool          `json:"nordvpn"`
@@ -40,7 +41,11 @@ func GetUpdaterSettings(paramsReader params.Reader) (settings Updater, err error
 		CLI:        false,
 		DNSAddress: "127.0.0.1",
 	}
-	settings.Period, err = paramsReader.GetUpdaterPeriod()
+	settings.Period, err = paramsReader.GetServersUpdatePeriod()
+	if err != nil {
+		return settings, err
+	}
+	settings.Mirror, err = paramsReader.GetUpdaterMirror()
 	if err != nil {
 		return settings, err
 	}
@@ -55,5 +60,8 @@ func (s *Updater) String() string {
 		"Server updater settings:",
 		fmt.Sprintf("Period: %s", s.Period),
 	}
+	if len(s.Mirror) > 0 {
+		settingsList = append(settingsList, "Mirror: "+s.Mirror)
+	}
 	return strings.Join(settingsList, "\n|--")
 }
@@ -85,7 +85,11 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 
 	for ctx.Err() == nil {
 		settings := l.GetSettings()
-		server, err := shadowsockslib.NewServer(settings.Method, settings.Password, adaptLogger(l.logger, settings.Log))
+		users := settings.Users
+		if len(users) == 0 {
+			users = []models.ShadowSocksUser{{Port: settings.Port, Password: settings.Password}}
+		}
+		servers, err := newServers(settings.Method, users, adaptLogger(l.logger, settings.Log))
 		if err != nil {
 			crashed = true
 			l.logAndWait(ctx, err)
@@ -96,7 +100,7 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 
 		waitError := make(chan error)
 		go func() {
-			waitError <- server.Listen(shadowsocksCtx, fmt.Sprintf("0.0.0.0:%d", settings.Port))
+			waitError <- listenAll(shadowsocksCtx, servers, users)
 		}()
 		if err != nil {
 			crashed = true
@@ -151,3 +155,35 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 		}
 	}
 }
+
+func newServers(method string, users []models.ShadowSocksUser,
+	logger *logAdapter) (servers []shadowsockslib.Server, err error) {
+	servers = make([]shadowsockslib.Server, len(users))
+	for i, user := range users {
+		servers[i], err = shadowsockslib.NewServer(method, user.Password, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return servers, nil
+}
+
+// listenAll runs a server for each user on its port until the context
+// is canceled or one of the servers fails, and returns the first error.
+func listenAll(ctx context.Context, servers []shadowsockslib.Server,
+	users []models.ShadowSocksUser) (err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errs := make(chan error, len(servers))
+	for i := range servers {
+		go func(server shadowsockslib.Server, port uint16) {
+			errs <- server.Listen(ctx, fmt.Sprintf("0.0.0.0:%d", port))
+		}(servers[i], users[i].Port)
+	}
+	err = <-errs
+	cancel()
+	for i := 1; i < len(servers); i++ {
+		<-errs
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	nativeos "os"
+	"syscall"
+	"time"
+)
+
+var ErrLockTimeout = errors.New("timed out waiting for the servers file lock")
+
+const lockRetryPeriod = 100 * time.Millisecond
+
+// lock takes an exclusive lock on a lock file next to the servers file,
+// retrying until the lock timeout if another process holds it. The kernel
+// releases the lock if the process dies, so a lock file left behind does
+// not block later starts. If the lock file cannot be created because the
+// data directory is read only, no lock is taken. It returns a function to
+// release the lock.
+func (s *storage) lock() (unlock func(), err error) {
+	noop := func() {}
+	if s.filepath == "" {
+		return noop, nil
+	}
+	lockPath := s.filepath + ".lock"
+	file, err := nativeos.OpenFile(lockPath, nativeos.O_CREATE|nativeos.O_RDWR, 0644)
+	if errors.Is(err, syscall.EROFS) || errors.Is(err, syscall.EACCES) {
+		s.logger.Warn("cannot create servers file lock, continuing without lock: %s", err)
+		return noop, nil
+	} else if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(s.lockTimeout)
+	for {
+		err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			break
+		} else if !errors.Is(err, syscall.EWOULDBLOCK) {
+			_ = file.Close()
+			return nil, fmt.Errorf("cannot lock %s: %w", lockPath, err)
+		} else if time.Now().After(deadline) {
+			_ = file.Close()
+			return nil, fmt.Errorf("%w: %s is still locked after %s", ErrLockTimeout, lockPath, s.lockTimeout)
+		}
+		time.Sleep(lockRetryPeriod)
+	}
+	unlock = func() {
+		if err := syscall.Flock(int(file.Fd()), syscall.LOCK_UN); err != nil {
+			s.logger.Warn("cannot release servers file lock: %s", err)
+		}
+		if err := file.Close(); err != nil {
+			s.logger.Warn("cannot close servers file lock: %s", err)
+		}
+	}
+	return unlock, nil
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/qdm12/golibs/logging"
+	"github.com/qdm12/golibs/os"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_storage_lock(t *testing.T) {
+	t.Parallel()
+	logger, err := logging.NewEmptyLogger()
+	require.NoError(t, err)
+	s := &storage{
+		os:          os.New(),
+		logger:      logger,
+		filepath:    filepath.Join(t.TempDir(), "servers.json"),
+		lockTimeout: time.Millisecond,
+	}
+
+	unlock, err := s.lock()
+	require.NoError(t, err)
+
+	_, err = s.lock()
+	assert.ErrorIs(t, err, ErrLockTimeout)
+
+	unlock()
+	unlock, err = s.lock()
+	require.NoError(t, err)
+	unlock()
+}
+
+func Test_storage_lock_stale(t *testing.T) {
+	t.Parallel()
+	logger, err := logging.NewEmptyLogger()
+	require.NoError(t, err)
+	s := &storage{
+		os:          os.New(),
+		logger:      logger,
+		filepath:    filepath.Join(t.TempDir(), "servers.json"),
+		lockTimeout: time.Millisecond,
+	}
+
+	// lock file left behind by a killed process
+	err = ioutil.WriteFile(s.filepath+".lock", nil, 0644)
+	require.NoError(t, err)
+
+	unlock, err := s.lock()
+	require.NoError(t, err)
+	unlock()
+}
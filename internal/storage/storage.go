@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"time"
+
 	"github.com/qdm12/gluetun/internal/models"
 	"github.com/qdm12/golibs/logging"
 	"github.com/qdm12/golibs/os"
@@ -13,15 +15,17 @@ type Storage interface {
 }
 
 type storage struct {
-	os       os.OS
-	logger   logging.Logger
-	filepath string
+	os          os.OS
+	logger      logging.Logger
+	filepath    string
+	lockTimeout time.Duration
 }
 
-func New(logger logging.Logger, os os.OS, filepath string) Storage {
+func New(logger logging.Logger, os os.OS, filepath string, lockTimeout time.Duration) Storage {
 	return &storage{
-		os:       os,
-		logger:   logger.WithPrefix("storage: "),
-		filepath: filepath,
+		os:          os,
+		logger:      logger.WithPrefix("storage: "),
+		filepath:    filepath,
+		lockTimeout: lockTimeout,
 	}
 }
@@ -30,6 +30,12 @@ func countServers(allServers models.AllServers) int {
 
 func (s *storage) SyncServers(hardcodedServers models.AllServers) (
 	allServers models.AllServers, err error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return allServers, err
+	}
+	defer unlock()
+
 	serversOnFile, err := s.readFromFile(s.filepath)
 	if err != nil {
 		return allServers, fmt.Errorf("%w: %s", ErrCannotReadFile, err)
@@ -53,7 +59,7 @@ func (s *storage) SyncServers(hardcodedServers models.AllServers) (
 		return allServers, nil
 	}
 
-	if err := s.FlushToFile(allServers); err != nil {
+	if err := s.flushToFile(allServers); err != nil {
 		return allServers, fmt.Errorf("%w: %s", ErrCannotWriteFile, err)
 	}
 	return allServers, nil
@@ -66,18 +72,34 @@ func (s *storage) readFromFile(filepath string) (servers models.AllServers, err
 	} else if err != nil {
 		return servers, err
 	}
-	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(&servers); err != nil {
+	servers, err = decodeServers(file)
+	if err != nil {
 		_ = file.Close()
-		if errors.Is(err, io.EOF) {
-			return servers, nil
-		}
 		return servers, err
 	}
 	return servers, file.Close()
 }
 
+// decodeServers decodes the servers data from the reader,
+// and returns no server if there is no data.
+func decodeServers(reader io.Reader) (servers models.AllServers, err error) {
+	decoder := json.NewDecoder(reader)
+	if err := decoder.Decode(&servers); err != nil && !errors.Is(err, io.EOF) {
+		return servers, err
+	}
+	return servers, nil
+}
+
 func (s *storage) FlushToFile(servers models.AllServers) error {
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return s.flushToFile(servers)
+}
+
+func (s *storage) flushToFile(servers models.AllServers) error {
 	file, err := s.os.OpenFile(s.filepath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 	if err != nil {
 		return err
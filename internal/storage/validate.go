@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+
+	"github.com/qdm12/gluetun/internal/models"
+)
+
+var (
+	ErrDecodeServers = errors.New("cannot decode servers")
+	ErrServerInvalid = errors.New("invalid server")
+)
+
+// ValidateServers decodes the servers data from the reader as SyncServers
+// does for the servers file, and returns an error for each problem found.
+func ValidateServers(reader io.Reader) (errs []error) {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return []error{err}
+	}
+	servers, err := decodeServers(bytes.NewReader(data))
+	if err != nil {
+		return []error{decodeError(data, err)}
+	}
+	return validateServers(servers)
+}
+
+func decodeError(data []byte, err error) error {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return fmt.Errorf("%w: %s", ErrDecodeServers, err)
+	}
+	// the offset is just after the byte causing the error
+	line, column := offsetPosition(data, offset-1)
+	return fmt.Errorf("%w: line %d column %d: %s", ErrDecodeServers, line, column, err)
+}
+
+// offsetPosition returns the line and column numbers, both starting at 1,
+// of the byte offset in the data.
+func offsetPosition(data []byte, offset int64) (line, column int) {
+	if offset < 0 {
+		offset = 0
+	} else if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	line, column = 1, 1
+	for _, b := range data[:offset] {
+		if b == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return line, column
+}
+
+func validateServers(servers models.AllServers) (errs []error) { //nolint:gocognit,gocyclo
+	check := func(provider string, index int, ok bool, problem string) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: %s.servers[%d]: %s", ErrServerInvalid, provider, index, problem))
+		}
+	}
+	const (
+		noRegion   = "region is empty"
+		noHostname = "hostname is empty"
+		noIP       = "no IP address"
+	)
+	for i, server := range servers.Cyberghost.Servers {
+		check("cyberghost", i, len(server.Region) > 0, noRegion)
+		check("cyberghost", i, hasIP(server.IPs...), noIP)
+	}
+	for i, server := range servers.Mullvad.Servers {
+		check("mullvad", i, len(server.Country) > 0, "country is empty")
+		check("mullvad", i, hasIP(append(server.IPs, server.IPsV6...)...), noIP)
+	}
+	for i, server := range servers.Nordvpn.Servers {
+		check("nordvpn", i, len(server.Region) > 0, noRegion)
+		check("nordvpn", i, hasIP(server.IP), noIP)
+		check("nordvpn", i, server.TCP || server.UDP, "neither TCP nor UDP is supported")
+	}
+	for i, server := range servers.Pia.Servers {
+		check("pia", i, len(server.Region) > 0, noRegion)
+		check("pia", i, hasIP(server.OpenvpnUDP.IPs...) || hasIP(server.OpenvpnTCP.IPs...), noIP)
+		check("pia", i, !hasIP(server.OpenvpnUDP.IPs...) || len(server.OpenvpnUDP.CN) > 0, "UDP common name is empty")
+		check("pia", i, !hasIP(server.OpenvpnTCP.IPs...) || len(server.OpenvpnTCP.CN) > 0, "TCP common name is empty")
+	}
+	for i, server := range servers.Privado.Servers {
+		check("privado", i, len(server.Hostname) > 0, noHostname)
+		check("privado", i, hasIP(server.IP), noIP)
+	}
+	for i, server := range servers.Purevpn.Servers {
+		check("purevpn", i, len(server.Region) > 0, noRegion)
+		check("purevpn", i, hasIP(server.IPs...), noIP)
+	}
+	for i, server := range servers.Surfshark.Servers {
+		check("surfshark", i, len(server.Region) > 0, noRegion)
+		check("surfshark", i, hasIP(server.IPs...), noIP)
+	}
+	for i, server := range servers.Vyprvpn.Servers {
+		check("vyprvpn", i, len(server.Region) > 0, noRegion)
+		check("vyprvpn", i, hasIP(server.IPs...), noIP)
+	}
+	for i, server := range servers.Windscribe.Servers {
+		check("windscribe", i, len(server.Hostname) > 0, noHostname)
+		check("windscribe", i, hasIP(server.IP), noIP)
+	}
+	return errs
+}
+
+func hasIP(ips ...net.IP) bool {
+	for _, ip := range ips {
+		if len(ip) > 0 {
+			return true
+		}
+	}
+	return false
+}
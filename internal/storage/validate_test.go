@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ValidateServers(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		data   string
+		errors []string
+	}{
+		"empty file": {},
+		"valid servers": {
+			data: `{"windscribe":{"servers":[{"region":"A","hostname":"a.b","ip":"1.2.3.4"}]}}`,
+		},
+		"syntax error": {
+			data:   "{\n  \"pia\": {,\n}",
+			errors: []string{"cannot decode servers: line 2 column 11: invalid character ',' looking for beginning of object key string"}, //nolint:lll
+		},
+		"unknown field ignored": {
+			data: `{"other":{}}`,
+		},
+		"invalid servers": {
+			data: `{"nordvpn":{"servers":[{"region":"A","ip":"1.2.3.4","tcp":true}]},` +
+				`"privado":{"servers":[{"hostname":"a.b"},{"ip":"1.2.3.4"}]}}`,
+			errors: []string{
+				"invalid server: privado.servers[0]: no IP address",
+				"invalid server: privado.servers[1]: hostname is empty",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			errs := ValidateServers(strings.NewReader(testCase.data))
+			var messages []string
+			for _, err := range errs {
+				messages = append(messages, err.Error())
+			}
+			assert.Equal(t, testCase.errors, messages)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// Package systemd implements the systemd readiness notification protocol.
+package systemd
+
+import (
+	"fmt"
+	"net"
+)
+
+// Notify sends the state, such as READY=1, to the systemd notification
+// socket given. It does nothing if the socket is empty.
+func Notify(socket, state string) error {
+	if len(socket) == 0 {
+		return nil
+	}
+	// an abstract socket starting with @ is handled by the net package
+	address := &net.UnixAddr{Name: socket, Net: "unixgram"}
+	conn, err := net.DialUnix(address.Net, nil, address)
+	if err != nil {
+		return fmt.Errorf("cannot notify systemd: %w", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(state)); err != nil {
+		return fmt.Errorf("cannot notify systemd: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package updater
+
+import "github.com/qdm12/gluetun/internal/models"
+
+// diffServers returns the number of servers added and removed
+// between the old and new servers given.
+func diffServers(oldServers, newServers models.AllServers) (added, removed int) {
+	oldKeys := serverKeys(oldServers)
+	newKeys := serverKeys(newServers)
+	for key := range newKeys {
+		if _, ok := oldKeys[key]; !ok {
+			added++
+		}
+	}
+	for key := range oldKeys {
+		if _, ok := newKeys[key]; !ok {
+			removed++
+		}
+	}
+	return added, removed
+}
+
+// serverKeys returns a set of unique keys identifying each server.
+func serverKeys(servers models.AllServers) (keys map[string]struct{}) {
+	keys = make(map[string]struct{})
+	for i := range servers.Cyberghost.Servers {
+		keys["cyberghost"+servers.Cyberghost.Servers[i].String()] = struct{}{}
+	}
+	for i := range servers.Mullvad.Servers {
+		keys["mullvad"+servers.Mullvad.Servers[i].String()] = struct{}{}
+	}
+	for i := range servers.Nordvpn.Servers {
+		keys["nordvpn"+servers.Nordvpn.Servers[i].String()] = struct{}{}
+	}
+	for i := range servers.Pia.Servers {
+		keys["pia"+servers.Pia.Servers[i].String()] = struct{}{}
+	}
+	for i := range servers.Privado.Servers {
+		keys["privado"+servers.Privado.Servers[i].String()] = struct{}{}
+	}
+	for i := range servers.Purevpn.Servers {
+		keys["purevpn"+servers.Purevpn.Servers[i].String()] = struct{}{}
+	}
+	for i := range servers.Surfshark.Servers {
+		keys["surfshark"+servers.Surfshark.Servers[i].String()] = struct{}{}
+	}
+	for i := range servers.Vyprvpn.Servers {
+		keys["vyprvpn"+servers.Vyprvpn.Servers[i].String()] = struct{}{}
+	}
+	for i := range servers.Windscribe.Servers {
+		keys["windscribe"+servers.Windscribe.Servers[i].String()] = struct{}{}
+	}
+	return keys
+}
@@ -0,0 +1,56 @@
+package updater
+
+import (
+	"net"
+	"testing"
+
+	"github.com/qdm12/gluetun/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_diffServers(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		oldServers models.AllServers
+		newServers models.AllServers
+		added      int
+		removed    int
+	}{
+		"no servers": {},
+		"same servers": {
+			oldServers: models.AllServers{Surfshark: models.SurfsharkServers{
+				Servers: []models.SurfsharkServer{{Region: "a", IPs: []net.IP{{1, 1, 1, 1}}}},
+			}},
+			newServers: models.AllServers{Surfshark: models.SurfsharkServers{
+				Servers: []models.SurfsharkServer{{Region: "a", IPs: []net.IP{{1, 1, 1, 1}}}},
+			}},
+		},
+		"added and removed servers": {
+			oldServers: models.AllServers{
+				Surfshark: models.SurfsharkServers{
+					Servers: []models.SurfsharkServer{{Region: "a"}, {Region: "b"}},
+				},
+			},
+			newServers: models.AllServers{
+				Surfshark: models.SurfsharkServers{
+					Servers: []models.SurfsharkServer{{Region: "b"}},
+				},
+				Vyprvpn: models.VyprvpnServers{
+					Servers: []models.VyprvpnServer{{Region: "a"}, {Region: "c"}},
+				},
+			},
+			added:   2,
+			removed: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			added, removed := diffServers(testCase.oldServers, testCase.newServers)
+			assert.Equal(t, testCase.added, added)
+			assert.Equal(t, testCase.removed, removed)
+		})
+	}
+}
@@ -29,6 +29,8 @@ type looper struct {
 	storage       storage.Storage
 	setAllServers func(allServers models.AllServers)
 	logger        logging.Logger
+	// State
+	servers models.AllServers
 	// Internal channels and locks
 	loopLock     sync.Mutex
 	start        chan struct{}
@@ -57,6 +59,7 @@ func NewLooper(settings settings.Updater, currentServers models.AllServers,
 		storage:       storage,
 		setAllServers: setAllServers,
 		logger:        loggerWithPrefix,
+		servers:       currentServers,
 		start:         make(chan struct{}),
 		running:       make(chan models.LoopStatus),
 		stop:          make(chan struct{}),
@@ -145,7 +148,9 @@ func (l *looper) Run(ctx context.Context, wg *sync.WaitGroup) {
 				}
 				runWg.Wait()
 				l.state.setStatusWithLock(constants.Completed)
-				l.logger.Info("Updated servers information")
+				added, removed := diffServers(l.servers, servers)
+				l.servers = servers
+				l.logger.Info("Updated servers information: %d servers added, %d servers removed", added, removed)
 			case err := <-errorCh:
 				close(serversCh)
 				runWg.Wait()
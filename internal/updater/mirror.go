@@ -0,0 +1,62 @@
+package updater
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/qdm12/golibs/network"
+)
+
+// mirrorClient is an HTTP client fetching each URL from the mirror
+// base URL instead, at the path made of the original host and path.
+type mirrorClient struct {
+	network.Client
+	mirror string
+}
+
+func newMirrorClient(client network.Client, mirror string) network.Client {
+	return &mirrorClient{
+		Client: client,
+		mirror: mirror,
+	}
+}
+
+func (m *mirrorClient) Get(ctx context.Context, rawURL string, setters ...network.GetSetter) (
+	content []byte, status int, err error) {
+	mirrorURL, err := mirrorURL(m.mirror, rawURL)
+	if err != nil {
+		return nil, 0, err
+	}
+	return m.Client.Get(ctx, mirrorURL, setters...)
+}
+
+func (m *mirrorClient) Do(request *http.Request) (content []byte, status int, err error) {
+	mirrorURL, err := mirrorURL(m.mirror, request.URL.String())
+	if err != nil {
+		return nil, 0, err
+	}
+	mirrorRequest := request.Clone(request.Context())
+	mirrorRequest.URL, err = url.Parse(mirrorURL)
+	if err != nil {
+		return nil, 0, err
+	}
+	mirrorRequest.Host = ""
+	return m.Client.Do(mirrorRequest)
+}
+
+// mirrorURL returns the URL under the mirror base URL, such that
+// https://api.mullvad.net/www/relays/openvpn/ is fetched from
+// <mirror>/api.mullvad.net/www/relays/openvpn/
+func mirrorURL(mirror, rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	mirrored := strings.TrimSuffix(mirror, "/") + "/" + u.Host + u.EscapedPath()
+	if len(u.RawQuery) > 0 {
+		mirrored += "?" + u.RawQuery
+	}
+	return mirrored, nil
+}
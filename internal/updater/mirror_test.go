@@ -0,0 +1,43 @@
+package updater
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_mirrorURL(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		mirror    string
+		rawURL    string
+		mirrorURL string
+	}{
+		"path": {
+			mirror:    "https://mirror.local/gluetun/",
+			rawURL:    "https://api.mullvad.net/www/relays/openvpn/",
+			mirrorURL: "https://mirror.local/gluetun/api.mullvad.net/www/relays/openvpn/",
+		},
+		"query": {
+			mirror:    "http://10.0.0.1:8080/",
+			rawURL:    "https://nordvpn.com/api/server?limit=10",
+			mirrorURL: "http://10.0.0.1:8080/nordvpn.com/api/server?limit=10",
+		},
+		"escaped path": {
+			mirror:    "https://mirror.local/",
+			rawURL:    "https://s3-us-west-1.amazonaws.com/heartbleed/windows/New+OVPN+Files.zip",
+			mirrorURL: "https://mirror.local/s3-us-west-1.amazonaws.com/heartbleed/windows/New+OVPN+Files.zip",
+		},
+	}
+
+	for name, testCase := range testCases {
+		testCase := testCase
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			mirrorURL, err := mirrorURL(testCase.mirror, testCase.rawURL)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.mirrorURL, mirrorURL)
+		})
+	}
+}
@@ -38,12 +38,16 @@ func New(settings settings.Updater, httpClient *http.Client,
 	}
 	resolver := newResolver(settings.DNSAddress)
 	const clientTimeout = 10 * time.Second
+	client := network.NewClient(clientTimeout)
+	if len(settings.Mirror) > 0 {
+		client = newMirrorClient(client, settings.Mirror)
+	}
 	return &updater{
 		logger:   logger,
 		timeNow:  time.Now,
 		println:  func(s string) { fmt.Println(s) },
 		lookupIP: newLookupIP(resolver),
-		client:   network.NewClient(clientTimeout),
+		client:   client,
 		options:  settings,
 		servers:  currentServers,
 	}